@@ -41,7 +41,7 @@ func run() error {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	router := gin.New()
-	sessions := session.NewSingleManager(e)
+	sessions := session.NewSingleManager(e, nil)
 	if _, err := rest.New(sessions, router); err != nil {
 		return fmt.Errorf("creating REST API: %w", err)
 	}
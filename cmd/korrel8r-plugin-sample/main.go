@@ -0,0 +1,44 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Command korrel8r-plugin-sample is an example korrel8r domain plugin, see package plugin.
+//
+// Usage:
+//
+//	korrel8r-plugin-sample [EVENTS_FILE]
+//
+// EVENTS_FILE is a JSON list of deployment events, see package plugin/sample.
+// Configure korrel8r to start it as a subprocess:
+//
+//	domains:
+//	  - name: deploy
+//	    plugin:
+//	      command: [korrel8r-plugin-sample, events.json]
+//	stores:
+//	  - domain: deploy
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/plugin"
+	"github.com/korrel8r/korrel8r/pkg/plugin/sample"
+)
+
+func main() {
+	log.SetPrefix("korrel8r-plugin-sample: ")
+	var events []sample.Event
+	if len(os.Args) > 1 {
+		b, err := os.ReadFile(os.Args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := json.Unmarshal(b, &events); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := plugin.Serve(sample.New(events)); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -26,6 +26,7 @@ func TestMain_list(t *testing.T) {
 	require.NoError(t, test.ExecError(err))
 	want := `
 alert     Prometheus/AlertManager alerts.
+change    recent changes to cluster resources.
 incident  cluster health incidents.
 k8s       Kubernetes resources.
 log       application, infrastructure, and audit logs.
@@ -50,6 +51,20 @@ func TestMain_get(t *testing.T) {
 	assert.Equal(t, "\"hello\"\n", string(out))
 }
 
+func TestMain_get_groupBy(t *testing.T) {
+	out, err := cliCommand(t, "get", "-o", "json", "--group-by", "x", `mock:foo:hello`).Output()
+	require.NoError(t, test.ExecError(err))
+	assert.JSONEq(t, `[{"fields":{"x":""},"count":1}]`, string(out))
+}
+
+func TestMain_get_project(t *testing.T) {
+	out, err := cliCommand(t, "get", "-o", "ndjson", "--project", "mock=x", `mock:foo:hello`).Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Equal(t, "\"hello\"\n", string(out), "non-JSON objects are not projected")
+	_, err = cliCommand(t, "get", "--project", "x[0]", `mock:foo:hello`).Output()
+	assert.Error(t, err)
+}
+
 func TestMain_rules(t *testing.T) {
 	for _, x := range []struct {
 		args    []string
@@ -85,11 +100,72 @@ func TestMain_rules(t *testing.T) {
 	}
 }
 
+func TestMain_run(t *testing.T) {
+	out, err := cliCommand(t, "run").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Contains(t, string(out), "name: foo-to-bar")
+
+	out, err = cliCommand(t, "run", "foo-to-bar", "--param", "foo=x").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Contains(t, string(out), "query: mock:bar:y")
+
+	// "recipe" is an alias for "run".
+	out, err = cliCommand(t, "recipe", "foo-to-bar", "-p", "foo=x").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Contains(t, string(out), "query: mock:bar:y")
+
+	_, err = cliCommand(t, "run", "foo-to-bar", "--param", "nonsense=x").Output()
+	assert.Error(t, err)
+
+	out, err = cliCommand(t, "run", "foo-to-bar", "--results", "--rank").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Contains(t, string(out), "resultScores:")
+}
+
+func TestMain_resolve(t *testing.T) {
+	// The test configuration only has a mock store, so there are no candidates.
+	out, err := cliCommand(t, "resolve", "pod foo/bar").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Equal(t, "[]", strings.TrimSpace(string(out)))
+}
+
+func TestMain_timeline(t *testing.T) {
+	// Mock objects loaded from a file have no time, so the timeline is empty.
+	out, err := cliCommand(t, "timeline", "-q", "mock:foo:x").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Equal(t, "entries: []", strings.TrimSpace(string(out)))
+
+	_, err = cliCommand(t, "timeline", "-q", "mock:foo:x", "nonesuch:goal").Output()
+	assert.Error(t, err)
+}
+
+func TestMain_rootcause(t *testing.T) {
+	// Mock objects loaded from a file have no time or status, so there are no candidates.
+	out, err := cliCommand(t, "rootcause", "-q", "mock:foo:x").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Equal(t, "candidates: []", strings.TrimSpace(string(out)))
+
+	_, err = cliCommand(t, "rootcause", "-q", "mock:foo:x", "extra").Output()
+	assert.Error(t, err)
+}
+
+func TestMain_doctor(t *testing.T) {
+	// The test configuration only has a mock store, so the checks that need a cluster are skipped.
+	out, err := cliCommand(t, "doctor", "mock").Output()
+	require.NoError(t, test.ExecError(err))
+	assert.Contains(t, string(out), "level: skipped")
+	assert.Contains(t, string(out), "message: mock store")
+
+	_, err = cliCommand(t, "doctor", "nonesuch").Output()
+	assert.Error(t, err)
+}
+
 func TestMain_stores(t *testing.T) {
 	out, err := cliCommand(t, "stores").Output()
 	require.NoError(t, test.ExecError(err))
 	want := `{
   "alert": null,
+  "change": null,
   "incident": null,
   "k8s": null,
   "log": null,
@@ -0,0 +1,51 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/engine/doctor"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [DOMAIN...]",
+	Short: "Diagnose connection and permission problems for the stores of the listed domains, or all domains if none are listed.",
+	Long: `Diagnose connection and permission problems for the stores of the listed domains, or all domains if none are listed.
+
+Each store is checked in turn: expand the store templates, create the store, run a cheap probe query,
+and check the permissions the store needs for the current kube config user.
+Findings that are not OK include a suggested fix. Exits with an error if any store has an error finding.`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		e := newEngine()
+		var domains []korrel8r.Domain
+		for _, d := range args {
+			domains = append(domains, must.Must1(e.Domain(d)))
+		}
+		ctx, cancel := e.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		stores := doctor.Diagnose(ctx, e, domains, nil)
+		newPrinter(os.Stdout).Print(rest.APIDiagnoses(stores))
+		n := 0
+		for _, s := range stores {
+			if s.Level() == doctor.Error {
+				n++
+			}
+		}
+		if n > 0 {
+			must.Must(fmt.Errorf("%v of %v stores have errors", n, len(stores)))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout for store requests.")
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/korrel8r/korrel8r/internal/pkg/must"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/authz"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/projection"
 	"github.com/korrel8r/korrel8r/pkg/rest"
 	"github.com/spf13/cobra"
 )
@@ -24,21 +26,51 @@ var (
 	objects      []string
 	limit        int
 	graphOptions = api.GraphOptions{
-		Rules:   new(false),
-		Errors:  new(false),
-		Results: new(false),
+		Rules:    new(false),
+		Errors:   new(false),
+		Results:  new(false),
+		Patterns: new(false),
+		GroupBy:  new(""),
+		Project:  new(""),
 	}
+	rankResults bool
 	// Constraint values
 	since, until, timeout time.Duration
+	cluster               string
 )
 
 func startFlags(cmd *cobra.Command) {
 	cmd.Flags().StringArrayVarP(&queries, "query", "q", nil, "Query string for start objects, can be multiple.")
 	cmd.Flags().StringVar(&class, "class", "", "Class for serialized start objects")
 	cmd.Flags().StringArrayVar(&objects, "object", nil, "Serialized start object, can be multiple.")
+}
+
+func graphFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(graphOptions.Rules, "rules", false, "Include rule names in returned graph")
 	cmd.Flags().BoolVar(graphOptions.Results, "results", false, "Include complete query results in graph")
 	cmd.Flags().BoolVar(graphOptions.Errors, "errors", false, "Include non-fatal errors in graph")
+	cmd.Flags().BoolVar(graphOptions.Patterns, "patterns", false, "Include a summary of log patterns in log nodes")
+	cmd.Flags().StringVar(graphOptions.GroupBy, "group-by", "", "Include counts of returned node results grouped by these comma-separated fields")
+	cmd.Flags().StringVar(graphOptions.Project, "project", "", "Projection of results, select fields, 'compact' or 'summary'. See the REST API Projection for syntax.")
+	cmd.PreRun = func(*cobra.Command, []string) { must.Must1(projection.Parse(*graphOptions.Project)) }
+	rankFlag(cmd)
+}
+
+func rankFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&rankResults, "rank", false, "Sort results in each node by relevance, and apply --limit to each node")
+}
+
+// rankOptions sets rank order in opts if the --rank flag is set.
+func rankOptions(opts *api.GraphOptions) {
+	if rankResults {
+		opts.Order = new(api.OrderRank)
+	}
+}
+
+// rankStart sets rank order in opts and the start if the --rank flag is set.
+func rankStart(s *traverse.Start, opts *api.GraphOptions) {
+	rankOptions(opts)
+	s.Rank = rankResults
 }
 
 func constraintFlags(cmd *cobra.Command) {
@@ -46,6 +78,7 @@ func constraintFlags(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout for store requests.")
 	cmd.Flags().DurationVar(&since, "since", 0, "Only get results since this long ago.")
 	cmd.Flags().DurationVar(&until, "until", 0, "Only get results until this long ago.")
+	cmd.Flags().StringVar(&cluster, "cluster", "", "Only use stores for this cluster, and stores with no cluster.")
 }
 
 var (
@@ -57,18 +90,30 @@ var (
 		Run: func(cmd *cobra.Command, args []string) {
 			e := newEngine()
 			q := must.Must1(e.Query(args[0]))
-			p := newPrinter(os.Stdout)
-			defer p.Close()
 			ctx, cancel := e.WithTimeout(context.Background(), timeout)
 			defer cancel()
-			must.Must(e.Get(ctx, q, constraint(), p))
+			if len(groupBy) > 0 {
+				groups, err := e.Aggregate(ctx, q, constraint(), groupBy)
+				must.Must(authz.Fatal(err))
+				newPrinter(os.Stdout).Print(rest.APIGroups(groups))
+				return
+			}
+			project := must.Must1(projection.Parse(objectsProject))
+			p := newPrinter(os.Stdout)
+			defer p.Close()
+			result := korrel8r.AppenderFunc(func(objects ...korrel8r.Object) { p.Append(project.Objects(q.Class(), objects)...) })
+			must.Must(authz.Fatal(e.Get(ctx, q, constraint(), result)))
 		},
 	}
+	groupBy        []string
+	objectsProject string
 )
 
 func init() {
 	rootCmd.AddCommand(objectsCmd)
 	constraintFlags(objectsCmd)
+	objectsCmd.Flags().StringSliceVar(&groupBy, "group-by", nil, "Print counts of results grouped by these fields instead of the results")
+	objectsCmd.Flags().StringVar(&objectsProject, "project", "", "Projection of results, select fields, 'compact' or 'summary'. See the REST API Projection for syntax.")
 }
 
 var (
@@ -80,7 +125,9 @@ var (
 			e := newEngine()
 			ctx, cancel := e.WithTimeout(context.Background(), timeout)
 			defer cancel()
-			g, err := traverse.Neighbors(ctx, e, start(e), depth)
+			s := start(e)
+			rankStart(&s, &graphOptions)
+			g, err := traverse.Neighbors(ctx, e, s, depth)
 			must.Must(err)
 			newPrinter(os.Stdout).Print(rest.NewGraph(g, &graphOptions))
 		},
@@ -91,6 +138,7 @@ var (
 func init() {
 	rootCmd.AddCommand(neighborsCmd)
 	startFlags(neighborsCmd)
+	graphFlags(neighborsCmd)
 	constraintFlags(neighborsCmd)
 	neighborsCmd.Flags().IntVarP(&depth, "depth", "d", 2, "Depth of neighborhood search.")
 }
@@ -108,7 +156,9 @@ var (
 			}
 			ctx, cancel := e.WithTimeout(context.Background(), timeout)
 			defer cancel()
-			g, err := traverse.Goals(ctx, e, start(e), goals)
+			s := start(e)
+			rankStart(&s, &graphOptions)
+			g, err := traverse.Goals(ctx, e, s, goals)
 			must.Must(err)
 			newPrinter(os.Stdout).Print(rest.NewGraph(g, &graphOptions))
 		},
@@ -118,6 +168,7 @@ var (
 func init() {
 	rootCmd.AddCommand(goalsCmd)
 	startFlags(goalsCmd)
+	graphFlags(goalsCmd)
 	constraintFlags(goalsCmd)
 }
 
@@ -133,6 +184,7 @@ func constraint() *korrel8r.Constraint {
 	if until > 0 {
 		c.End = new(now.Add(-until))
 	}
+	c.Cluster = cluster
 	return c
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/rules/quickrules"
+	"github.com/korrel8r/korrel8r/pkg/session"
 	"github.com/spf13/cobra"
 )
 
@@ -95,6 +96,17 @@ func newEngineWithConfigs(c config.Configs) (*engine.Engine, error) {
 		Engine()
 }
 
+// newFactory returns a session factory that builds engines from configs plus a session overlay.
+func newFactory(configs config.Configs) session.Factory {
+	return func(overlay *config.Config) (*engine.Engine, error) {
+		c, err := configs.WithOverlay(overlay)
+		if err != nil {
+			return nil, err
+		}
+		return newEngineWithConfigs(c)
+	}
+}
+
 func newEngine() *engine.Engine {
 	return must.Must1(newEngineWithConfigs(must.Must1(config.Load(*configFlag))))
 }
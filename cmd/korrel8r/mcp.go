@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,7 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
 	mcpmetrics "github.com/korrel8r/korrel8r/internal/pkg/mcp"
 	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/audit"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/mcp"
 	"github.com/korrel8r/korrel8r/pkg/rest"
@@ -29,12 +31,21 @@ For a HTTP streaming server use the 'web' command with the '--mcp' flag.
 	Run: func(cmd *cobra.Command, args []string) {
 		configs := must.Must1(config.Load(*configFlag))
 		e := must.Must1(newEngineWithConfigs(configs))
-		sessions := session.NewSingleManager(e)
+		sessions := session.NewSingleManager(e, newFactory(configs))
 		if os.Getenv(gin.EnvGinMode) == "" {
 			gin.SetMode(gin.ReleaseMode)
 		}
+		var auditLog *audit.Logger
+		if len(configs) > 0 && configs[0].Tuning != nil && configs[0].Tuning.Audit != nil {
+			cfg := *configs[0].Tuning.Audit
+			if cfg.File == "-" {
+				panic(errors.New("audit file can't be standard output, it is used by the MCP stdio protocol"))
+			}
+			auditLog = must.Must1(audit.New(cfg))
+			defer func() { _ = auditLog.Close() }()
+		}
 		router := gin.New()
-		router.Use(gin.Recovery(), session.Middleware(sessions))
+		router.Use(gin.Recovery(), rest.Audit(auditLog), session.Middleware(sessions))
 		must.Must1(rest.New(sessions, router))
 		client := mcp.NewClientForHandler(router)
 		server := mcp.NewServer(client, build.Version, logging.Log())
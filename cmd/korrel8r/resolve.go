@@ -0,0 +1,35 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/engine/resolve"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve [TEXT...]",
+	Short: "Propose start queries from free text such as log lines, alert notifications or URLs. Reads stdin if there is no TEXT.",
+	Run: func(cmd *cobra.Command, args []string) {
+		text := strings.Join(args, " ")
+		if len(args) == 0 {
+			text = string(must.Must1(io.ReadAll(os.Stdin)))
+		}
+		e := newEngine()
+		ctx, cancel := e.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		newPrinter(os.Stdout).Print(rest.APICandidates(resolve.Resolve(ctx, e, text, constraint())))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+	constraintFlags(resolveCmd)
+}
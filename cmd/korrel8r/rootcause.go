@@ -0,0 +1,46 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rootCauseCmd = &cobra.Command{
+		Use:     "rootcause",
+		Aliases: []string{"rootcauses"},
+		Short:   "Search the neighbors of the start objects, print candidate root causes of a problem with the start objects.",
+		Long: `Search the neighbors of the start objects, print candidate root causes of a problem with the start objects.
+
+Each object found by the neighbors search is scored using its statuses, how shortly it happened before the
+start objects, Kubernetes ownership relative to the start objects, and the number of rules followed to find it.
+Candidates are printed most likely first, with the rules followed and the reasons for the score.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			e := newEngine()
+			ctx, cancel := e.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			s := start(e)
+			g, err := traverse.Neighbors(ctx, e, s, depth)
+			must.Must(err)
+			newPrinter(os.Stdout).Print(rest.APIRootCauses(rootcause.Analyze(e, g, s.Class, candidates), g.Errors))
+		},
+	}
+	candidates int
+)
+
+func init() {
+	rootCmd.AddCommand(rootCauseCmd)
+	startFlags(rootCauseCmd)
+	constraintFlags(rootCauseCmd)
+	rootCauseCmd.Flags().IntVarP(&depth, "depth", "d", rootcause.DefaultDepth, "Depth of neighborhood search.")
+	rootCauseCmd.Flags().IntVarP(&candidates, "candidates", "n", rootcause.DefaultLimit, "Maximum number of candidates to print, 0 for all.")
+}
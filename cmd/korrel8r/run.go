@@ -0,0 +1,72 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runCmd = &cobra.Command{
+		Use:     "run [RECIPE]",
+		Aliases: []string{"recipe"},
+		Short:   "Run the RECIPE recipe, or list recipes if there is no RECIPE.",
+		Args:    cobra.RangeArgs(0, 1),
+		Run: func(cmd *cobra.Command, args []string) {
+			e := newEngine()
+			if len(args) == 0 {
+				recipes := []api.Recipe{}
+				for _, r := range e.Recipes() {
+					recipes = append(recipes, rest.APIRecipe(r))
+				}
+				newPrinter(os.Stdout).Print(recipes)
+				return
+			}
+			r := must.Must1(e.Recipe(args[0]))
+			params := map[string]string{}
+			for _, p := range runParams {
+				k, v, ok := strings.Cut(p, "=")
+				if !ok {
+					must.Must(fmt.Errorf("invalid parameter, expecting NAME=VALUE: %q", p))
+				}
+				params[k] = v
+			}
+			queries := must.Must1(r.Queries(params))
+			c := r.Constraint(time.Now()) // Use the recipe constraint unless constraint flags are set.
+			if cmd.Flags().Changed("limit") || cmd.Flags().Changed("since") || cmd.Flags().Changed("until") || cmd.Flags().Changed("cluster") {
+				c = constraint()
+			}
+			opts := &graphOptions
+			if r.Options != nil && !cmd.Flags().Changed("rules") && !cmd.Flags().Changed("results") && !cmd.Flags().Changed("errors") {
+				opts = &api.GraphOptions{Rules: &r.Options.Rules, Results: &r.Options.Results, Errors: &r.Options.Errors}
+			}
+			ctx, cancel := e.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			rankOptions(opts)
+			g, err := traverse.Recipe(ctx, e, r, traverse.Start{Queries: queries, Constraint: c, Rank: rankResults})
+			must.Must(err)
+			newPrinter(os.Stdout).Print(rest.NewGraph(g, opts))
+		},
+	}
+	runParams []string
+)
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	constraintFlags(runCmd)
+	runCmd.Flags().StringArrayVarP(&runParams, "param", "p", nil, "Recipe parameter NAME=VALUE, can be multiple.")
+	runCmd.Flags().BoolVar(graphOptions.Rules, "rules", false, "Include rule names in returned graph")
+	runCmd.Flags().BoolVar(graphOptions.Results, "results", false, "Include complete query results in graph")
+	runCmd.Flags().BoolVar(graphOptions.Errors, "errors", false, "Include non-fatal errors in graph")
+	rankFlag(runCmd)
+}
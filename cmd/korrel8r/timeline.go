@@ -0,0 +1,53 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
+	"github.com/korrel8r/korrel8r/pkg/engine/timeline"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/rest"
+	"github.com/spf13/cobra"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline [GOAL...]",
+	Short: "Search from the start objects, print the objects found sorted by time.",
+	Long: `Search from the start objects, print the objects found sorted by time.
+
+Does a goals search if GOAL classes are listed, otherwise a neighbors search to --depth.
+Each entry has the class, time, preview, statuses and the rules followed to find the object.
+Objects of classes that have no time are omitted.`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		e := newEngine()
+		var goals []korrel8r.Class
+		for _, g := range args {
+			goals = append(goals, must.Must1(e.Class(g)))
+		}
+		ctx, cancel := e.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		s := start(e)
+		var g *graph.Graph
+		var err error
+		if len(goals) > 0 {
+			g, err = traverse.Goals(ctx, e, s, goals)
+		} else {
+			g, err = traverse.Neighbors(ctx, e, s, depth)
+		}
+		must.Must(err)
+		newPrinter(os.Stdout).Print(rest.APITimeline(timeline.New(e, g, s.Class), g.Errors))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+	startFlags(timelineCmd)
+	constraintFlags(timelineCmd)
+	timelineCmd.Flags().IntVarP(&depth, "depth", "d", timeline.DefaultDepth, "Depth of neighborhood search, if there are no goals.")
+}
@@ -20,9 +20,11 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/must"
 	"github.com/korrel8r/korrel8r/internal/pkg/tlsprofile"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/audit"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/mcp"
+	"github.com/korrel8r/korrel8r/pkg/oidc"
 	"github.com/korrel8r/korrel8r/pkg/rest"
 	"github.com/korrel8r/korrel8r/pkg/session"
 	"github.com/korrel8r/korrel8r/pkg/tokenreview"
@@ -72,31 +74,51 @@ var webCmd = &cobra.Command{
 
 		// Get session values from from top-level configuration
 		var timeout time.Duration
+		var tuning config.Tuning
 		configs := must.Must1(config.Load(*configFlag))
 		if len(configs) > 0 && configs[0].Tuning != nil {
-			timeout = time.Duration(configs[0].Tuning.SessionTimeout)
-			if configs[0].Tuning.UnsafeSharedSession {
+			tuning = *configs[0].Tuning
+			timeout = time.Duration(tuning.SessionTimeout)
+			if tuning.UnsafeSharedSession {
 				*unsafeSharedSessionFlag = true
 			}
 		}
 		var sessions session.Manager
 		if *unsafeSharedSessionFlag {
 			e := must.Must1(newEngineWithConfigs(configs))
-			sessions = session.NewSingleManager(e)
+			sessions = session.NewSingleManager(e, newFactory(configs))
 		} else {
-			factory := func() (*engine.Engine, error) { return newEngineWithConfigs(configs) }
-			tokenReview, err := tokenreview.New()
+			var authn session.Authenticator
+			var err error
+			if tuning.OIDC != nil {
+				log.V(0).Info("OIDC authentication", "issuer", tuning.OIDC.Issuer)
+				authn, err = oidc.New(*tuning.OIDC)
+			} else {
+				authn, err = tokenreview.New()
+			}
 			if err != nil {
 				panic(fmt.Errorf("authentication unavailable: %w\nUse the --unsafe-shared-session flag if you want an unauthenticated server", err))
 			}
-			sessions = session.NewTokenReviewManager(tokenReview, timeout, factory)
+			var store session.Store
+			if tuning.SessionStore != "" {
+				store = must.Must1(session.NewFileStore(tuning.SessionStore))
+				defer func() { _ = store.Close() }()
+				log.V(0).Info("Saving sessions", "file", tuning.SessionStore)
+			}
+			sessions = session.NewAuthManager(authn, timeout, newFactory(configs), store)
+		}
+		var auditLog *audit.Logger
+		if tuning.Audit != nil {
+			auditLog = must.Must1(audit.New(*tuning.Audit))
+			defer func() { _ = auditLog.Close() }()
+			log.V(0).Info("Audit log", "file", tuning.Audit.File)
 		}
 
 		if os.Getenv(gin.EnvGinMode) == "" {
 			gin.SetMode(gin.ReleaseMode)
 		}
 		router := gin.New()
-		router.Use(gin.Recovery(), session.Middleware(sessions))
+		router.Use(gin.Recovery(), rest.Audit(auditLog), session.Middleware(sessions))
 
 		if *restFlag {
 			must.Must1(rest.New(sessions, router))
@@ -104,7 +126,7 @@ var webCmd = &cobra.Command{
 		}
 		if *mcpFlag {
 			mcpRouter := gin.New()
-			mcpRouter.Use(gin.Recovery(), session.Middleware(sessions))
+			mcpRouter.Use(gin.Recovery(), rest.Audit(auditLog), session.Middleware(sessions))
 			must.Must1(rest.New(sessions, mcpRouter))
 			client := mcp.NewClientForHandler(mcpRouter)
 			mcpSrv := mcp.NewServer(client, build.Version, logging.Log())
@@ -122,6 +144,11 @@ var webCmd = &cobra.Command{
 
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 		defer stop()
+		if interval := time.Duration(tuning.ReloadInterval); interval > 0 {
+			w := config.NewWatcher(*configFlag, configs, interval, tuning.ReloadURLs)
+			go w.Watch(ctx, func(configs config.Configs, err error) { reload(sessions, configs, err) })
+			log.V(0).Info("Reloading configuration on change", "interval", interval, "urls", tuning.ReloadURLs)
+		}
 		done := make(chan struct{})
 		go func() {
 			defer close(done)
@@ -149,6 +176,21 @@ var webCmd = &cobra.Command{
 	},
 }
 
+// reload replaces the session engines with engines built from configs.
+// If err is not nil, or the new engine cannot be built, sessions keep their current engine.
+func reload(sessions session.Manager, configs config.Configs, err error) {
+	factory := newFactory(configs)
+	if err != nil {
+		factory = func(*config.Config) (*engine.Engine, error) { return nil, err }
+	}
+	err = sessions.Reload(factory)
+	if err != nil {
+		log.Error(err, "Configuration reload failed, keeping previous configuration")
+	} else {
+		log.V(0).Info("Configuration reloaded", "configuration", *configFlag)
+	}
+}
+
 var (
 	httpFlag, httpsFlag     *string
 	certFlag, keyFlag       *string
@@ -81,6 +81,7 @@ func assertDo(t *testing.T, h *http.Client, want, method, url, body string) {
 
 const domains = `[
 {"description":"Prometheus/AlertManager alerts.","name":"alert"},
+{"description":"recent changes to cluster resources.","name":"change"},
 {"description":"cluster health incidents.","name":"incident"},
 {"description":"Kubernetes resources.","name":"k8s"},
 {"description":"application, infrastructure, and audit logs.","name":"log"},
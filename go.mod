@@ -21,6 +21,7 @@ require (
 	github.com/go-logr/stdr v1.2.2
 	github.com/go-openapi/runtime v0.33.0
 	github.com/go-openapi/strfmt v0.27.0
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/korrel8r/korrel8r/pkg/api v0.12.0
 	github.com/korrel8r/korrel8r/pkg/mcp v0.12.0
 	github.com/modelcontextprotocol/go-sdk v1.7.0
@@ -33,6 +34,7 @@ require (
 	github.com/spf13/cobra v1.10.2
 	github.com/stretchr/testify v1.11.1
 	github.com/valyala/quicktemplate v1.8.0
+	go.etcd.io/bbolt v1.4.3
 	go.opentelemetry.io/otel v1.45.0
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp v1.45.0
 	go.opentelemetry.io/otel/exporters/prometheus v0.67.0
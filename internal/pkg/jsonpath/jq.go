@@ -0,0 +1,233 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package jsonpath
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jqPath is a parsed jq expression, a sequence of steps applied to each value in turn.
+//
+// The jq subset is a pipeline of paths separated by '|', each path starts with '.' and has steps:
+//
+//	.key ."key" ["key"]  object value, null if missing
+//	[n]                  array element, negative n counts from the end, null if out of range
+//	[]                   all array elements or object values, object values in key order
+//	?                    ignored, errors are never reported
+//
+// A pipeline of paths is the same as a single path with all the steps, so pipes don't need special treatment.
+type jqPath []jqStep
+
+type jqKind int
+
+const (
+	jqKey jqKind = iota
+	jqIndex
+	jqIterate
+)
+
+type jqStep struct {
+	kind  jqKind
+	key   string
+	index int
+}
+
+// parseJQ parses a jq expression in the subset described by [jqPath].
+func parseJQ(expr string) (jqPath, error) {
+	p := &jqParser{s: expr}
+	path := jqPath{} // Not nil, "." is a valid path with no steps.
+	for {
+		p.skipSpace()
+		if !p.next('.') {
+			return nil, p.errorf("expected '.'")
+		}
+		// A path can start with a key or a bracket right after the leading '.'.
+		if p.peekKey() {
+			step, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, step)
+		}
+		for {
+			p.skipSpace()
+			switch {
+			case p.done():
+				return path, nil
+			case p.next('|'):
+			case p.next('?'):
+				continue
+			case p.next('['):
+				step, err := p.bracket()
+				if err != nil {
+					return nil, err
+				}
+				path = append(path, step)
+				continue
+			case p.next('.'):
+				if p.peek() == '[' {
+					continue // .[...] is the same as [...]
+				}
+				step, err := p.key()
+				if err != nil {
+					return nil, err
+				}
+				path = append(path, step)
+				continue
+			default:
+				return nil, p.errorf("unexpected %q", p.peek())
+			}
+			break // Pipe, start the next path.
+		}
+	}
+}
+
+type jqParser struct {
+	s string
+	i int
+}
+
+func (p *jqParser) done() bool { return p.i >= len(p.s) }
+
+func (p *jqParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *jqParser) next(c byte) bool {
+	if !p.done() && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *jqParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.s[p.i])) {
+		p.i++
+	}
+}
+
+func (p *jqParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at offset %v: %v", p.i, fmt.Sprintf(format, args...))
+}
+
+func isIdent(c byte, first bool) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (!first && '0' <= c && c <= '9')
+}
+
+// peekKey returns true if the next token is an identifier or a quoted key.
+func (p *jqParser) peekKey() bool { return isIdent(p.peek(), true) || p.peek() == '"' }
+
+// key parses an identifier or quoted string after a '.'.
+func (p *jqParser) key() (jqStep, error) {
+	if p.peek() == '"' {
+		s, err := p.quoted()
+		return jqStep{kind: jqKey, key: s}, err
+	}
+	start := p.i
+	for !p.done() && isIdent(p.s[p.i], p.i == start) {
+		p.i++
+	}
+	if p.i == start {
+		return jqStep{}, p.errorf("expected key")
+	}
+	return jqStep{kind: jqKey, key: p.s[start:p.i]}, nil
+}
+
+// quoted parses a JSON string.
+func (p *jqParser) quoted() (string, error) {
+	start := p.i
+	for p.i++; !p.done() && p.s[p.i] != '"'; p.i++ {
+		if p.s[p.i] == '\\' {
+			p.i++
+		}
+	}
+	if !p.next('"') {
+		return "", p.errorf("unterminated string")
+	}
+	s, err := strconv.Unquote(p.s[start:p.i])
+	if err != nil {
+		return "", p.errorf("invalid string %v", p.s[start:p.i])
+	}
+	return s, nil
+}
+
+// bracket parses the contents of [...] after the opening '['.
+func (p *jqParser) bracket() (step jqStep, err error) {
+	p.skipSpace()
+	switch {
+	case p.next(']'):
+		return jqStep{kind: jqIterate}, nil
+	case p.peek() == '"':
+		if step.key, err = p.quoted(); err != nil {
+			return step, err
+		}
+		step.kind = jqKey
+	default:
+		end := strings.IndexByte(p.s[p.i:], ']')
+		if end < 0 {
+			return step, p.errorf("missing ']'")
+		}
+		if step.index, err = strconv.Atoi(strings.TrimSpace(p.s[p.i : p.i+end])); err != nil {
+			return step, p.errorf("invalid index %q", p.s[p.i:p.i+end])
+		}
+		step.kind = jqIndex
+		p.i += end
+	}
+	p.skipSpace()
+	if !p.next(']') {
+		return step, p.errorf("missing ']'")
+	}
+	return step, nil
+}
+
+// eval returns the values selected by the path, including nulls.
+func (path jqPath) eval(v any) []any {
+	values := []any{v}
+	for _, step := range path {
+		var next []any
+		for _, v := range values {
+			switch step.kind {
+			case jqKey:
+				if m, ok := v.(map[string]any); ok {
+					next = append(next, m[step.key])
+				} else if v == nil {
+					next = append(next, nil)
+				}
+			case jqIndex:
+				if l, ok := v.([]any); ok {
+					i := step.index
+					if i < 0 {
+						i += len(l)
+					}
+					if i >= 0 && i < len(l) {
+						next = append(next, l[i])
+					} else {
+						next = append(next, nil)
+					}
+				} else if v == nil {
+					next = append(next, nil)
+				}
+			case jqIterate:
+				switch v := v.(type) {
+				case []any:
+					next = append(next, v...)
+				case map[string]any:
+					for _, k := range slices.Sorted(maps.Keys(v)) {
+						next = append(next, v[k])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
@@ -0,0 +1,57 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExpr_jq(t *testing.T) {
+	var o any
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "items": [{"name": "a", "tags": {"x": 1, "w": 2}}, {"name": "b"}, {"id": "c"}],
+  "page": {"next token": "t"}
+}`), &o))
+	for _, x := range []struct {
+		expr string
+		want []any
+	}{
+		{`.items[] | .name`, []any{"a", "b"}},
+		{`.items[]|.name`, []any{"a", "b"}},
+		{`.items | .[1] | .name`, []any{"b"}},
+		{`.items | .[-1].id`, []any{"c"}},
+		{`.items[5] | .name`, nil},
+		{`.items[0].tags | .[]`, []any{float64(2), float64(1)}},
+		{`.page | ."next token"`, []any{"t"}},
+		{`.page | .["next token"]`, []any{"t"}},
+		{`.items[]? | .nonesuch`, nil},
+		{`. | .items[0] | .name`, []any{"a"}},
+	} {
+		t.Run(x.expr, func(t *testing.T) {
+			p, err := NewExpr(x.expr)
+			require.NoError(t, err)
+			require.NotNil(t, p.jq, "not parsed as jq")
+			assert.Equal(t, x.want, p.FindAll(o))
+		})
+	}
+}
+
+func TestNewExpr_JSONPath(t *testing.T) {
+	p, err := NewExpr(`{.items[*].name}`)
+	require.NoError(t, err)
+	assert.Nil(t, p.jq)
+	assert.Equal(t, []any{"a"}, p.FindAll(map[string]any{"items": []any{map[string]any{"name": "a"}}}))
+}
+
+func TestNewExpr_error(t *testing.T) {
+	for _, expr := range []string{`.items[] |`, `.items[x] | .a`, `.a | length`, `.a | ."b`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := NewExpr(expr)
+			assert.ErrorContains(t, err, "invalid jq")
+		})
+	}
+}
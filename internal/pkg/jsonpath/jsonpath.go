@@ -0,0 +1,118 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package jsonpath evaluates JSONPath expressions on decoded JSON values, using the kubectl syntax.
+// A subset of jq is also supported, see [NewExpr].
+package jsonpath
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Path is a JSONPath expression. It is safe for concurrent use.
+// Methods of a nil Path find nothing.
+type Path struct {
+	m  sync.Mutex
+	jp *jsonpath.JSONPath
+	jq jqPath // Used instead of jp for jq expressions.
+}
+
+// New parses expr, the surrounding braces are optional. Returns nil if expr is empty.
+func New(expr string) (*Path, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	jp := jsonpath.New("").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+	}
+	return &Path{jp: jp}, nil
+}
+
+// NewExpr parses expr as JSONPath, or as jq if it is not valid JSONPath. Returns nil if expr is empty.
+//
+// The jq subset is a pipeline of paths like ".items[] | .metadata.name", with the steps
+// ".key", ".\"key\"", "[\"key\"]", "[n]" for array elements and "[]" for all array elements or object values.
+// Object values are iterated in key order. Other jq features are not supported.
+func NewExpr(expr string) (*Path, error) {
+	p, err := New(expr)
+	if err == nil {
+		return p, nil
+	}
+	jq, jqErr := parseJQ(expr)
+	if jqErr != nil {
+		return nil, fmt.Errorf("%w, invalid jq %q: %w", err, expr, jqErr)
+	}
+	return &Path{jq: jq}, nil
+}
+
+// FindAll returns all values selected by the path.
+func (p *Path) FindAll(o any) []any {
+	if p == nil {
+		return nil
+	}
+	if p.jq != nil {
+		var values []any
+		for _, v := range p.jq.eval(o) {
+			if v != nil {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	p.m.Lock()
+	defer p.m.Unlock()
+	results, err := p.jp.FindResults(o)
+	if err != nil {
+		return nil
+	}
+	var values []any
+	for _, r := range results {
+		for _, v := range r {
+			if v.IsValid() && !(v.Kind() == reflect.Interface && v.IsNil()) {
+				values = append(values, v.Interface())
+			}
+		}
+	}
+	return values
+}
+
+// Find returns the first value selected by the path, nil if there is none.
+func (p *Path) Find(o any) any {
+	if values := p.FindAll(o); len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+// FindString returns the first value selected by the path as a string, "" if there is none.
+func (p *Path) FindString(o any) string {
+	if v := p.Find(o); v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// FindTime returns the first value selected by the path as a time.
+// The value must be a RFC3339 string or a number of seconds since the Unix epoch.
+// Returns the zero time if there is no such value.
+func (p *Path) FindTime(o any) time.Time {
+	switch v := p.Find(o).(type) {
+	case string:
+		t, _ := time.Parse(time.RFC3339Nano, v)
+		return t
+	case float64:
+		sec := int64(v)
+		return time.Unix(sec, int64((v-float64(sec))*1e9))
+	default:
+		return time.Time{}
+	}
+}
@@ -11,10 +11,13 @@ package loki
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
@@ -98,8 +101,102 @@ const ( // Query URL keywords
 
 	lokiStackPath  = "/api/logs/v1/"
 	queryRangePath = "/loki/api/v1/query_range"
+	queryPath      = "/loki/api/v1/query"
 )
 
+// Count uses the plain Loki API to count log records for a LogQL log query in the constraint time range,
+// grouped by the values of labels. Labels must be stream labels, structured metadata or labels extracted by the query.
+// Returns [korrel8r.ErrNotAggregated] if a label is not a valid LogQL label name.
+func (c *Client) Count(ctx context.Context, logQL string, labels []string, constraint *korrel8r.Constraint) ([]korrel8r.Group, error) {
+	u, err := countURL(logQL, labels, constraint)
+	if err != nil {
+		return nil, err
+	}
+	return c.count(ctx, u, labels)
+}
+
+// CountStack is like [Client.Count] but uses the LokiStack tenant API.
+func (c *Client) CountStack(ctx context.Context, logQL, tenant string, labels []string, constraint *korrel8r.Constraint) ([]korrel8r.Group, error) {
+	u, err := countURL(logQL, labels, constraint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(lokiStackPath, tenant, u.Path)
+	return c.count(ctx, u, labels)
+}
+
+var labelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// countURL returns an instant query URL for a LogQL metric query that counts logQL results at the end of the constraint.
+func countURL(logQL string, labels []string, c *korrel8r.Constraint) (*url.URL, error) {
+	for _, l := range labels {
+		if !labelName.MatchString(l) {
+			return nil, fmt.Errorf("%w: invalid Loki label name %q", korrel8r.ErrNotAggregated, l)
+		}
+	}
+	end := c.GetEnd()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := c.GetStart()
+	if start.IsZero() || !start.Before(end) {
+		start = end.Add(-time.Hour)
+	}
+	seconds := int64(math.Ceil(end.Sub(start).Seconds()))
+	metricQL := fmt.Sprintf("count_over_time(%v [%vs])", logQL, seconds)
+	if len(labels) > 0 {
+		metricQL = fmt.Sprintf("sum by (%v) (%v)", strings.Join(labels, ","), metricQL)
+	} else {
+		metricQL = fmt.Sprintf("sum(%v)", metricQL)
+	}
+	v := url.Values{}
+	v.Add(query, metricQL)
+	v.Add("time", formatTime(end))
+	return &url.URL{Path: queryPath, RawQuery: v.Encode()}, nil
+}
+
+func (c *Client) count(ctx context.Context, u *url.URL, labels []string) ([]korrel8r.Group, error) {
+	u = c.BaseURL.ResolveReference(u)
+	log.V(5).Info("Loki GET", "url", u)
+	var qr struct {
+		Status string `json:"status"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Metric map[string]string `json:"metric"`
+				Value  []any             `json:"value"` // [time, "count"]
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := impl.Get(ctx, u, c.Client, &qr); err != nil {
+		return nil, err
+	}
+	if qr.Status != "success" {
+		return nil, fmt.Errorf("expected 'status: success' in %v", qr)
+	}
+	if qr.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("expected 'resultType: vector' in %v", qr)
+	}
+	var groups []korrel8r.Group
+	for _, r := range qr.Data.Result {
+		g := korrel8r.Group{Fields: make(map[string]string, len(labels))}
+		for _, l := range labels {
+			g.Fields[l] = r.Metric[l]
+		}
+		if len(r.Value) == 2 {
+			if s, ok := r.Value[1].(string); ok {
+				n, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid count in Loki response: %q", s)
+				}
+				g.Count = int(n)
+			}
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
 func queryURL(logQL string, c *korrel8r.Constraint) *url.URL {
 	v := url.Values{}
 	v.Add(query, logQL)
@@ -357,3 +357,38 @@ func containsString(s, substr string) bool {
 					return false
 				}())))
 }
+
+func TestClient_Count(t *testing.T) {
+	end := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	var got url.Values
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, got = r.URL.Path, r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+{"metric":{"level":"error","container":"a"},"value":[1704164400,"12"]},
+{"metric":{"container":"b"},"value":[1704164400,"3"]}]}}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	client := New(server.Client(), baseURL)
+	c := &korrel8r.Constraint{Start: ptr.To(end.Add(-10 * time.Minute)), End: &end}
+
+	groups, err := client.Count(context.Background(), `{app="test"}|json`, []string{"level", "container"}, c)
+	assert.NoError(t, err)
+	assert.Equal(t, queryPath, gotPath)
+	assert.Equal(t, `sum by (level,container) (count_over_time({app="test"}|json [600s]))`, got.Get("query"))
+	assert.Equal(t, formatTime(end), got.Get("time"))
+	assert.Equal(t, []korrel8r.Group{
+		{Fields: map[string]string{"level": "error", "container": "a"}, Count: 12},
+		{Fields: map[string]string{"level": "", "container": "b"}, Count: 3},
+	}, groups)
+
+	_, err = client.CountStack(context.Background(), `{app="test"}`, "application", nil, c)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/logs/v1/application"+queryPath, gotPath)
+	assert.Equal(t, `sum(count_over_time({app="test"} [600s]))`, got.Get("query"))
+
+	_, err = client.Count(context.Background(), `{app="test"}`, []string{"not.a.label"}, c)
+	assert.ErrorIs(t, err, korrel8r.ErrNotAggregated)
+}
@@ -0,0 +1,47 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package storeclient creates HTTP clients for stores that are not Kubernetes services.
+package storeclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+)
+
+// New returns a client using the CA from store config if set.
+//
+// If the store auth field is [config.StoreAuthToken] the caller's bearer token is always required and forwarded.
+// If it is not set, the token is forwarded if forward is true and the caller has one.
+// Other auth values are not valid.
+func New(cs config.Store, forward bool) (*http.Client, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	var rt http.RoundTripper = t
+	switch mode := cs[config.StoreKeyAuth]; mode {
+	case "":
+		if forward {
+			rt = auth.Wrap(t)
+		}
+	case config.StoreAuthToken:
+		rt = auth.Require(t)
+	default:
+		return nil, fmt.Errorf("invalid store %v: %q, only %q is allowed", config.StoreKeyAuth, mode, config.StoreAuthToken)
+	}
+	if ca := cs[config.StoreKeyCA]; ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %v", ca)
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: rt}, nil
+}
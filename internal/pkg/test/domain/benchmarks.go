@@ -3,59 +3,12 @@
 package domain
 
 import (
-	"context"
 	"testing"
-
-	"github.com/korrel8r/korrel8r/internal/pkg/json"
-	"github.com/korrel8r/korrel8r/pkg/korrel8r"
-	"github.com/korrel8r/korrel8r/pkg/ptr"
-	"github.com/korrel8r/korrel8r/pkg/result"
-	"github.com/stretchr/testify/require"
 )
 
+// Benchmark runs the [domaintest.Suite] benchmarks with the mock store.
 func (f *Fixture) Benchmark(b *testing.B) {
 	b.Helper()
 	f.Init(b)
-	b.ResetTimer()
-	for name, bench := range map[string]func(*testing.B){
-		"Get":              f.BenchmarkGet,
-		"ParseQuery":       f.BenchmarkParseQuery,
-		"MarshalUnmarshal": f.BenchmarkMarshalUnmashal,
-	} {
-		b.Run(name, bench)
-	}
-}
-
-func (f *Fixture) BenchmarkGet(b *testing.B) {
-	b.Helper()
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		r := result.New(f.Query.Class())
-		require.NoError(b, f.MockEngine.Get(context.Background(), f.Query, nil, r))
-		require.Equal(b, BatchLen, len(r.List()))
-	}
-}
-
-func (f *Fixture) BenchmarkParseQuery(b *testing.B) {
-	b.Helper()
-	q := f.Query.String()
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := f.MockEngine.Query(q)
-		require.NoError(b, err)
-	}
-}
-
-func (f *Fixture) BenchmarkMarshalUnmashal(b *testing.B) {
-	b.Helper()
-	r := result.New(f.Query.Class())
-	require.NoError(b, f.MockEngine.Get(context.Background(), f.Query, &korrel8r.Constraint{Limit: ptr.To(1)}, r))
-	o := r.List()[0]
-	c := f.Query.Class()
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		bytes, _ := json.Marshal(o)
-		o2, _ := c.Unmarshal(bytes)
-		require.IsType(b, o, o2)
-	}
+	f.Suite().Benchmark(b)
 }
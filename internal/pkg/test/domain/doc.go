@@ -1,11 +1,8 @@
 // Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
 
-// Package domain provides standard tests and benchmarks to run against every domain for "korrel8r-fitness".
+// Package domain runs the [domaintest.Suite] conformance tests and benchmarks against the built-in domains,
+// with mock stores loaded from "testdata/domain_test.yaml", and cluster tests against real stores.
 //
-// To use the tests in a new domain package:
-//   - Create sub-directory "testdata/domain_test.yaml" containing
-//     -
-//
-// and provide a query that returns BatchLen objects.
-// It should have test functions TestDomain and BenchmarkDomain that call [domain.Test] and [domain.Benchmark].
+// A domain package should provide a [Fixture] with a query that returns BatchLen objects,
+// and test functions TestDomain and BenchmarkDomain that call [Fixture.Test] and [Fixture.Benchmark].
 package domain
@@ -10,8 +10,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/domains"
+	"github.com/korrel8r/korrel8r/pkg/domaintest"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/stretchr/testify/require"
@@ -40,9 +42,29 @@ func (f *Fixture) Init(t testing.TB) {
 			StoreConfigs(config.Store{config.StoreKeyDomain: d.Name(), config.StoreKeyMock: MockDataFile}).
 			Engine()
 		require.NoError(t, err)
+		if timer, ok := f.Query.Class().(korrel8r.Timer); ok {
+			// Drop mock objects outside the constraint time range, like a real store.
+			for _, s := range f.MockEngine.StoresFor(d) {
+				if ms, ok := s.(*mock.Store); ok {
+					ms.ConstraintFunc = func(c *korrel8r.Constraint, o korrel8r.Object) bool { return c.CompareTime(timer.Time(o)) == 0 }
+				}
+			}
+		}
 	}
 }
 
+// Suite returns a [domaintest.Suite] for the mock store of f.Query().Class().Domain.
+// Suite.Time is set if the query class implements [korrel8r.Timer].
+// Call [Fixture.Init] first.
+func (f *Fixture) Suite() *domaintest.Suite {
+	d := f.Query.Class().Domain()
+	s := &domaintest.Suite{Domain: d, Store: f.MockEngine.StoreFor(d), Query: f.Query, Len: BatchLen}
+	if t, ok := f.Query.Class().(korrel8r.Timer); ok {
+		s.Time = t.Time
+	}
+	return s
+}
+
 // ClusterEngine returns an engine with all known domains backed by an Openshift cluster.
 // Used for cluster testing with multiple domains.
 func (f *Fixture) ClusterEngine(t testing.TB) *engine.Engine {
@@ -6,51 +6,18 @@ import (
 	"context"
 	"testing"
 
-	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
-	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/result"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// Test runs the [domaintest.Suite] conformance tests with the mock store, then the cluster tests.
 func (f *Fixture) Test(t *testing.T) {
 	t.Helper()
 	f.Init(t)
-	for _, f := range []func(*testing.T){f.TestGet, f.TestMarshalUnmashal, f.TestGet_cluster} {
-		t.Run(funcName(f), f)
-	}
-}
-
-// TestGet tests only the domain Get overhead using mock in-memory stores.
-func (f *Fixture) TestGet(t *testing.T) {
-	t.Helper()
-	r := result.New(f.Query.Class())
-	require.NoError(t, f.MockEngine.Get(context.Background(), f.Query, &korrel8r.Constraint{}, r))
-	if assert.Equal(t, BatchLen, len(r.List()), "wrong number of results: %v", f.Query) {
-		// Test de-duplication for classes with ID.
-		if _, ok := f.Query.Class().(korrel8r.IDer); ok {
-			t.Run("TestGet_dedup", func(t *testing.T) {
-				// Re-read same query into the same result. We shouldn't get any new values.
-				require.NoError(t, f.MockEngine.Get(context.Background(), f.Query, &korrel8r.Constraint{}, r))
-				assert.Equal(t, BatchLen, len(r.List()), "de-duplication failed: %v", f.Query)
-			})
-		}
-	}
-}
-
-func (f *Fixture) TestMarshalUnmashal(t *testing.T) {
-	t.Helper()
-	c := f.Query.Class()
-	r := result.New(c)
-	require.NoError(t, f.MockEngine.Get(context.Background(), f.Query, &korrel8r.Constraint{Limit: ptr.To(1)}, r))
-	require.GreaterOrEqual(t, len(r.List()), 1)
-	o := r.List()[0]
-	bytes, err := json.Marshal(o)
-	require.NoError(t, err)
-	o2, err := c.Unmarshal(bytes)
-	require.NoError(t, err)
-	require.Equal(t, o, o2)
+	t.Run("Suite", f.Suite().Run)
+	t.Run(funcName(f.TestGet_cluster), f.TestGet_cluster)
 }
 
 // TestGet_cluster that query constraints work with real stores.
@@ -5,6 +5,7 @@ package mock
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -71,10 +72,26 @@ type Class struct {
 	domain korrel8r.Domain
 }
 
-func (c Class) Domain() korrel8r.Domain  { return c.domain }
-func (c Class) String() string           { return korrel8r.ClassString(c) }
-func (c Class) Name() string             { return c.name }
-func (c Class) ID(o korrel8r.Object) any { return o }
+func (c Class) Domain() korrel8r.Domain { return c.domain }
+func (c Class) String() string          { return korrel8r.ClassString(c) }
+func (c Class) Name() string            { return c.name }
+
+// ID returns the object itself if it is comparable, its JSON string otherwise.
+func (c Class) ID(o korrel8r.Object) any {
+	if o != nil && !reflect.TypeOf(o).Comparable() {
+		b, _ := json.Marshal(o)
+		return string(b)
+	}
+	return o
+}
+
+// Time returns the time of objects that implement [Timestamper], the zero time for others.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if t, ok := o.(Timestamper); ok {
+		return t.Timestamp()
+	}
+	return time.Time{}
+}
 func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) {
 	var o Object
 	err := json.Unmarshal(b, &o)
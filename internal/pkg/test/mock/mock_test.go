@@ -129,7 +129,7 @@ func TestClass_Methods(t *testing.T) {
 	assert.Equal(t, d, c.Domain())
 	assert.Equal(t, "testdomain:testclass", c.String())
 	obj := map[string]any{"test": "value"}
-	assert.Equal(t, "map[test:value]", GetID(c, obj))
+	assert.Equal(t, `{"test":"value"}`, GetID(c, obj), "maps are not comparable, use JSON")
 }
 
 // GetID returns the object ID using if class is an IDer, "" otherwise.
@@ -2,9 +2,33 @@
 
 package api
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
 
 var (
 	Spec     = func() *openapi3.T { s, err := GetSpec(); if err != nil { panic(err) }; return s }()
 	BasePath = func() string { p, err := Spec.Servers.BasePath(); if err != nil { panic(err) }; return p }()
 )
+
+// mcpToolKey is the context key for the name of the MCP tool making a REST request.
+type mcpToolKey struct{}
+
+// WithMCPTool returns a context for REST requests made in-process by the MCP server for a tool call.
+// The tool name identifies the call in the REST audit log.
+// It is only passed in the request context, never in a header, so HTTP clients cannot set it.
+func WithMCPTool(ctx context.Context, tool string) context.Context {
+	return context.WithValue(ctx, mcpToolKey{}, tool)
+}
+
+// ContextMCPTool returns the tool name set by [WithMCPTool], or "" if there is none.
+func ContextMCPTool(ctx context.Context) string {
+	tool, _ := ctx.Value(mcpToolKey{}).(string)
+	return tool
+}
+
+// HeaderRedacted is set on REST responses with redacted values, if redaction is enabled.
+// The value is a comma-separated list of RULE=COUNT, the number of values redacted by each rule.
+const HeaderRedacted = "Korrel8r-Redacted"
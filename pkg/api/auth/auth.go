@@ -5,6 +5,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 )
@@ -54,6 +55,50 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	return rt.next.RoundTrip(req)
 }
 
+// ErrNoToken is returned by a [Require] round tripper if there is no token to forward.
+var ErrNoToken = errors.New("no bearer token to forward")
+
+// Require is like [Wrap], but fails requests if the context has no bearer token,
+// rather than sending them with whatever other credentials the transport has.
+func Require(next http.RoundTripper) http.RoundTripper {
+	return &requireRoundTripper{next: next}
+}
+
+type requireRoundTripper struct{ next http.RoundTripper }
+
+func (rt *requireRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := ContextToken(req.Context())
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	req.Header.Set(headerKey, "Bearer "+token)
+	return rt.next.RoundTrip(req)
+}
+
+// User identifies the authenticated caller of a request.
+type User struct {
+	Name string // Name identifies the user, it may be a UID if the user has no username.
+	// Username is the Kubernetes username, empty if the user has none.
+	// Stores that impersonate the caller require a username.
+	Username string
+	Groups   []string
+}
+
+// ContextUser returns the authenticated user from a context, or nil if there is none.
+func ContextUser(ctx context.Context) *User {
+	u, _ := ctx.Value(userKey{}).(*User)
+	return u
+}
+
+// WithUser adds an authenticated user to the context. No-op if u == nil
+func WithUser(ctx context.Context, u *User) context.Context {
+	if u == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, userKey{}, u)
+}
+
 type authKey struct{}
+type userKey struct{}
 
 const headerKey = "Authorization"
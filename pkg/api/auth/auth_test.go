@@ -4,6 +4,7 @@ package auth_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -48,3 +49,29 @@ func TestWithToken_RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestRequire_RoundTrip(t *testing.T) {
+	drt := dummyRoundTripper{}
+	rt := auth.Require(&drt)
+	req, err := http.NewRequestWithContext(auth.WithToken(context.Background(), "my-token"), "GET", "/", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer korrel8r-token")
+	_, err = rt.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer my-token", drt.Header.Get("Authorization"))
+
+	drt.Request = nil
+	req, err = http.NewRequest("GET", "/", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer korrel8r-token")
+	_, err = rt.RoundTrip(req)
+	assert.True(t, errors.Is(err, auth.ErrNoToken), "%v", err)
+	assert.Nil(t, drt.Request, "request must not be sent")
+}
+
+func TestWithUser(t *testing.T) {
+	assert.Nil(t, auth.ContextUser(context.Background()))
+	assert.Equal(t, context.Background(), auth.WithUser(context.Background(), nil))
+	u := &auth.User{Name: "alice", Groups: []string{"dev"}}
+	assert.Equal(t, u, auth.ContextUser(auth.WithUser(context.Background(), u)))
+}
@@ -19,6 +19,38 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// Defines values for Level.
+const (
+	LevelError   Level = "error"
+	LevelOk      Level = "ok"
+	LevelSkipped Level = "skipped"
+	LevelWarning Level = "warning"
+)
+
+// Defines values for Order.
+const (
+	OrderRank Order = "rank"
+	OrderRaw  Order = "raw"
+)
+
+// Candidate Candidate start query resolved from free text.
+type Candidate struct {
+	// Confidence Confidence that the query is what the text refers to, between 0 and 1.
+	Confidence float64 `json:"confidence" jsonschema:"Confidence that the query is what the text refers to, between 0 and 1."`
+
+	// Count Number of objects found when checking the store, omitted if the store could not be checked.
+	Count *int `json:"count,omitempty" jsonschema:"Number of objects found when checking the store, omitted if the store could not be checked."`
+
+	// Query Candidate start query.
+	Query Query `json:"query" jsonschema:"Candidate start query in DOMAIN:CLASS:SELECTOR format."`
+
+	// Reason Text that suggested the query.
+	Reason string `json:"reason,omitempty" jsonschema:"Text that suggested the query."`
+}
+
+// Candidates List of candidate start queries.
+type Candidates = []Candidate
+
 // Class Full name of a class of data, format is DOMAIN:CLASS. DOMAIN: name of a domain (e.g. k8s, log, metric, alert, trace, netflow). CLASS: name within the domain.
 //
 // Example: ["k8s:Pod","k8s:Deployment.apps","log:application","metric:metric","alert:alert","netflow:network"]
@@ -29,6 +61,15 @@ type Class = string
 // Example: ["Pod","Service","Deployment"]
 type Classes = []string
 
+// ClusterCount Cluster with number of objects found.
+type ClusterCount struct {
+	// Cluster Cluster name.
+	Cluster string `json:"cluster"`
+
+	// Count Number of objects found in the cluster.
+	Count int `json:"count"`
+}
+
 // Console State of the user's graphical console display (e.g. OpenShift web console).
 type Console struct {
 	// Search The troubleshooting panel displays the results of this correlation search.
@@ -40,6 +81,9 @@ type Console struct {
 
 // Constraint Constrains the objects that will be included in search results.
 type Constraint struct {
+	// Cluster Only use stores for this cluster, and stores that do not belong to a cluster. Default: use stores for all clusters.
+	Cluster string `json:"cluster,omitempty" jsonschema:"Only use stores for this cluster, and stores that do not belong to a cluster. Default: use stores for all clusters."`
+
 	// End Ignore objects with timestamps after this end time. Default: now.
 	//
 	//
@@ -56,6 +100,27 @@ type Constraint struct {
 	Start *time.Time `json:"start,omitempty" jsonschema:"Ignore objects with timestamps before this start time. Default: 1 hour before end."`
 }
 
+// Diagnoses List of store diagnoses.
+type Diagnoses = []Diagnosis
+
+// Diagnosis Diagnosis of a configured store.
+type Diagnosis struct {
+	// Domain Domain of the store.
+	Domain string `json:"domain"`
+
+	// Expanded Store configuration after template expansion, omitted if expansion failed.
+	Expanded *Store `json:"expanded,omitempty"`
+
+	// Findings Results of the checks, in the order they were run.
+	Findings []Finding `json:"findings"`
+
+	// Level Worst level of the findings.
+	Level Level `json:"level"`
+
+	// Store Store configuration before template expansion.
+	Store Store `json:"store"`
+}
+
 // Domain Domain configuration information.
 type Domain struct {
 	// Description Brief description of the domain.
@@ -92,6 +157,21 @@ type Error struct {
 	Error string `json:"error"`
 }
 
+// Finding Result of a store check.
+type Finding struct {
+	// Check Name of the check, one of template, create, probe, rbac.
+	Check string `json:"check"`
+
+	// Fix Suggested fix, omitted for OK findings.
+	Fix string `json:"fix,omitempty"`
+
+	// Level Level of a finding.
+	Level Level `json:"level"`
+
+	// Message What the check found.
+	Message string `json:"message"`
+}
+
 // Goals Parameters for a goal-directed correlation search. Finds paths from start objects to goal classes.
 type Goals struct {
 	// Goals Goal classes in DOMAIN:CLASS format, e.g. log:application, alert:alert
@@ -109,16 +189,52 @@ type Graph struct {
 	// Edges List of graph edges.
 	Edges []Edge `json:"edges,omitempty" jsonschema:"List of graph edges."`
 
+	// Errors Non-fatal errors from the search, objects dropped because the caller is not authorized, only included if requested.
+	Errors []string `json:"errors,omitempty" jsonschema:"Non-fatal errors from the search, objects dropped because the caller is not authorized, only included if requested."`
+
 	// Nodes List of graph nodes.
 	Nodes []Node `json:"nodes,omitempty" jsonschema:"List of graph nodes."`
 }
 
+// GroupCount Number of objects that have the same values for a set of fields.
+type GroupCount struct {
+	// Count Number of objects in the group.
+	Count int `json:"count" jsonschema:"Number of objects in the group."`
+
+	// Fields Field values for the group, "" for objects that do not have the field.
+	Fields map[string]string `json:"fields" jsonschema:"Field values for the group, empty for objects that do not have the field."`
+}
+
+// GroupCounts List of group counts, largest first.
+type GroupCounts = []GroupCount
+
 // Help Domain help documentation including query syntax and examples.
 type Help struct {
 	// Documentation Full documentation text for one or more domains.
 	Documentation string `json:"documentation"`
 }
 
+// Level Level of a finding.
+type Level string
+
+// LogPattern Pattern of similar log lines, with variable parts replaced by "<*>".
+type LogPattern struct {
+	// Count Number of log lines that match the pattern.
+	Count int `json:"count" jsonschema:"Number of log lines that match the pattern."`
+
+	// Example Example of a matching log line.
+	Example string `json:"example" jsonschema:"Example of a matching log line."`
+
+	// First Time of the earliest matching line, omitted if the lines have no time.
+	First *time.Time `json:"first,omitempty" jsonschema:"Time of the earliest matching line."`
+
+	// Last Time of the latest matching line, omitted if the lines have no time.
+	Last *time.Time `json:"last,omitempty" jsonschema:"Time of the latest matching line."`
+
+	// Template Template of the log lines, tokens that vary between lines are replaced by "<*>".
+	Template string `json:"template" jsonschema:"Template of the log lines, tokens that vary between lines are replaced by <*>."`
+}
+
 // Neighbors Parameters for a neighborhood correlation search. Finds all objects reachable from the start by following correlation rules up to the maximum depth.
 type Neighbors struct {
 	// Depth Maximum number of correlation steps to follow from the start. Depth 1 returns direct correlations only.
@@ -133,14 +249,29 @@ type Node struct {
 	// Class Full class name.
 	Class string `json:"class" jsonschema:"Full class name in DOMAIN:CLASS format."`
 
+	// Clusters Number of results from each cluster, omitted if no objects belong to a cluster.
+	Clusters []ClusterCount `json:"clusters,omitempty" jsonschema:"Number of results from each cluster, omitted if no objects belong to a cluster."`
+
 	// Count Number of results for this class, after de-duplication.
 	Count *int `json:"count,omitempty" jsonschema:"Number of results for this class, after de-duplication."`
 
+	// Groups Counts of the results of the node grouped by field values, largest first. Only present if requested by the "groupBy" graph option. Only the results returned in the node are counted, up to the constraint limit.
+	Groups []GroupCount `json:"groups,omitempty" jsonschema:"Counts of the results of the node grouped by field values, largest first. Only counts the results returned in the node. Only present if requested."`
+
+	// Patterns Log patterns found in the results of a log node, most frequent first. Only present for log nodes if requested by the "patterns" graph option.
+	Patterns []LogPattern `json:"patterns,omitempty" jsonschema:"Log patterns found in the results of a log node, most frequent first. Only present if requested."`
+
 	// Queries Queries yielding results for this class.
 	Queries []QueryCount `json:"queries,omitempty" jsonschema:"Queries yielding results for this class."`
 
 	// Result Serialized result contents, may be large.
 	Result []Object `json:"result,omitempty" jsonschema:"Serialized result contents, may be large."`
+
+	// ResultClusters Cluster of each object in result, in the same order. Empty string for objects that do not belong to a cluster. Omitted if no objects belong to a cluster.
+	ResultClusters []string `json:"resultClusters,omitempty" jsonschema:"Cluster of each object in result, in the same order. Omitted if no objects belong to a cluster."`
+
+	// ResultScores Relevance score of each object in result, in the same order, from 0 to 1. Higher scores are closer in time to the start objects, have error or warning statuses, or are more interesting for their class. Only present for rank order.
+	ResultScores []float64 `json:"resultScores,omitempty" jsonschema:"Relevance score of each object in result, in the same order, from 0 to 1. Only present for rank order."`
 }
 
 // Nodes List of result nodes.
@@ -152,6 +283,27 @@ type Object = json.RawMessage
 // Objects List of data objects serialized as JSON.
 type Objects = []Object
 
+// Order Order of results in each graph node: "raw" keeps the order returned by stores, "rank" gets up to 10 times the constraint limit from stores, sorts by decreasing relevance score, and applies the constraint limit to each node after sorting.
+type Order string
+
+// Overlay Session configuration overlay, added to the base configuration. Items have the same format as the corresponding sections of a korrel8r configuration file. Aliases in the overlay apply to rules in the overlay.
+type Overlay struct {
+	// Aliases Additional class aliases.
+	Aliases []Object `json:"aliases,omitempty" jsonschema:"Additional class aliases, same format as the aliases section of a korrel8r configuration file."`
+
+	// Rules Additional correlation rules.
+	Rules []Object `json:"rules,omitempty" jsonschema:"Additional correlation rules, same format as the rules section of a korrel8r configuration file."`
+
+	// Stores Additional stores.
+	Stores []Store `json:"stores,omitempty" jsonschema:"Additional stores, same format as the stores section of a korrel8r configuration file."`
+
+	// Templates Additional named templates.
+	Templates []Object `json:"templates,omitempty" jsonschema:"Additional named templates, same format as the templates section of a korrel8r configuration file."`
+}
+
+// Projection Projection of result objects, to select fields and reduce the size of results. A list of entries separated by ";", each entry is "[SELECTOR=]FIELDS". FIELDS is "compact" for the compact form of each class, "summary" for a summary of each object with identity, health indicators and times, or a comma-separated list of field paths like "metadata.name" or "status.conditions[*].type". Keys containing "." are quoted, for example 'metadata.labels["app.kubernetes.io/name"]'. SELECTOR is a domain like "k8s" or a class like "k8s:Pod", entries without a selector apply to all classes. Projection is applied after rules, it does not change correlation results.
+type Projection = string
+
 // Query Query for data objects, format is DOMAIN:CLASS:SELECTOR. DOMAIN: name of a domain (e.g. k8s, log, metric, alert, trace, netflow). CLASS: name of a class in the domain (e.g. Pod, application, metric, alert, span, network). SELECTOR: domain-specific query string.
 type Query = string
 
@@ -167,6 +319,105 @@ type QueryCount struct {
 	Statuses []StatusCount `json:"statuses,omitempty"`
 }
 
+// Recipe Named correlation search with parameters.
+type Recipe struct {
+	// Depth Maximum depth for a neighbors search.
+	Depth *int `json:"depth,omitempty" jsonschema:"Maximum depth for a neighbors search."`
+
+	// Description What the recipe searches for.
+	Description string `json:"description,omitempty" jsonschema:"What the recipe searches for."`
+
+	// Goals Goal classes for a goal search.
+	Goals []Class `json:"goals,omitempty" jsonschema:"Goal classes in DOMAIN:CLASS format for a goal search."`
+
+	// Name Name of the recipe.
+	Name string `json:"name" jsonschema:"Name of the recipe."`
+
+	// Params Parameters of the recipe.
+	Params []RecipeParam `json:"params,omitempty" jsonschema:"Parameters of the recipe."`
+}
+
+// RecipeParam Recipe parameter.
+type RecipeParam struct {
+	// Default Default value, a parameter with no default is required.
+	Default *string `json:"default,omitempty" jsonschema:"Default value, a parameter with no default is required."`
+
+	// Description Description of the parameter.
+	Description string `json:"description,omitempty" jsonschema:"Description of the parameter."`
+
+	// Name Name of the parameter.
+	Name string `json:"name" jsonschema:"Name of the parameter."`
+}
+
+// RecipeRun Parameters to run a recipe.
+type RecipeRun struct {
+	// Constraint Constraint to use instead of the recipe constraint.
+	Constraint *Constraint `json:"constraint,omitempty" jsonschema:"Constraint to use instead of the recipe constraint."`
+
+	// Params Recipe parameter values by name.
+	Params map[string]string `json:"params,omitempty" jsonschema:"Recipe parameter values by name."`
+}
+
+// Recipes List of recipes.
+type Recipes = []Recipe
+
+// Resolve Free text to resolve to start queries.
+type Resolve struct {
+	// Constraint Constraint for checking candidates against stores.
+	Constraint *Constraint `json:"constraint,omitempty" jsonschema:"Constraint for checking candidates against stores."`
+
+	// Text Free text, for example a log line, alert notification or URL.
+	Text string `json:"text" jsonschema:"Free text, for example a log line, alert notification or URL."`
+}
+
+// RootCause An object that may be the root cause of a problem with the start objects.
+type RootCause struct {
+	// Class Full class name of the object.
+	Class string `json:"class" jsonschema:"Full class name of the object."`
+
+	// Cluster Cluster of the object, omitted if it does not belong to a cluster.
+	Cluster string `json:"cluster,omitempty" jsonschema:"Cluster of the object, omitted if it does not belong to a cluster."`
+
+	// Preview Short description of the object, for example a log message or alert name.
+	Preview string `json:"preview,omitempty" jsonschema:"Short description of the object, for example a log message or alert name."`
+
+	// Reasons Evidence that contributed to the score, for example "status: CrashLoopBackOff" or "owned by start object".
+	Reasons []string `json:"reasons,omitempty" jsonschema:"Evidence that contributed to the score, for example 'status: CrashLoopBackOff' or 'owned by start object'."`
+
+	// Rules Names of the rules followed from the start to find the object, the evidence path.
+	Rules []string `json:"rules,omitempty" jsonschema:"Names of the rules followed from the start to find the object, the evidence path."`
+
+	// Score Score between 0 and 1, higher scores are more likely causes.
+	Score float64 `json:"score" jsonschema:"Score between 0 and 1, higher scores are more likely causes."`
+
+	// Statuses Statuses of the object from status rules.
+	Statuses []string `json:"statuses,omitempty" jsonschema:"Statuses of the object from status rules."`
+
+	// Time Time of the object, omitted if the object has no time.
+	Time *time.Time `json:"time,omitempty" jsonschema:"Time of the object, omitted if the object has no time."`
+}
+
+// RootCauseSearch Parameters for a root cause search.
+type RootCauseSearch struct {
+	// Depth Maximum number of correlation steps for the neighbors search, default 3.
+	Depth *int `json:"depth,omitempty" jsonschema:"Maximum number of correlation steps for the neighbors search, default 3."`
+
+	// Limit Maximum number of candidates to return, default 10.
+	Limit *int `json:"limit,omitempty" jsonschema:"Maximum number of candidates to return, default 10."`
+
+	// Start Objects with a problem, the starting point for the search.
+	Start Start `json:"start" jsonschema:"Objects with a problem, the starting point for the search."`
+}
+
+// RootCauses Candidate root causes, most likely first.
+type RootCauses struct {
+	// Candidates Candidate root causes sorted by decreasing score.
+	Candidates []RootCause `json:"candidates" jsonschema:"Candidate root causes sorted by decreasing score."`
+
+	// Errors Non-fatal errors from the search, objects dropped because the caller is not authorized.
+	Errors []string `json:"errors,omitempty" jsonschema:"Non-fatal errors from the search, objects dropped because the caller is not authorized."`
+}
+
 // Rule Rule is a correlation rule with a list of queries and results counts found during navigation.
 type Rule struct {
 	// Name Name is an optional descriptive name.
@@ -176,6 +427,9 @@ type Rule struct {
 	Queries []QueryCount `json:"queries,omitempty" jsonschema:"Queries generated while following this rule."`
 }
 
+// SavedSearches Saved searches for a session, by name.
+type SavedSearches map[string]Search
+
 // Search Correlation search parameters. Set exactly one of 'goals' (targeted search to specific classes) or 'neighbors' (open-ended exploration to a depth).
 type Search struct {
 	// Goals Parameters for a goal-directed correlation search.
@@ -215,11 +469,65 @@ type StatusCount struct {
 // Store Store is a map string keys and values used to connect to a store.
 type Store map[string]string
 
+// Timeline Objects found by a correlation search, sorted by time.
+type Timeline struct {
+	// Entries Objects with a time, earliest first.
+	Entries []TimelineEntry `json:"entries" jsonschema:"Objects with a time, earliest first."`
+
+	// Errors Non-fatal errors from the search, objects dropped because the caller is not authorized.
+	Errors []string `json:"errors,omitempty" jsonschema:"Non-fatal errors from the search, objects dropped because the caller is not authorized."`
+}
+
+// TimelineEntry An object in a timeline.
+type TimelineEntry struct {
+	// Class Full class name of the object.
+	Class string `json:"class" jsonschema:"Full class name of the object."`
+
+	// Cluster Cluster of the object, omitted if it does not belong to a cluster.
+	Cluster string `json:"cluster,omitempty" jsonschema:"Cluster of the object, omitted if it does not belong to a cluster."`
+
+	// Preview Short description of the object, for example a log message or alert name.
+	Preview string `json:"preview,omitempty" jsonschema:"Short description of the object, for example a log message or alert name."`
+
+	// Rules Names of the rules followed from the start to find the object, empty for start objects.
+	Rules []string `json:"rules,omitempty" jsonschema:"Names of the rules followed from the start to find the object, empty for start objects."`
+
+	// Statuses Statuses of the object from status rules.
+	Statuses []string `json:"statuses,omitempty" jsonschema:"Statuses of the object from status rules."`
+
+	// Time Time of the object.
+	Time time.Time `json:"time" jsonschema:"Time of the object."`
+}
+
+// TimelineSearch Parameters for a timeline search. Runs a goals search if goals are specified, otherwise a neighbors search to depth.
+type TimelineSearch struct {
+	// Depth Maximum number of correlation steps for a neighbors search, default 2. Ignored if goals are specified.
+	Depth *int `json:"depth,omitempty" jsonschema:"Maximum number of correlation steps for a neighbors search, default 2. Ignored if goals are specified."`
+
+	// Goals Goal classes in DOMAIN:CLASS format, e.g. log:application, alert:alert. If empty, a neighbors search is done.
+	Goals []Class `json:"goals,omitempty" jsonschema:"Goal classes in DOMAIN:CLASS format, e.g. log:application, alert:alert. If empty, a neighbors search is done."`
+
+	// Start Starting point for the search.
+	Start Start `json:"start" jsonschema:"Starting point for the search."`
+}
+
 // GraphOptions Options controlling the form of the returned graph.
 type GraphOptions struct {
 	// Errors If true include non-fatal error messages.
 	Errors *bool `json:"errors,omitempty" jsonschema:"If true include non-fatal error messages."`
 
+	// GroupBy Comma-separated list of object fields. If present include counts of the results of each node grouped by the values of the fields. Counts are computed in memory over the results returned in each node, up to the constraint limit, they are not counted in the backend. Use the "groupBy" parameter of /objects to count all matching objects.
+	GroupBy *string `json:"groupBy,omitempty" jsonschema:"Comma-separated list of object fields. If present include counts of node results grouped by the field values. Only the results returned in each node are counted, up to the constraint limit."`
+
+	// Order Order of results in each graph node: "raw" keeps the order returned by stores, "rank" gets up to 10 times the constraint limit from stores, sorts by decreasing relevance score, and applies the constraint limit to each node after sorting.
+	Order *Order `json:"order,omitempty"`
+
+	// Patterns If true include a summary of log patterns for log nodes.
+	Patterns *bool `json:"patterns,omitempty" jsonschema:"If true include a summary of log patterns for log nodes."`
+
+	// Project Projection of result objects, to select fields and reduce the size of results. A list of entries separated by ";", each entry is "[SELECTOR=]FIELDS". FIELDS is "compact" for the compact form of each class, "summary" for a summary of each object with identity, health indicators and times, or a comma-separated list of field paths like "metadata.name" or "status.conditions[*].type". Keys containing "." are quoted, for example 'metadata.labels["app.kubernetes.io/name"]'. SELECTOR is a domain like "k8s" or a class like "k8s:Pod", entries without a selector apply to all classes. Projection is applied after rules, it does not change correlation results.
+	Project *Projection `json:"project,omitempty" jsonschema:"Projection of result objects. Entries separated by ';' of the form [SELECTOR=]FIELDS, where FIELDS is 'compact', 'summary' or a comma-separated list of field paths, and SELECTOR is a domain or class."`
+
 	// Results If true include full JSON results with each Query.
 	Results *bool `json:"results,omitempty" jsonschema:"If true include full JSON results with each Query."`
 
@@ -233,6 +541,12 @@ type SetConfigParams struct {
 	Verbose *int `form:"verbose,omitempty" json:"verbose,omitempty"`
 }
 
+// DoctorParams defines parameters for Doctor.
+type DoctorParams struct {
+	// Domain Diagnose stores for these domains, all domains if not set.
+	Domain *[]string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
 // GraphGoalsParams defines parameters for GraphGoals.
 type GraphGoalsParams struct {
 	// Options Options controlling the form of the returned graph.
@@ -258,8 +572,23 @@ type ObjectsParams struct {
 
 	// Constraint Constrains the objects that will be included in results.
 	Constraint *Constraint `form:"constraint,omitempty" json:"constraint,omitempty"`
+
+	// Project Projection of returned objects, to select fields and reduce the size of results. For example "compact" uses the compact form for each class, "summary" returns a summary of each object, "k8s=metadata.name,status.phase" selects fields of k8s objects.
+	Project *Projection `form:"project,omitempty" json:"project,omitempty"`
+
+	// GroupBy Comma-separated list of object fields, for example "level,k8s_container_name". If present, return counts of objects grouped by the values of the fields instead of the objects. Nested fields are separated by ".", for example "status.statusCode".
+	GroupBy *string `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+}
+
+// RunRecipeParams defines parameters for RunRecipe.
+type RunRecipeParams struct {
+	// Options Options controlling the form of the returned graph.
+	Options *GraphOptions `form:"options,omitempty" json:"options,omitempty"`
 }
 
+// SetOverlayJSONRequestBody defines body for SetOverlay for application/json ContentType.
+type SetOverlayJSONRequestBody = Overlay
+
 // SetConsoleJSONRequestBody defines body for SetConsole for application/json ContentType.
 type SetConsoleJSONRequestBody = Console
 
@@ -280,87 +609,182 @@ type GraphNeighboursJSONRequestBody = Neighbors
 // ListGoalsJSONRequestBody defines body for ListGoals for application/json ContentType.
 type ListGoalsJSONRequestBody = Goals
 
+// SaveSearchJSONRequestBody defines body for SaveSearch for application/json ContentType.
+type SaveSearchJSONRequestBody = Search
+
+// TimelineJSONRequestBody defines body for Timeline for application/json ContentType.
+type TimelineJSONRequestBody = TimelineSearch
+
+// RootCausesJSONRequestBody defines body for RootCauses for application/json ContentType.
+type RootCausesJSONRequestBody = RootCauseSearch
+
+// RunRecipeJSONRequestBody defines body for RunRecipe for application/json ContentType.
+type RunRecipeJSONRequestBody = RecipeRun
+
+// ResolveJSONRequestBody defines body for Resolve for application/json ContentType.
+type ResolveJSONRequestBody = Resolve
+
 // Base64 encoded, compressed with deflate, json marshaled OpenAPI spec.
 // Stored as a slice of fixed-width chunks rather than one concatenated
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"7Hxbcxu3kvBfQc33VcmqHZKSk6qk+ObIOj7e+KJjOvuwlvcUONMkcQQCEwBDisel/77VDcyFQ5AeXeNN",
-	"/JJY5Eyju9H3C78kmV4WWoFyNhl/SQpu+BIcGPrrleHF4n3hhFb0dw42M4L+TsZJ+IJlWjmjpRRqztwC",
-	"2EybJdMz+rcBVxoFOZsjqGGSJnBdSJ1DMnamhDQRCOn3EswmSRPFl5CMEx1OTBObLWDJH+rowugCjBNA",
-	"xIAx2kTIej1jiBoTKpNlDkxpNZhxxyWjN9gSrOVzsAjRbQpEeKq1BK6SNLkeaF6IQaZzmIMawLUzfOD4",
-	"nM75l9WqougWx9zcpIkBW0rXA9tZKSX7z8n7dyy8wtbCLRjwbMH+gWx+YLR7nEf4lxJ6YI+PMZQCy4Ty",
-	"F8cgf3hmHzjnBtENR+npvyBzyU2aWLeR+AkKGBHkQdNJZ5LbCG1/Q87gGSiQnGX4FP4z546nJKncMWHZ",
-	"y/dvX7x+Nz5782IyGVZ/tV7M9ZILxZ7BcD5kVz/blEk9T9kSnBFZyrgE41LmDM8gZQrcTOr18ZARvAAH",
-	"r0Qo0goPbXipSBX5skCyPiVXP9vxhc6TlP71EgqpN0tQbsiLAhVR6vmYF4UUGSfy0sSfP/b/S9KE8BjT",
-	"f1GTPR5jBW6tzVXyOU0K7hwY5Myn/xl//o8x/be5VuuMUHO61bke4IcDeyWKgTcGXA4KLZQD4w3HTerZ",
-	"HhOqN8I6ZJ3nuL/lmTY1K4fbpHuyJ2BWIoMkTRriEWvhYBk546yGzZ4hd3XpqosqDMzE9fEOZY1YcWP4",
-	"5jaUamU1ItvFYuK4g8relRbMkfXCLDIu0TjiaywXtpB8EyTofQFqshAzx9YwrZ459gKxbSAtcJMt8F9c",
-	"yvezZPzpS/L/DcyScfL/Ro3bGAVVGE388zef0w6aHxfAnNHlVIJdaO3QWhdcgaxQs8FgewNC9Ag07saA",
-	"JHljHpfhrZT+AY9FjV8JWPdnBtm+PbwgMaluB8EeRuj3ymz3J51OJ6F3sfNStHptuzOenL85P/v4/kOw",
-	"S/vMIEqiM1woF1GJ6jtPhX8J/80dWwsp2bS2vzme73lbURvxzyqPOIy50qYBTr7GiSVYx5eFZXzmwHiu",
-	"gcrpmyF7CTNeSjdmSq87hi95fnL60+Dkp8Hz04+nP41/eD5+/vPw9IcfT5//cPrfSZp4biTjJOcOBggu",
-	"arB6u6H7Yk+CKMVSBP7TV8n49OQk3TGCS+GY0xhQqHI5BYPyVJ1cgAli1cA/PTnx3An0oQ2ag7kVgXc7",
-	"laiiL95ESOtHGb4uwJ/hDX9eGgoLDV+BsVxuHfpIlN4WCx9KOG7crUV9CjP8mqSFIHTl5ZQtdGmq50Dl",
-	"nuanFek7YLnH9Lwk77rLJv85mreZmJfG222hPJlCq13DsvV+F9wvRsCMtT6r3GsTO9w1YPHZTffAdyHQ",
-	"O3QGCYk2sWBnQp/X5ENeW/0GWB3DHPTeCOjOQQqlKL+XwkCOMRWR+nnvLR4I2n4l7/uzCehbxlXeps7z",
-	"oTdVQWruHnud5/PIpb0UBjIHOSUNLITW3pP5ACxlM6OXbOJFXrNXGgMyH7HuSuRcc9k/tPD5xm5o0YpJ",
-	"gzwhXKZ0DreLHg4A2okcWgHD3ixvAnS19C2baSn1GnLGpaasHb1dPofeV/qhlHeW09twoSfWNwRziagX",
-	"blPLTW3UH/pOvRV9iEttIB261Y5me7pSL7IxDT/3bOjKAH3sCwX+2ZaVa71sjDaRl6kiEvQr08pxodCr",
-	"crVdLNlT5NkHsPVWx952iPZQYtSiXkck/qIuo4XME9k1yCurEUkx2N+Eyi0ruFtYbzv89dRhtPZKWNmQ",
-	"SL42j+PSNj177jlllBp2kvxQW/A5/f6CwXYh4HNPLQ4C//hq/DDkdyK1ngkxPb6r1PQ5ZaNIVO2w75Lj",
-	"fgXUjvp6GakoiUo0uq+IFFGNzKsgnkciyvfk6B0dzOeHyjSdKl8v6SGv/ATCE8UReYpW86s00UO9aXqn",
-	"8z+ApoBjPOb+O8hib8S9AFmwXGflEpSrwm5M71E8KJtjdqMcv6YQLpiOSPCzBWJPGXX7GAfXXtS1AoZ2",
-	"HFOHEC/ePUDvaMo2WjFNeQdivphGewg79l+FZxdaHzL/XMra4hvg2YJPJXhdazz2dBPCEWR0G5YPVcoC",
-	"nYWv+1yLZblkORRuEfMY9MUu9m/De01eu4Wxg4L8kUeigx2mdoVbsNPQf7HMe702CMu0kpt7p+BPhOaf",
-	"xPL7uz5k+ckA7eanOt+T4YRAzNYFSy/qVqi5BO9yd7U9O9AsaQr2w3vVJDrADqYsmS5jxcx3tUy1ifP1",
-	"YQSchmJdDoO8rEOG4f0E+q6HVuWzWH2DSsECLNsIkGSa47B7uykqLZ8R157AWfXGvmmSxvJPI7gU/4a8",
-	"nUMgVSlb8g2bApPc3CIFfV/rzOMnoX1R31F4r2n7FP1A8BIOerro5SZNAkfRwOa58A9dtAyH51snDuGO",
-	"B2/JbMMnbinN3M0vW9gkY2Lz8ANfv/VJYFIjcYAzeXOi3XPkE8jPTer7O3Fd922fNqb7ms110+eRus6t",
-	"tvdW8zkAvdB5yrbyrQ5wW3BFsNfaXB0PWYXuOMAZ2AIyMRNZFW2SpwhxTqzZfO+Wc8v27eE91b9V145H",
-	"3GBPt5MyvRTOQc6EL9p4UtfcMqUdg2vISgf5Qzue3sfWnZv7d0Yb4W2HcSjId+19duH06XpSgORKG6+1",
-	"+2/YTJcqZxXUyiDULqlu2PYsvCPUe3nUrun3NxIz/VQ83SEMP0XrwHfSCS/QnMlgAasGF6Z0lSsmWa6Y",
-	"Evpdiq/EfE//5UAfBHFQrCKyacOs4AHCwn4n9Aqm5qDAcNSP9UJghlbnYyQAyLlvPKDqRcFN777OpJ4V",
-	"6Q4FdFNd1owYDtkE0JjwzMmNT+Zn7IhKVEfsmcO4BhEM7znNaoMf6nnHmP0fVbk1vqQLJF/lkDMaNAz9",
-	"QKfRpWEGdHywctrPgvmi764Fu33V91byewfwVKpqlyn6EdhUNnoQ+bXSxv1o/Cr0eOVqEu+pR5LmaA2T",
-	"vXastCWXclMJHdja+DnN5uCaYgICrGOtaelYxlU9atgKEytHwS1bg5SM25FQ1gHPW6Y1Jp910vwQzSQ9",
-	"28F6yD4EJWfrBShWYg7PjsK3R0hwYfRKRMkZstctv2CaIZ8UObRhy9I6KmxNoZmxoqTtUj2GhdtL5b46",
-	"QJT4exMXqgvteamet9e8E7nCew9Z3YKR9zwLGaD3pVSTre5WJJeqbsWyI2IoSaHIQTkx27QQYig7Q/ZC",
-	"YrzPyZE7zY7CnR35O93oknFpgOcbtuD4SEOQF8NvLe/vzx6fYR1iTr+YBo1hTGUuE59ujemgsQUJmdPm",
-	"Mqn15yNKvfBSstTWsUwvl1qxNd80XnvDeAOe+LJvCHn85ZICDVvwDC6T8WU1DXaZpP4b+nC5GRQ6v0xu",
-	"evccQ+bxdEHWPpbeZfSynSbsyUy62Sc6F66yKmG5QxbagbCVFiqtIJJ31hnUXiz3pXiHe/ABaDT4pNGl",
-	"vbWjL7vTVJEZKp//LHkR6gjsCjY+z1lxWYJlpYUcxTnTSpFqaZJobSAyyoDYwzWZJPlSZxFm1INOv1kw",
-	"7FUpckjSpDQyGScL5wo7Ho2uwjPDuXCLcjoUuv5ohGQINdP+HpXjvnwWVmgujCb9r07ZAR0gZnrZgKz+",
-	"scuhGtnq3sAyPbVgVnwqpHAbZsVccVknhro0me+3c/ZrOQWjwJGtKq0DQ/FV4KP1STS1Z3Ixm4EB5erp",
-	"r2dSz21VGbKhNGRD4cmmbdj1qcdf65A5zaalkDmG0NQEpcKHpGyocQoNzQaIYM4sYPLiMAqyFuGhKNOq",
-	"S4mXGHLlUonfS2B///jxgr0o3UIb8W9//AJ4jtSfbQ0rZguu5khMNaKNog4psZLkq2IV9aQoCLHaY1uA",
-	"qXDxHgFsGMLUpcNEN3Y+swsEUndqgnWtAZFZliIDZaElUi8Kni2APR+e3EqYRlOppyO8zdGb12fn7ybn",
-	"ZIGFo/nrmskfzicf2YuL10marMBYL3arUy6LBT8lG10BHDTfnwxPnw9PBzmsECbmfrwQyTj5YXgyPPVV",
-	"wAWp3shPENKGWxmxeG91jh7K+zbIO9OkFhxacBsKl1LP2QrMVFvhNsdMo4ybUtFEkvVrJJ6HaIIIwuvc",
-	"z5P5eyfEmjW7T11k/otgA5OwAkkiJvV8LtScSgqRfTmPDGzty4Xerx+gXgrl/zjZNdcYYxqwhVah5vX8",
-	"5KSyKRDi1qZIO0I/R8sh9UkH5zRoGIysYWeB71dfayuXS242GGySEuxjPHfIYRodRltLPvdTUo+FJp8R",
-	"2Chr9mTmELnlD6HVi3FKVhoyNFs6hwei8eBsavTa0hC3wKdWgrOL3z6y6oghO1d8iraEz5FgNCm5sJle",
-	"oRnAALnayEG3IrW+onE1F5OLVyQXhPgj3kR1xJ67SJMfT358uGunebnIUUp3GM5XXEjkZEccXoGLX1Hn",
-	"/ommzzdpXK0nlfVsX7FGu0jXRnk6JgVM+Ct+e3bBnNYS0/t/1lf9cQH0TZAFSvnqJMLyZeMOnsXM7THG",
-	"7PgomTgPZL99qOWAbPkvOt88hQh0yi4kvEXOgylsb5ENk3ZYFvoiT28/zjq7bRW2tswysHZWSrnxUn3y",
-	"+FIt1IpLkbcqmx1pfsuvYJ/gUxwZBDIq3Oj7qpwjiMVgqvPNIFj/8FnSNoEjWFUb3VFL+BuxywcTzoj5",
-	"HAzkaPk8H5mpQgkKy2q1sAu9/qdQT64a4bbPPVFflTcH185zYGCdAb58EB2ZTM6ZB8fWCzDgAz865qgK",
-	"YgXIvJmL4ZSqD0Dh9eWsktkw/3ypOlKCBxC46hiaaIrJebiXg2KzzyZelHZBRc8Y4GpyrIuKv+nwjLAs",
-	"BylWJDPhhY6/DAEr5GRWX503njNIZtyB0gQWeeeAnXerhGBd+6d0MWY/F3r9Wv1hJvRsL0MtLfNt8ekb",
-	"saLeDCCC7tuxnE8fitTS2lVIvDi+R0UqG3hvk+1T3dEX//+bUdbstR+MYpumbHfRnYxv6FrkWxv/2xrz",
-	"Rljnh3mrXfqvZCa7u2rIDUKjmrCfabO1YHv1s62yFszGmqTFv7+jB+0cplsQesxMpeLAgfj4z60P4T6V",
-	"dr7WFwnI8dq3ZC7ywwqVLoQRhJaE30aio1uAvt8WW3ckT9xHzu1jpljVEX9ZEaoHB3uKULsG3Jrej0gQ",
-	"lensqO7TF9rG0r2m0eB397ZWqFIMNqtWnjaRTqYvufHWotWQNbLpS4Xt7TMpw76WBJ63lmLax/oyMUFs",
-	"4r6dEgCCfhVWczoWOHYTzSOjrZ9o8hby4cOeMPAQiYl9y6+1r9bdU3vSQMfvLn3Xv7j+nVEduzPw4KX6",
-	"wMJhNZawFeeEJsAtI52gxVsDKY+ryTTyU8dEW7Mk1aRHV8H1rKXYVNH3QLRhEqzf+u1o/BZye/W7Gar5",
-	"JnW8NfPTR8+Rp/VFftfyb1DLt6TdyzY3NKMZ9VBiBSpsij24qpddXS8MZNw1Mf9fRvvL7+r/Xf3//Oq/",
-	"CIvDB9O92e5mL2WTUsZSg7S1XdwqeKRbq8bp9q5xRBlppfkRpZPg92l4YjZEK9R8Sr164sXBJAifrstE",
-	"d+Zu3fUnOA/I1ZdVQecOJaR5xYz/q+WjQ9f+7RRw2vLWKqgfLN5gtv4HZ95VwaC9Hd/+YaIwAbngvqM7",
-	"Bb87D3ksId9XIKrS779y/uwXQ7+71q+41koe61/FsvX4WBgK3BpzfQB/2hqjjhr9c7+Q11j3uoBKg9Cb",
-	"ozCXhxq1VYOLbCf4WVH2UoNf9ivA0M9qc7XZ8svcD6TF9KlapP2KL/hHe21zz2xT9ed+M99j4vcmve+P",
-	"lbYG6Hv8gHlr7D+9RWexmvrv/tz0Yzqu6qq+K31c6RvNojtO698LacxA60fN4rEbAgSzqpTAz06OeCFG",
-	"9YAj3nF4b89sIqi5UN0BudhA4nBLDsOA3K4GeOXzPXe5uzQ+7KrgLoRXYYVwt55ot3CoTN4uhF+MyOfA",
-	"puDWAIpx9uq313VX9tmH88nHYx8YKPbidZjZevb27OJ4W9WoI//55n8DAAD//w==",
+	"7L1tcxs3sij8V1DzPFWKzx1RkrN1N6Wt88GRvV7fOJFXcu5WXct3C5xpkjgaArMARhJPSv/9FrqBeSOG",
+	"HEqU7GT9JbE4M0Cj0Wj0e/+WZGpZKgnSmuT0t6Tkmi/Bgsa/3mpeLs5LK5TEv3MwmRb4d3Ka+AcsU9Jq",
+	"VRRCzpldAJspvWRqhv/WYCstIWdzN9QkSRO4KwuVQ3JqdQVpItxI/6pAr5I0kXwJyWmi/IxpYrIFLPm+",
+	"pi61KkFbAbgY0FrpyLLezZgDjQmZFVUOTCp5OOOWFwy/YEswhs/BuBHtqnQAT5UqgMskTe4OFS/FYaZy",
+	"mIM8hDur+aHlc5znv4ySYUU7THN/nyZzraryx9U6tGdqueSHBtzGWchZIYx1GFDT/4LMspmAIjcT9m7G",
+	"Sg0GpK0nzFQlrWmwZaqC/gSeLZhUOTCcFXI2XeE7N7yooP4iDH1G43DthlyWlYNCSLaEpdIrpm5Adyao",
+	"t0XIZqaUVSWzCl/MlDRWcyEtK8RS2NT9usLxpbIENn3u3p7y7BpkPmG/GsAfrgKurhJWU7OD+YhQYtw8",
+	"OAjjRcGW3GYLRz7+6eRKNvtqrBZyvtO27mM/EPcBX709wAH8TkzYuSxW27Hr9wbxtgnTRGpK56Dduv5/",
+	"DbPkNPn/jhoWcUTrNEfn+NJ9mpTcWtByxDnizFTLJXc0MWOFmrPwqTu1+IODdd/HavSsbumlVm6Xti3+",
+	"A73mlnmfJh712xEwq4qC/a/L81/q3boVdkHb9HfHAfe89BHzuUXrqoAR0LvXmGPQxtEW8lQG+f754IZ5",
+	"7u/v66noPDn8G7sq3C+O9+OC/Da5mc64zEXOLUQYZ3jEjOXaMryDHKZUcQM5m2m1ZDMNwCzc2fXbI1Ny",
+	"JnKQWWzo+hmzC27xrNHwwrDb8Isbl2mYgTbMqpRNwd4CSHbMuMzZiZvSLYnb5DTJVTUtoEG0rJZT0Dsy",
+	"pr0A5RCMrGR92b8gUA2zc0eskjm7XYBk2QKy63BNG6s0pEwthUVWPmt+dXyqyJHTT4G+grxFYkJamO+4",
+	"9KcEzOGDxJfT3xJeFOez5PTTZvaBZy+5/5yOocjJbpscJWoh2evzn1+9++X07P2ry8vTyzfv35x9PL9g",
+	"RF+eDQA3Sq7v6kdHEUgxpprPwTi81LQzid6Vc3Xofjw016I8JGGOF4elcnung+A3flFbILgn6P9VCQ15",
+	"cvqpliZbR/RzhG/UuIowv/f+1s4i+BTE8oSFpdl2U9RzJA3n4lrz1Vg8OTgLbiIg/tVxd8cnHZycZe4t",
+	"98+cW576nXVHu73zk/BX68NcLbmQ7DuYzCfs+geTuksxZUuwWmQp4wVoJ4JpnkHKJNhZoW5fTBhREo3j",
+	"rhUvjtFoJETBHV+WjjV/Sq5/MKcfVJ6k+K/XUBZqtQRpJ7wsnZxfqPkpL8tCZByXlyY0/yn9L0kThOMU",
+	"/+sUBYLjVIK9Vfra7a+/15PT5NP/Pf38P07xvw8lz4D2jbSBGKebyskSAZWT7tJp2Zegb0TmOHiz+ORz",
+	"i4p6rKAem33nsKsqGzaq1DATdy/WVvYYAquMBX0W5+r+KckOMs5JI9cjfTU8nFvbJLaIHS8XT3d+vshF",
+	"0ecOAbIwU5Q1KGlUEbnZLy23EBSgyoA+MCSiiIwXKE+rAlguTFnwlT9T5yXIy4WYWXYL0/DOCzoiXaQZ",
+	"4DpbjL9ILun99Zvko7vJNcoMZqGUdddbySUUATTT1/nsQhiWKa2hwBPICJbdbp89Tut27UbA7eNvVQcU",
+	"HpywO27YzQA94OLF2ZEN2Nh86Zg7+H6AEklFi4qZ9IxWUau37qa8FUXBprVUjeeEcBtWu8OZRS2zMl4Q",
+	"Mn6ZbufoixSFQ/8Qp8+VF5QK5SQrxXh4d8Jew4xXhT3tj+i0cf/SoBa+Z8niCywMKRtkHlG45lLpZhuR",
+	"31qxBGP5sjSMzyx46EDm+KQ1pVS3vUs3eXl88ufD4z8fvjz5ePLn0+9fnr78YXLy/Z9OXn5/8n86ugW3",
+	"cOiGe5Td49HQI2LQDEGowUfJ6cnxcbp2AS+FZVZZXkQupBK0P8DN+CfHxx2KeogS8bBZG/3gfWRp41bm",
+	"hU6cg4SOvNKosWh+A9rwojPpE610Vyhw5Sg170zqU5i5x0gtOEKfXk7YQlU6vOfMf1fy2Un6AVAOMPnX",
+	"gs+l2ihskgqahxdHKyB+aGEeIR82Y6yBVz/ySojTuOaVBs801+8YEmIjA+HvQbKqP16TDuGu5DKHfAch",
+	"yY0VkQsuvVJPEJP04bkULMuCW2A4mRFKdiwC9a9sxkWBNoA0mQmZCzmPoOiiLVx4w4FJg9yKxlYycN+C",
+	"BqYrOXpv/0pzru2s46NwA8V4HL3H19dx9A+ljWU4WGP1p4VOyPTmcPskWxFO19peTNYkek9TAZyw+tam",
+	"xGT815spsQuNkMRbcP41mm5/3x/uRy1gxlq/BUQ2yuJDNVTylq0pSl6z3zRH2DoT03Dc7+2THITaZrBR",
+	"1Ok3+4Fcp7fFuNThXdzAOH9C5eIH7cE3KNH1+dQO/JSo5uHM9E0+hxgf1ZA5/gL5HAJvIEGd9MuUDNKX",
+	"dM8o9lbxgq5hiEjyc8V3OP1kYIrYIxsjhKcnNy76S3a0Sg4PtKYYdW2ScdfEJeDW4lM2U0WhbiFnnERy",
+	"FDHzOYze0ouqeDCd7oKFkVDf45hLB3ppVzXd1JLUvvcUB97LpjYjbdrV3smmdaVEsrET/obQ0KcB/Jm8",
+	"W/Rui8u1PtZaRRRa/Dmcr0xJy4V0oiyXXef7QNDA0ICtr3r8trdoGiW22nCnD4gRJGZ5f4STJNYhxJ83",
+	"Xwv4SsqUpF/8FZuyTAP+v9RqCinTU55F746ZuIscyto2PxN3jcDkbo/zn1piw8Pvu1qoGSHKpInfjHVA",
+	"/xF8XYiGxoC5ecsIrY1sEYaPbaJjzhG29aGOrfH2Ykfzh3lg/REzGHPkYFjJ7cLQBUBnrBXJMG9fBBGb",
+	"4jwOS/v+GDisKUPzZc80n7KWJX7YzN81338e6y4hrvX0vHg/y+/puGOFYPd6TAjmmiymblG11PUQO+yW",
+	"odZ4MNFIWEmUop0MEqEi97Pno24+JFE+YEfuMdJ8Tv+Ii229+IJR1IOi1TMQTxRGh9OhiLJfuqFd/ig3",
+	"W5LWBzrXqsRgH8h45QOaMl4UoJkwaHnklV0oLf4bcsfAi1XLwjtjblORB3dwthc/0U7+9i+wXod/jOTZ",
+	"RlN1kNEomvpF5V+ApuqQpPvoWVRVeTbWS4ZW6wW/IdwavqzD+OgOMiSV+ri0SJjLyHm8zoLRansP1+gO",
+	"fo8iiIPXfcrzXBD2P3QgX6P6nvu8FUdXc0icIGVXyVWCv3WQ6E3/NS4RhHWpc5eVbgICxf/RUKzxdI+g",
+	"TV7OhpA2HhpVlT42MWUF13Mwls2ENnb0IWomeoTi/DcoykFrzQKKkuUqq5YgbTDZOD7hbiUKgjErafkd",
+	"qv9eYjEx82RriIGYi+40GDCFuySBOR0A7bQI1SOE3TULVxus2F6+D7JxbxeD6Y4HCdzBBLJaumGVE2gd",
+	"GCU4me2Wa0lA9vWT5hC9V/MPIdBiXb7FB246I5ai4BRfWQgJJiXz+Q3Xgk8LYCXX1jANZcEzCm69Sq6q",
+	"4+Pvs//A/8FV8gBmVE9HZwVje/GU+OCQvTGm7RPd37ck4zV1kR7QxtQRyGHQyaO8FtvGJu6pTQSRH0Wj",
+	"IwLXhQBjW0MIuR4aR0hAbiQVOUD274jZDpf3HvJtqyq4/QrXFIOKVhR088iq/JN6jOakWXUN0lPmDder",
+	"OoiTFsY1bD94j1jY/gBrgxW54mrkhEuuOXExFvkLiPliqvQYzVz6dxdKbVLMnWc/3M4aeLZA3tZIu6is",
+	"T1fe2ue2tj0WWQKbcPwlvxPLaslyKO0ipsvjg3Xof/bfNb7aDsQWSrQUEBA96Jy7srQLduIzBwwje0R7",
+	"CIOC96Pdys8E5h9EJ6e93qSTo2oS0TXzAQeCt3Ma/3OtAAg5L4CMIbGYoOHg0yYA8nEcozfYRo9AiKPZ",
+	"JAXU63Mk5E5lE87T4vRS1Uc3Fs0zOsK3HTz5HLr1npc5Kp6/TTE+Poobk3p/eQ6HeVVbyPYmY+02aZ0k",
+	"F4umHcp0c3/2E93aCVZ9hYfyrerErZYBIqRntbPPSJenLd+SqlUDMjZT60qOJdDHK1+7ZZnsFdU+J24b",
+	"2jZsjE/sGsxSe99NCWuFFLeA53WiWMqWysGI48s4XXQSywaoJEzZJ5Px+9rSxJ7DPrV/NK3vkg8xW9+k",
+	"v9MDtnIE4y7ROG8YzbTdeKtnOxOjob+vswljPm8teOHsoG2/JaBVZsmdKE0HaDQOzmtB4ukd32NBbxBw",
+	"NnjP+yd1orJPrBXSD16HVqGtE+OrJoy8xSSbDNrUokG956Ov0u7RfXaj+4Pwcr6boEAjXWbx0KELKOCG",
+	"ywyYcW/sAokPbzl2c55M2N/EfAGahvGZ5YUyoPFDsYRwLXa8oSmp7OTAV5p5c5Z7yVbG3TBK41hookMs",
+	"g7GBIOwChA9sjbBzzeW1x1l3m7dlaD7Dxu8P7ZuWva6hkGowpJlsMCl7JvB8jpj7NPHcbtBfQEjv2Za5",
+	"5QGLpuFh3GDYSdzy76FJTnGPJhf89mcfKFADsQEzeTOjGZjyGXi7gzTUAOglgrif27J5qDLQuK1O2VWi",
+	"+e1Vwq4BNfo6yLUW3KYrH3WX4rvy2glBYIMp5OQYz7iJCr4hCoI+N0o7drViOWQauKHLtXMcKIUDPfhD",
+	"Q1rVLpWA2oUb2BnLr2TLXK75bZIiwOumcYda997hDdeYEug+QHRd4Ff+n+5Th94b0AVfxW55g5HF3ehT",
+	"Ra+njOfO8emZ35SbXtDshL1z1NFz9/lUUB5WrzWYUqEzgBkqY+Clt+sQK9mdfiYKmLBXheA+WgL3lGBC",
+	"zK4cSGTN6j6NGbE4jbO+9lf1wfTGAP/mVyjRDIGaxjDunwVcb0f1ptDH9sx9U+JXjqg+uFFk4ZMdUTUU",
+	"ytyafMco30fFLj8QPzVPW0cKPdoRK8E6vhkxjlfldQjgV05CPWCjuKqf7oSuWJxFq8rLusOgftYSaGo5",
+	"1CpmoGjK/eAVpCGvMs+WxX9D852ZsFd1mSCQFnXFpoAQemj+cpWkdEm5F7Bkx1XyKeSQ/ufnv7578/71",
+	"5VUyYfQvesHtG8+sD2Yg7o+/1HWyvNkQ7WpXia+U49/vVM5pS5ToyRU5SCvsKmUL4IX7QeYi41ZpWi5e",
+	"4SRys2ygJhJix0c3FuLa2UWWYLmTgiZur68S9/1VQhL8JHOXFl5Xn/7j88Ttl1vwT7Ay7Rjeq2RylaCc",
+	"/69KoRnNLcY7hthBPUHBp1CYT1cJL8vJdTUFLcGdAKGOaO7PBxMWMOzwWZdK8KBe/2AIwFB8ofndBUHi",
+	"jvndDCn83NOF0s29yYsmfpO16EoYL7XkXibxTFM4fRUoOCpbcDmHLmv1NNVLBvUb/xcP3H928Jx6BJcL",
+	"bh7n1dx0LibsTYy4D/5yUOcWOapco+rU1WnR0KLsA7+ag5QdeBo9GE1pJBBGN1bptiXm76Gqy7otiuJy",
+	"2tL6UMWNOs/7iUpvtGp/dCpw+EE/qDxlnfDV3uCm5DJlvozGi4bgT/04h6aETMxEFqJokCa8XBeruPHo",
+	"uhst29wA7nuVKIbz2Ue6NdYCAGipt5wOGdxBVtk9FiHaddo9lhhqiLfNM5ARPLDcQX+cccWGgk0mXmHD",
+	"PfFGZiU7x6wxmdY1GkYKdG7Ux4WgRasMxcwfF5CJciAzLxZNQATdVN6c7Or4x8e9yAXT8lLvwWm/eYr7",
+	"fnjlYM6FRtz4D3GX9bPUkNoMADoPRyRKNGkbLez+7jIbYsu4H5VPSsh7nLc/Nh656DRfbo7NWYNiXH4f",
+	"vo7DPMcGDMN7PzqrtQ1yxMzuHjb8IsYufIWJtWhZekAu15TxZhB/qSrmv2XCsADq4/b7oXNu5Smv13Oq",
+	"Ozh5cp6yGYBxJ2oLxA85VB0YdqO4i0puPIBo5pOMt47gWlXMVrmikamqzTeRfNX6oZu8MsCENBZ43j1c",
+	"LaPuZNeqmDuP3+VWD8w/6J/ikAUwXfUjqta9C/vzHG2BIW4Yoc82OnjwhR059CPcFRdUtTUSpxYKuCLp",
+	"0lvun2vVHJ+PjFFoDjVH6/qShvE5d6TXMlU+iIrHDo87C3d2A866lhNeR/J65ZFJZZ1m6H0Umv168f6R",
+	"gYCPmjkSH3wXj5y8UMqe8cpECOaVDFYuH9iP4QnICJSyjLLUUOl2SdIFLOkSW3NGPzSSMrCdtZz2PcRV",
+	"9oZuhVNujLJovulorG071EAA5ZPfwHuAEpm5hlBrsKeMLpS2seItYaZ1MvV54WiOIlodjI/dd5jN3oBt",
+	"ygFHCPbNTbuKNDZgENPKNn5J73ZtzxZMuKfsTHOzeK9U+SPPrs9ns2DiVbe1Z7g5RVdJ5wp59pCahyz1",
+	"YGilaKE8iK7zYKPL7xesMBvEkW71kF6qgVWY5tXZdfdvCAtxFtAvnBu899VQyXdfiKp3ft3P/RrmKVus",
+	"hRdhSJCz3xcr4vFm3+XXHwXKSJNZ57zX9SJsZSL+4Wff+PFQutViftXGVK4Is28Nu0Ar6nMkb+0Ax0AI",
+	"VSDfjaLKZV0beEvqUktOGaq98Ig8ouBI7NsA01qJ/37y9GlCo6Do1RPdstJGUrbKhyo1o50c739RWyfc",
+	"Yx7TebuEZS26pg2v3U+O0yOmGagLtfFImE1NPZpjYHwwuuepdep6Tzzf0A0gOirGiEHeiz7Dkzxe8Q1L",
+	"eZYA4Z1X8fw1Tf6IdUtifL8htiiFV7Gkcfcruav7oVThwAVvdyjVS2EnFKnpM2nIq+Yr90p+I+YDRS03",
+	"mC4dDJIFdDcaxw3sIRlw3AyjskXmIIHCAG4XooBWFi56EB3mvvKMkVErGG/kveQ3kF96z9cm2+WYpgNr",
+	"ubJu8I5fjXFmKJ41HTZrOrAGRJuzdU9py0nKLsE6rSuzxSqUsjtAJ94B+85yPQdbw4MmvxDI4J1jL1Af",
+	"qyWIA/adKt2uyBxyhk0KfYwY2gtQZHqxscDauCuaasOtX9G7F4dLHuiVGjs8OjDaOfPjFtik2Y9Y5LY8",
+	"+8etcevocfv2ZbxoeSSDO1rqjL2zrDIVL4pVIDowNU+2is3BdkWiOoZoWrl7Wda90FopAP4dxg27haJg",
+	"3By1PBXBkh2hz9ruuI/CoWq2BvWEXXjeQ/20KpQgDvzTA7fgUqsbEV0ONkKsryvd9KvwTR+XlaE2jVNo",
+	"2oVgiNaVfBqD4sAqh5LSo4t/9OJ8VvZTOSEe3C/kAR6JB87lEKCG0mUuO0UwI3kyYVdcqKBDKFIhRazO",
+	"Vi2AmKMdl9pgQUuO8oVV7MDv2QHt6UpVjBcaeL5qMis63UK/tnzL8ehB7GxEzjhRyzHD2JG58sXZT3Gi",
+	"0xD9epXU5+ejo3pBVIJqmgvhVJLd8lVza68Y7ymQQx3GTn+7QvnHlDyDq+T0KgRDXCUpPcEfl6vD0oXo",
+	"3o8uTeoj6p5P9htC6UO6CLXD3wbMh/2oSne5cJnBcIevbdGVvRF6VSk6Za7qXl3BzDkI5VDo4ubivX7Q",
+	"qExctzB4mB8fvye1bMnLkGJ8DStSv7xHvTLkNMiUlHi0VCjjHJWInX2xEDKigJ13mp9NV1ERJG1p9cEC",
+	"2t08H5g+PL7XKN3XaVNza7eyf2EVLuz7WY7OKOC/GTWeyKgRaOrzBoImUtjgdRfSb1wohPfNif7Nif77",
+	"daI/iUe3qQy7FnLyu/XrDq7pm+tzyPX5LH7NDU5LnG0Tsx/tsgz8vragXFTSeBtVcO05PkR/cw21XcVJ",
+	"lXYB+lYYiGQmOFJ7imqK8TyIxn33csKoeVw+APYz+UgfCePYtIiHN1BwFhI8+mls94RhuVMUxivZv7cG",
+	"EuPW/wcpaTnk0qVSyWiCKV6rLEJtdROvXw1o9rYSueNzlS6S02RhbWlOj45ChvdkLuyimro83vDTkaMI",
+	"IWfKx/ZaTqVgyMcVklbrVmFrQ/sRM7Vshgz/WNcIf2pSzelMgmFqakDf8KkohF0xI+aSF7V/TlU6Iyri",
+	"7Kc6EbkpQfXOBr3RUDIcXhy5mM1Ag7R1Z7PvCjU3IcPTeEIzPoHUpO2x61lfbCtPaxWbVqLIGfdFXjC+",
+	"u0CnVGMEa9asARfM6yTf4AMiyYlnC+ybHXS0Sop/VcD+9vHjB/bKaxc0/QI4lqg66yTsU8azSevuysZi",
+	"y6K6T29AFVY1QaOrUQRtCTrAQhYwMLaVmi2j8zOzcIPUZVID9wwDIWsqRAbSQIukXpU8WwB7OTneiZiO",
+	"poWaHrndPHr/7uzNL5dvkJcJizncNZIv3lx+ZK8+vEvS5Aa0IbK7OeFFueAneITDgIfN8+PJycvJyWEO",
+	"N25MVYLkpUhOk+8nx5MTyuZd4NE7ohIJ7p9lFQueUbmzyJEtD/JeRQUD1vEE4xOQnYB8A3qqjLCrF0w5",
+	"GteVpMJc1BOfcKhKoBHe5dQrjfY98akWQMXgPvWB+d84Nvgelb7y4tzXuRfuDcrTDLk4CQEDGHRFfOy3",
+	"xBdepo68SyHpj+NIK/nPaULVc7w0+vL4OPAU8Hb6htUfOW7pfmtm2ti+xt0DxA17Npmf6AKgTHensYW0",
+	"/yjiuXUYDnYf4tyfkvAyJJ/dYH6Tj1S7ElEBsUrjF0AHK15zqMnI7RyJ7na+xqFD1aOvAocXsFTelh8t",
+	"trS+rgFkpskcbAxrVKd6eAIDWBv8w68fWW83Yih8C/YZ8BemGMBgmvzp+E/72yytlY5NJdXgLvR28a13",
+	"pT52C6OM7lWeh6IbdX2nprxMuzO8igEx68xNxcnZx0VzIYKcCwmU9ejuWNsoypFzVhaV6dTWYhdUK9+B",
+	"gmX7boSqTG/x0Fx5H5tvmWisfejjsm2w7kqhwcSI8LJLhHiF/qjy1XPQX1cOsIrxfFMptKQte/o0sS/A",
+	"dlpV0gTkzFRZBsbMqqJY0XE6fvrjJOQNL0TelJFr73aXyIRhlSQZK+8dtUuwjPde3/GgOckk6BWeeg6n",
+	"Kl8d+rvZ/5aEC8ooioPbzmArjZJwRygMDJazqVa3BtvWC/fWjeA113Wvu8I0fFpgkhxIaquYC5O51bHb",
+	"UDgApVZhWKEU5tZxO8CmzzzgT0hvYYovzqa7COc3XBQOkxEuHd2iHp3gmgbZcd0lvL3FinFJ24aBMxp4",
+	"zgRt8c9nH5hVqmBzsP+st9pxQffE0wLGYHTrJYaT8V1MH3gR6oyhDE6DDAuwNR3sn1NuIIGefQ2Jt8y5",
+	"l9URe7kwpbtDvg4uedaFqob2i3LLltrRpeaf+TUMET6zDUFGifshLPAIbsD3aItywl9Ln+KrgVkt5nPQ",
+	"5PslPIYq697zVx8Ls1C3/xTy2Y+G3+03tKit9GbhzhIGDo3VQBUxHn1GLi/fMBrOVxej4npumoNgZcGa",
+	"YXXXFI6xM4cg3fblLNCsN5Nf9SVTNwEOF6ZBI22Mzv2+bCSbIZ74oTILupUjAwfpqA8K7bR/x0mCUIgb",
+	"pJkgTnXvS29RgRzZ6ts3zc3pKTN+gWrKom7OilU1gHUwLsZvxPjnQt2+k1+MhZ4NItSA7OPpK+GixAYc",
+	"gPbr4ZzPL4rU1Lomv8qc8YEjEnjgo1l2rjKr9CCrPsOW41R1M8jFXo08dYoX925R/KVd6ZTsqs2z1Bdi",
+	"yRbAS2rYTiXRyBRKrc3d2yXopUCu3aolyyRA3kgHFOSRtlpmvlfX4tLy7BpHcy3dFP1pQXI88b5RvQ/p",
+	"5G5MZV2ndxoETabtjvBRsxAha4uJ77Xgc6lMXQbXQ23qNpspBtH6Pyi6zDIDyEkxkD+H4OCJWQTpw45B",
+	"cKSrGL0yK7TNOpdP8qTmwYAG84WlfkIX4hiD0HrHrLtbLWs8UlJDjSE70GwyUtJcR7/R/++PvMNtq07Y",
+	"pEI1kTsNudceTk8zMdJ0pWyot+yZn3MLlbYrMHkUWUVgtKrHdWqyXv9ggpHaGd8jFNm9VdoU2o93fErK",
+	"CxjYQHd/7NtlC8kHI2SH5uoUKE9jLSr3lSNbFL4LRddeIP8p69wZpn2v1B63MXRuntJgEab4tyWhuufJ",
+	"SBJqhzi3mklHKAi9suaojtMoVawJ7mUTR08N33utc3iToaR0JFHHt9GgopG+S05DmwhDuwi3u5B9XW/g",
+	"KFGQjtOZloIIccRGi1ozqLmhKWlujQPHdqJ55Qg/PUcMGH8371+J8Pl8EQ2TYjhCkBfFvc1bYSPPqzYg",
+	"Mr6dv4Hzd0bidTeYnqi6tYF1FpPfyFb9uEaCoe931Rv8Ke7kWz7tSW4q+rYraWCqZB2G1zvgatY62BjA",
+	"QYMozQowhkE+h96J70ZzDp3vJmf0qzzjDXijzrnDab2R3075V3jKO9ROtM21+yB+Q4kbkD6OdO9Hveqf",
+	"9VJDxm0j8//bnP7q2/H/dvz/+Md/AUW5Vd1zhluWq6xagrRNnJU7fxHVoG25axk80tCzYyUtv6OT760P",
+	"0cP4NwfZE1Injj8mNstpQw5NjE8xNLOx7g0pQe7t2kz0YOzWQZ44zh6x+joYdB5gQpoHZPxezUebtv3r",
+	"MeC06a3lntpovHHa+hfWvIPBwJ0R/0EgoeYDX7+USzYFpoFni5CU1eV3QwaioH7/O+vP1NP229W65WoN",
+	"9IgbRQ3wQ7aAz3nvVHHYw33aqhISZfpvqI9Sw91rAyrW+Vgd+DQMd6I6Nrj1o0hLn7DXIRm2BI09y1wA",
+	"Zvte5pR/MGH/cGLywVyrqvxxdZCGaXwxNjWrR8ZXKGqD4g584QBf42fCLn2oaTjJOETo+TXl2TXI3P/o",
+	"eMGS22zRqS3U5MmFOandbrQlLkfXTSUt5DGmEBoZb7nQ/t5uGTYQjx/+HL6rRlTluE9/e2Stm1aRmxEu",
+	"wwZfSbpDsIH/ZM1tmG5rL+kbJz+8weRfO8W4m76QmJW61hiy9htEOkPq+uqJd4dMqQPihgaDV4kH3gTo",
+	"1cx1v+vV1ImgvSS0jMZ5g8Y4icR7BYbsXIStX8cc80zS6x/MP721G/Q/qaoM5vL55unjDrrDuz/moQEi",
+	"4aPXgiTghf0SvOm05xr6bUInV0kfYI95+t+Zyh2kgwj2jCp5SlFRSRiRvhg4zH26+b23DmIsbOP0/+j1",
+	"nLaupYYb6tavuOywV2LGrgIirhImTNjUybd7vnWZ+mATvSaJuii5dkmCmOCsmw4yA4oaPqewkoGueb6t",
+	"agtJmNssJDH0tYSMIeHWz/WUim+YYozu60BqN9CJiEdtFB795vBzP6x+vMI+s2Wnh1Po30TXRiV9oyO3",
+	"805koFzwthWrtgm+JUtgSemVHs2kD7toeS1yuoB6b9B0sR24qCRhZxfluNUAbl37xf/tovumX4eVsenA",
+	"FSGTfqemb3bF52WKiH2l2Rb2eNFqjtbmjWtexX0oPrppehU/+m/urOaZbUrNd9tekWo9qztkmSpbMG7q",
+	"Zksm1m0J+UIItXTs463mMy65a8FkJuys1XBKo3Qj8iCetMxaxDFKrg1o0wQsQl63qWoCWhprR9YMHq1a",
+	"juweO4Ok7XdR5seCM1I1ZVQ1hApHE3Yui5WXv7Sx7H/+6SfxI/Iahxb3ZmUg93YYS1UKXx63p3DvBCk9",
+	"yuX8Vj0V56DRI5T7sdv67Hm5RkMMXyHr6JxbV/FPmc3nIyLL7HZclbJZ3bYgfmJ93Zv+vdsvoNTogQuQ",
+	"oXcM3OAt7JUX8mYg74mU/R/VwYxyVTOK4lxOhYS2Ls1CUSaf6I/IT9e6HqXs/Pznn0RRuAOUaWFFxgvi",
+	"K+ZFipV/2BRmIb+8t75WxQrXOEmbhSjJnuRLtUa+CeFwrQbhm6pRNezF/YTteIzFZfca6CFvSBtk1V2I",
+	"grZ8OqL0VQDOd9dqQlKx4UKMczTNLp6IefQazGywxCrdJqEGNc/LVVoY+WaNHRBDuLzumL1a1ULXeAs3",
+	"URZhOjxiH/KKaTUe2JrRG15mBtsKhDoJ4Wev9jR9BQaUu7rZwRMSZLerwmgdz6z3SxhOoe7qfj0sdIt3",
+	"xCpv+LO9g4rVam++BxXri5VNeb70o/Z2hktCkMu4nyGBW8J495Ox+z+UHO3yNPmw6s4qmQMWhHPmWKax",
+	"dARG6spVD2qf64nHivW6enAN7BpK23o3dPjAkvbu+YMKS/Cbr4NI93/Bbr1XLRHP11Kx4vkvUBMw1EnX",
+	"I4Le7XTsdB/ZVpHtjeL4XspQoke+FUlUi50duV20bXE+NAn11wXK8lTRulvc24ukwprgrvGVeNNGRK/F",
+	"zlF1WYWdsPMmliCks9SV0nDajgYdOdF1CfOnOVW9GqMbT1cWnNNhx3Hhz3veanR8k1m3RBC4TTr0JB58",
+	"CgOybLxh0KP4goMJ9E24e6h03xEvxVFdX+/+cz3HQGk8X9ipWysnVg9v0nEp48sQcQeTH50y6guqtoMV",
+	"Clpelo43fX2Et76R2Lol0nRgCBhbH+FHLfJ508CWs7e/vqsNgd+5kg8vyEAm2at3viLLdz+ffXjRWSLl",
+	"23++/38DAA==",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
@@ -0,0 +1,243 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package audit writes a structured record of who searched for what, and what they got back.
+//
+// Each [Record] is written as a single line of JSON to an [io.Writer], normally a rotating [File].
+// Any other writer can be used as a sink with [NewLogger].
+// Fields can be removed or hashed before the record is written, see [config.Audit.Redact].
+package audit
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/config"
+)
+
+var log = logging.Log()
+
+// Interfaces that receive requests, see [Record.Interface].
+const (
+	REST = "rest"
+	MCP  = "mcp"
+)
+
+// Record of a single request.
+type Record struct {
+	Time       time.Time       `json:"time"`
+	User       string          `json:"user,omitempty"`    // Authenticated user name, if known.
+	Session    string          `json:"session,omitempty"` // Session ID: user name or hashed token.
+	Remote     string          `json:"remote,omitempty"`  // Client address.
+	Interface  string          `json:"interface"`         // [REST] or [MCP].
+	Tool       string          `json:"tool,omitempty"`    // MCP tool name.
+	Operation  string          `json:"operation"`         // HTTP method and path, e.g. "POST /graphs/neighbors".
+	Recipe     string          `json:"recipe,omitempty"`  // Recipe name.
+	Start      *Start          `json:"start,omitempty"`   // Start of a search, or query for /objects.
+	Goals      []string        `json:"goals,omitempty"`   // Goal classes.
+	Depth      *int            `json:"depth,omitempty"`   // Neighbors depth.
+	Text       string          `json:"text,omitempty"`    // Free text to resolve.
+	Domains    []string        `json:"domains,omitempty"` // Domains to diagnose, all domains if empty.
+	Constraint *api.Constraint `json:"constraint,omitempty"`
+	Results    []Count         `json:"results,omitempty"`    // Classes and counts of objects returned.
+	Candidates []string        `json:"candidates,omitempty"` // Candidate start queries returned by resolve.
+	Diagnoses  map[string]int  `json:"diagnoses,omitempty"`  // Number of stores diagnosed at each level.
+	Redacted   map[string]int  `json:"redacted,omitempty"`   // Number of values redacted by each rule.
+	Status     int             `json:"status"`               // HTTP status.
+	Error      string          `json:"error,omitempty"`
+	DurationMS int64           `json:"durationMs"`
+}
+
+// Start of a search.
+type Start struct {
+	Class   string   `json:"class,omitempty"`
+	Queries []string `json:"queries,omitempty"`
+	// Objects is the number of serialized start objects, their content is not recorded.
+	Objects int `json:"objects,omitempty"`
+}
+
+// Count of objects of a class.
+type Count struct {
+	Class string `json:"class"`
+	Count int    `json:"count"`
+}
+
+// Logger writes redacted records to a sink.
+type Logger struct {
+	m      sync.Mutex
+	sink   io.Writer
+	redact map[string]string
+}
+
+// New returns a logger that writes to the file in cfg.
+func New(cfg config.Audit) (*Logger, error) {
+	var w io.Writer = os.Stdout
+	if cfg.File != "-" {
+		f, err := OpenFile(cfg.File, int64(cmp.Or(cfg.MaxSize, 100))<<20, cmp.Or(cfg.MaxFiles, 5))
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	}
+	return NewLogger(w, cfg.Redact)
+}
+
+// NewLogger returns a logger that writes each record as one JSON line to sink.
+// redact maps field names to [config.RedactRemove] or [config.RedactHash].
+func NewLogger(sink io.Writer, redact map[string]string) (*Logger, error) {
+	for field, mode := range redact {
+		if !slices.Contains(fields, field) {
+			return nil, fmt.Errorf("audit redact: unknown field %q, expecting one of %v", field, fields)
+		}
+		if mode != config.RedactRemove && mode != config.RedactHash {
+			return nil, fmt.Errorf("audit redact %v: invalid mode %q", field, mode)
+		}
+	}
+	return &Logger{sink: sink, redact: redact}, nil
+}
+
+// fields are the JSON field names of a Record.
+var fields = func() (names []string) {
+	t := reflect.TypeFor[Record]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	return names
+}()
+
+// Log redacts and writes a record. Errors are logged, they do not fail the request.
+func (l *Logger) Log(r *Record) {
+	if err := l.log(r); err != nil {
+		log.Error(err, "Audit record not written", "operation", r.Operation)
+	}
+}
+
+func (l *Logger) log(r *Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if len(l.redact) > 0 {
+		var m map[string]any
+		if err := json.Unmarshal(b, &m); err != nil {
+			return err
+		}
+		for field, mode := range l.redact {
+			v, ok := m[field]
+			if !ok {
+				continue
+			}
+			switch mode {
+			case config.RedactRemove:
+				delete(m, field)
+			case config.RedactHash:
+				m[field] = hash(v)
+			}
+		}
+		if b, err = json.Marshal(m); err != nil {
+			return err
+		}
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	_, err = l.sink.Write(append(b, '\n'))
+	return err
+}
+
+// hash returns a stable, non-reversible string for a JSON value.
+func hash(v any) string {
+	b, _ := json.Marshal(v)
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// Close the sink if it is an [io.Closer].
+func (l *Logger) Close() error {
+	if c, ok := l.sink.(io.Closer); ok && l.sink != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
+
+// File is a writer that rotates the file when it reaches a maximum size.
+// Rotated files are named FILE.1 (newest) to FILE.N (oldest).
+type File struct {
+	m        sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+// OpenFile opens path for appending, rotating when it reaches maxSize bytes and keeping maxFiles rotated files.
+func OpenFile(path string, maxSize int64, maxFiles int) (*File, error) {
+	f := &File{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() (err error) {
+	if f.f, err = os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600); err != nil {
+		return err
+	}
+	info, err := f.f.Stat()
+	if err != nil {
+		return err
+	}
+	f.size = info.Size()
+	return nil
+}
+
+// Write b to the file, rotating first if b would take it over the maximum size.
+func (f *File) Write(b []byte) (int, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.size > 0 && f.size+int64(len(b)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.f.Write(b)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *File) rotate() error {
+	if err := f.f.Close(); err != nil {
+		return err
+	}
+	_ = os.Remove(fmt.Sprintf("%v.%v", f.path, f.maxFiles))
+	for i := f.maxFiles - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%v.%v", f.path, i), fmt.Sprintf("%v.%v", f.path, i+1))
+	}
+	if f.maxFiles > 0 {
+		if err := os.Rename(f.path, f.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+// Close the file.
+func (f *File) Close() error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return f.f.Close()
+}
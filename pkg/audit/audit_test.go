@@ -0,0 +1,82 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_Log(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := NewLogger(&buf, nil)
+	require.NoError(t, err)
+	l.Log(&Record{
+		Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), User: "alice", Interface: REST, Operation: "GET /objects",
+		Start: &Start{Queries: []string{"log:application:{}"}}, Results: []Count{{Class: "log:application", Count: 3}}, Status: 200,
+	})
+	assert.JSONEq(t, `{
+		"time":"2026-01-02T03:04:05Z", "user":"alice", "interface":"rest", "operation":"GET /objects",
+		"start":{"queries":["log:application:{}"]}, "results":[{"class":"log:application","count":3}],
+		"status":200, "durationMs":0
+	}`, buf.String())
+	assert.True(t, strings.HasSuffix(buf.String(), "}\n"), "JSON line")
+}
+
+func TestLogger_Redact(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := NewLogger(&buf, map[string]string{"user": config.RedactHash, "start": config.RedactRemove, "remote": config.RedactHash})
+	require.NoError(t, err)
+	for range 2 {
+		l.Log(&Record{User: "alice", Start: &Start{Class: "k8s:Pod"}, Operation: "POST /graphs/goals"})
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, lines[0], lines[1], "hash is stable")
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.NotContains(t, m, "start")
+	assert.NotContains(t, m, "remote", "empty fields stay empty")
+	assert.Regexp(t, `^sha256:[0-9a-f]{16}$`, m["user"])
+	assert.Equal(t, "POST /graphs/goals", m["operation"])
+
+	_, err = NewLogger(&buf, map[string]string{"nonesuch": config.RedactRemove})
+	assert.ErrorContains(t, err, `unknown field "nonesuch"`)
+	_, err = NewLogger(&buf, map[string]string{"user": "scramble"})
+	assert.ErrorContains(t, err, `invalid mode "scramble"`)
+}
+
+func TestFile_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := OpenFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, s := range []string{"1111\n", "2222\n", "3333\n", "4444\n", "5555\n", "6666\n", "7777\n"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	for name, want := range map[string]string{"": "7777\n", ".1": "5555\n6666\n", ".2": "3333\n4444\n"} {
+		got, err := os.ReadFile(path + name)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got), name)
+	}
+	assert.NoFileExists(t, path+".3")
+
+	// Re-opening appends to the existing file.
+	f, err = OpenFile(path, 10, 2)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("8888\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	got, _ := os.ReadFile(path)
+	assert.Equal(t, "7777\n8888\n", string(got))
+}
@@ -0,0 +1,171 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package authz drops objects the caller of a request is not allowed to see.
+//
+// Objects of classes that implement [korrel8r.Namespacer] are checked with a SelfSubjectAccessReview,
+// sent with the caller's bearer token (see [auth.ContextToken]).
+// Review results are cached per token hash and namespace.
+// Requests with no bearer token are denied, see [ErrNoToken].
+package authz
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/cache"
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var log = logging.Log()
+
+// Filter checks objects against the permissions of the caller.
+type Filter struct {
+	domains []string
+	attrs   authv1.ResourceAttributes
+	client  func() (client.Client, error)
+}
+
+// New returns a filter for cfg that sends reviews with c.
+// If c is nil, a client is created on first use from the default kube config.
+func New(cfg config.Authorization, c client.Client) *Filter {
+	f := &Filter{
+		domains: cfg.Domains,
+		attrs: authv1.ResourceAttributes{
+			Verb:     cmp.Or(cfg.Verb, "get"),
+			Group:    cfg.Group,
+			Resource: cmp.Or(cfg.Resource, "pods"),
+		},
+		client: func() (client.Client, error) { return c, nil },
+	}
+	if c == nil {
+		f.client = defaultClient
+	}
+	return f
+}
+
+var defaultClient = sync.OnceValues(func() (client.Client, error) { return k8s.NewClient(nil) })
+
+// Filter returns the objects the caller of ctx is allowed to see.
+// If any objects are dropped the error is a [*DroppedError].
+func (f *Filter) Filter(ctx context.Context, class korrel8r.Class, objects []korrel8r.Object) ([]korrel8r.Object, error) {
+	ns, ok := class.(korrel8r.Namespacer)
+	if !ok || len(objects) == 0 || (len(f.domains) > 0 && !slices.Contains(f.domains, class.Domain().Name())) {
+		return objects, nil
+	}
+	allowed := map[string]bool{}
+	var reviewErr error
+	var result []korrel8r.Object
+	for _, o := range objects {
+		namespace := ns.Namespace(o)
+		ok, seen := allowed[namespace]
+		if !seen {
+			var err error
+			if ok, err = f.allowed(ctx, namespace); err != nil { // Fail closed on review errors.
+				reviewErr = err
+			}
+			allowed[namespace] = ok
+		}
+		if ok {
+			result = append(result, o)
+		}
+	}
+	if dropped := len(objects) - len(result); dropped > 0 {
+		log.V(3).Info("Dropped objects", "class", class, "count", dropped, "error", reviewErr)
+		return result, &DroppedError{Class: class, Count: dropped, Err: reviewErr}
+	}
+	return result, nil
+}
+
+const cacheExpiry = time.Minute
+
+// ErrNoToken is the review error when the request has no bearer token.
+// Without a token the review would check korrel8r's own permissions, not the caller's.
+var ErrNoToken = errors.New("no bearer token for access review")
+
+type cacheKey struct {
+	token [sha256.Size]byte // Hash of the token, tokens are not kept in memory.
+	attrs authv1.ResourceAttributes
+}
+
+var reviewCache = cache.NewTTL[cacheKey, bool](cacheExpiry)
+
+// allowed reviews the caller's access in namespace, "" means all namespaces.
+func (f *Filter) allowed(ctx context.Context, namespace string) (bool, error) {
+	token := auth.ContextToken(ctx)
+	if token == "" {
+		return false, ErrNoToken
+	}
+	attrs := f.attrs
+	attrs.Namespace = namespace
+	key := cacheKey{token: sha256.Sum256([]byte(token)), attrs: attrs}
+	if allowed, ok := reviewCache.Get(key); ok {
+		return allowed, nil
+	}
+	c, err := f.client()
+	if err != nil {
+		return false, err
+	}
+	sar := &authv1.SelfSubjectAccessReview{Spec: authv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs}}
+	if err := c.Create(ctx, sar); err != nil {
+		return false, err
+	}
+	reviewCache.Put(key, sar.Status.Allowed)
+	return sar.Status.Allowed, nil
+}
+
+// DroppedError reports objects dropped because the caller is not allowed to see them.
+// It is not fatal: the objects the caller is allowed to see are still returned.
+type DroppedError struct {
+	Class korrel8r.Class
+	Count int
+	// Err is the error from the access review, nil if access was denied.
+	Err error
+}
+
+func (e *DroppedError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v: %v objects dropped, access review failed: %v", e.Class, e.Count, e.Err)
+	}
+	return fmt.Sprintf("%v: %v objects dropped, not authorized", e.Class, e.Count)
+}
+
+func (e *DroppedError) Unwrap() error { return e.Err }
+
+// Add adds the count of other to e. The classes must be the same.
+func (e *DroppedError) Add(other *DroppedError) {
+	e.Count += other.Count
+	e.Err = cmp.Or(e.Err, other.Err)
+}
+
+// Fatal returns err without any [DroppedError], or nil if nothing else is left.
+func Fatal(err error) error {
+	if _, ok := err.(*DroppedError); ok {
+		return nil
+	}
+	j, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err
+	}
+	var errs []error
+	for _, e := range j.Unwrap() {
+		if e = Fatal(e); e != nil {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
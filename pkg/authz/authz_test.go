@@ -0,0 +1,156 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/change"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// nsClass is a mock class where each object is the name of its namespace.
+type nsClass struct{ korrel8r.Class }
+
+func (nsClass) Namespace(o korrel8r.Object) string { return o.(string) }
+
+// fakeClient allows namespaces in allow, records reviews, and fails if err is set.
+type fakeClient struct {
+	client.Client
+	allow   []string
+	reviews []authv1.ResourceAttributes
+	err     error
+}
+
+func newFakeClient(allow ...string) *fakeClient {
+	f := &fakeClient{allow: allow}
+	f.Client = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			if f.err != nil {
+				return f.err
+			}
+			sar := obj.(*authv1.SelfSubjectAccessReview)
+			attrs := *sar.Spec.ResourceAttributes
+			f.reviews = append(f.reviews, attrs)
+			for _, ns := range f.allow {
+				sar.Status.Allowed = sar.Status.Allowed || ns == attrs.Namespace
+			}
+			return nil
+		},
+	}).Build()
+	return f
+}
+
+// tokenCtx is a context with a bearer token for reviews.
+var tokenCtx = auth.WithToken(context.Background(), "token")
+
+func TestFilter(t *testing.T) {
+	d := mock.NewDomain("d", "c")
+	c := nsClass{d.Class("c")}
+	objects := []korrel8r.Object{"a", "b", "a", ""}
+
+	t.Run("allowed", func(t *testing.T) {
+		reviewCache.Clear()
+		fc := newFakeClient("a")
+		got, err := New(config.Authorization{}, fc).Filter(tokenCtx, c, objects)
+		assert.Equal(t, []korrel8r.Object{"a", "a"}, got)
+		var dropped *DroppedError
+		require.ErrorAs(t, err, &dropped)
+		assert.Equal(t, 2, dropped.Count)
+		assert.EqualError(t, err, "d:c: 2 objects dropped, not authorized")
+		assert.Equal(t, []authv1.ResourceAttributes{
+			{Verb: "get", Resource: "pods", Namespace: "a"},
+			{Verb: "get", Resource: "pods", Namespace: "b"},
+			{Verb: "get", Resource: "pods", Namespace: ""},
+		}, fc.reviews, "one review per namespace")
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		reviewCache.Clear()
+		fc := newFakeClient("a", "b")
+		f := New(config.Authorization{Verb: "list", Group: "g", Resource: "r"}, fc)
+		ctx := auth.WithToken(context.Background(), "alice")
+		for range 2 {
+			got, err := f.Filter(ctx, c, objects[:2])
+			require.NoError(t, err)
+			assert.Equal(t, objects[:2], got)
+		}
+		assert.Equal(t, []authv1.ResourceAttributes{
+			{Verb: "list", Group: "g", Resource: "r", Namespace: "a"},
+			{Verb: "list", Group: "g", Resource: "r", Namespace: "b"},
+		}, fc.reviews)
+		// Different token is reviewed again.
+		_, err := f.Filter(auth.WithToken(context.Background(), "bob"), c, objects[:1])
+		require.NoError(t, err)
+		assert.Len(t, fc.reviews, 3)
+	})
+
+	t.Run("review error", func(t *testing.T) {
+		reviewCache.Clear()
+		fc := newFakeClient("a")
+		fc.err = errors.New("boom")
+		got, err := New(config.Authorization{}, fc).Filter(tokenCtx, c, objects)
+		assert.Empty(t, got, "fail closed")
+		assert.ErrorIs(t, err, fc.err)
+		assert.EqualError(t, err, "d:c: 4 objects dropped, access review failed: boom")
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		reviewCache.Clear()
+		fc := newFakeClient("a", "b")
+		got, err := New(config.Authorization{}, fc).Filter(context.Background(), c, objects)
+		assert.Empty(t, got, "fail closed")
+		assert.ErrorIs(t, err, ErrNoToken)
+		assert.Empty(t, fc.reviews, "korrel8r's own permissions are not reviewed")
+	})
+
+	t.Run("not filtered", func(t *testing.T) {
+		reviewCache.Clear()
+		fc := newFakeClient()
+		got, err := New(config.Authorization{Domains: []string{"other"}}, fc).Filter(tokenCtx, c, objects)
+		require.NoError(t, err)
+		assert.Equal(t, objects, got, "domain not in list")
+		got, err = New(config.Authorization{}, fc).Filter(tokenCtx, d.Class("c"), objects)
+		require.NoError(t, err)
+		assert.Equal(t, objects, got, "class is not a Namespacer")
+		assert.Empty(t, fc.reviews)
+	})
+}
+
+func TestFilter_change(t *testing.T) {
+	reviewCache.Clear()
+	fc := newFakeClient("a")
+	c := change.Domain.Class("change")
+	objects := []korrel8r.Object{
+		&change.Object{Type: change.Config, Resource: change.Resource{APIVersion: "v1", Kind: "Secret", Namespace: "a", Name: "x"}},
+		&change.Object{Type: change.Config, Resource: change.Resource{APIVersion: "v1", Kind: "Secret", Namespace: "b", Name: "y"}},
+		&change.Object{Type: change.Rollout, Resource: change.Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "b", Name: "z"}, Revision: "2"},
+	}
+	got, err := New(config.Authorization{}, fc).Filter(tokenCtx, c, objects)
+	assert.Equal(t, objects[:1], got)
+	var dropped *DroppedError
+	require.ErrorAs(t, err, &dropped)
+	assert.Equal(t, 2, dropped.Count)
+}
+
+func TestFatal(t *testing.T) {
+	dropped := &DroppedError{Count: 1}
+	other := errors.New("other")
+	assert.NoError(t, Fatal(nil))
+	assert.NoError(t, Fatal(dropped))
+	assert.NoError(t, Fatal(errors.Join(nil, dropped)))
+	assert.Equal(t, other, Fatal(other))
+	assert.Equal(t, other, Fatal(errors.Join(other, dropped)))
+	assert.EqualError(t, Fatal(errors.Join(other, dropped, other)), "other\nother")
+}
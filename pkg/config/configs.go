@@ -13,6 +13,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/yaml"
 	"github.com/korrel8r/korrel8r/pkg/unique"
@@ -33,6 +35,105 @@ func Load(fileOrURL string) (Configs, error) {
 	return l.configs, nil
 }
 
+// WithOverlay returns the base configurations followed by overlay.
+//
+// An overlay adds rules, aliases, templates and stores to a loaded configuration.
+// It may not have tuning, include or domains sections.
+//
+// Overlay stores may not read local files, or use credentials or hosts chosen by the caller:
+// they may not have mock data, kubeconfig, context, certificate authority or remote keys, or templates.
+// Store URLs must have the host of a URL in a base store, or a host in [Tuning.OverlayHosts].
+// The auth key must be the same as in the base stores of the domain, or [StoreAuthToken].
+//
+// Aliases in the overlay are expanded in overlay rules only, the base configurations are unchanged.
+func (c Configs) WithOverlay(overlay *Config) (Configs, error) {
+	if overlay == nil {
+		return c, nil
+	}
+	o := *overlay
+	if o.Source == "" {
+		o.Source = "overlay"
+	}
+	if o.Tuning != nil {
+		return nil, fmt.Errorf("%v: tuning section not allowed", o.Source)
+	}
+	if len(o.Include) > 0 {
+		return nil, fmt.Errorf("%v: include section not allowed", o.Source)
+	}
+	if len(o.Domains) > 0 {
+		return nil, fmt.Errorf("%v: domains section not allowed", o.Source)
+	}
+	if err := c.checkOverlayStores(o); err != nil {
+		return nil, err
+	}
+	o.Rules = slices.Clone(o.Rules) // Don't modify overlay rules during expansion.
+	overlays := Configs{o}
+	if err := expand(overlays); err != nil {
+		return nil, err
+	}
+	return append(slices.Clip(c), overlays...), nil
+}
+
+// overlayKeys are store keys that are not allowed in an overlay.
+var overlayKeys = []string{StoreKeyMock, StoreKeyKubeconfig, StoreKeyContext, StoreKeyCA, StoreKeyRemote}
+
+// checkOverlayStores returns an error if an overlay store uses local files, other credentials or an unknown host.
+func (c Configs) checkOverlayStores(o Config) error {
+	hosts := unique.NewSet[string]()
+	auths := map[string]unique.Set[string]{} // Auth values of base stores by domain.
+	for _, cfg := range c {
+		if cfg.Tuning != nil {
+			for _, h := range cfg.Tuning.OverlayHosts {
+				hosts.Add(h)
+			}
+		}
+		for _, s := range cfg.Stores {
+			d := s[StoreKeyDomain]
+			if auths[d] == nil {
+				auths[d] = unique.NewSet[string]()
+			}
+			auths[d].Add(s[StoreKeyAuth])
+			for _, v := range s {
+				if u := storeURL(v); u != nil && u.Host != "" {
+					hosts.Add(u.Host)
+				}
+			}
+		}
+	}
+	for _, s := range o.Stores {
+		for _, k := range overlayKeys {
+			if _, ok := s[k]; ok {
+				return fmt.Errorf("%v: store key %q not allowed", o.Source, k)
+			}
+		}
+		base := auths[s[StoreKeyDomain]]
+		if base == nil {
+			base = unique.NewSet("") // Default auth for a domain with no base stores.
+		}
+		if a := s[StoreKeyAuth]; a != StoreAuthToken && !base.Has(a) {
+			return fmt.Errorf("%v: store key %q: %q is not the same as the base stores", o.Source, StoreKeyAuth, a)
+		}
+		for k, v := range s {
+			if strings.Contains(v, "{{") {
+				return fmt.Errorf("%v: store key %q: templates not allowed", o.Source, k)
+			}
+			if u := storeURL(v); u != nil && (u.Host == "" || !hosts.Has(u.Host) && !hosts.Has(u.Hostname())) {
+				return fmt.Errorf("%v: store key %q: URL not allowed: %q", o.Source, k, v)
+			}
+		}
+	}
+	return nil
+}
+
+// storeURL returns the URL in a store value, nil if the value is not a URL with a host or a file URL.
+func storeURL(v string) *url.URL {
+	u, err := url.Parse(strings.TrimSpace(v))
+	if err != nil || (u.Host == "" && u.Scheme != "file") {
+		return nil
+	}
+	return u
+}
+
 type loader struct {
 	loaded  unique.Set[string]
 	configs Configs
@@ -133,3 +133,81 @@ func TestConfigs_Expand_sameGroupDifferentDomain(t *testing.T) {
 		}}
 	assert.Equal(t, want, c)
 }
+
+func TestConfigs_WithOverlay(t *testing.T) {
+	base := Configs{{Source: "base", Rules: []Rule{{Name: "a"}}}}
+	overlay := &Config{
+		Aliases: []Class{{Name: "x", Domain: "foo", Classes: []string{"p", "q"}}},
+		Rules:   []Rule{{Name: "b", Start: ClassSpec{Domain: "foo", Classes: []string{"x"}}}},
+		Stores:  []Store{{"domain": "foo"}},
+	}
+	c, err := base.WithOverlay(overlay)
+	require.NoError(t, err)
+	assert.Equal(t, Configs{
+		{Source: "base", Rules: []Rule{{Name: "a"}}},
+		{Source: "overlay",
+			Rules:  []Rule{{Name: "b", Start: ClassSpec{Domain: "foo", Classes: []string{"p", "q"}}}},
+			Stores: []Store{{"domain": "foo"}}},
+	}, c)
+	assert.Len(t, base, 1, "base unchanged")
+	assert.Equal(t, []string{"x"}, overlay.Rules[0].Start.Classes, "overlay unchanged")
+
+	c, err = base.WithOverlay(nil)
+	require.NoError(t, err)
+	assert.Equal(t, base, c)
+
+	for _, bad := range []*Config{
+		{Tuning: &Tuning{}},
+		{Include: []string{"other.yaml"}},
+		{Domains: []Domain{{Name: "foo"}}},
+		{Rules: []Rule{{}}},
+	} {
+		_, err := base.WithOverlay(bad)
+		assert.Error(t, err, "%+v", bad)
+	}
+}
+
+func TestConfigs_WithOverlay_stores(t *testing.T) {
+	base := Configs{
+		{Source: "base", Tuning: &Tuning{OverlayHosts: []string{"allowed.example", "other.example:8080"}},
+			Stores: []Store{{"domain": "log", "loki": "https://loki.example:3100/x"}, {"domain": "k8s", StoreKeyAuth: StoreAuthImpersonate}}},
+	}
+	for _, s := range []Store{
+		{"domain": "log", "loki": "https://loki.example:3100/api"},
+		{"domain": "log", "loki": "https://allowed.example/api"},
+		{"domain": "log", "loki": "https://allowed.example:9000"},
+		{"domain": "log", "loki": "http://other.example:8080"},
+		{"domain": "log", "cluster": "east", "auth": StoreAuthToken},
+		{"domain": "k8s", "cluster": "east", "auth": StoreAuthImpersonate},
+		{"domain": "k8s", "cluster": "west", "auth": StoreAuthToken},
+		{"domain": "metric", "cluster": "east"},
+	} {
+		t.Run(s["loki"], func(t *testing.T) {
+			_, err := base.WithOverlay(&Config{Stores: []Store{s}})
+			assert.NoError(t, err)
+		})
+	}
+	for _, x := range []struct {
+		name  string
+		store Store
+	}{
+		{StoreKeyMock, Store{"domain": "log", StoreKeyMock: "/etc"}},
+		{StoreKeyKubeconfig, Store{"domain": "k8s", StoreKeyKubeconfig: "/etc/kubernetes/admin.conf"}},
+		{StoreKeyContext, Store{"domain": "k8s", StoreKeyContext: "admin"}},
+		{"auth impersonate", Store{"domain": "log", StoreKeyAuth: StoreAuthImpersonate}},
+		{"auth default", Store{"domain": "k8s"}},
+		{StoreKeyCA, Store{"domain": "log", StoreKeyCA: "/etc/passwd"}},
+		{StoreKeyRemote, Store{"domain": "log", StoreKeyRemote: "https://loki.example:3100"}},
+		{"unknown host", Store{"domain": "log", "loki": "https://evil.example/api"}},
+		{"other port", Store{"domain": "log", "loki": "https://loki.example:9999/api"}},
+		{"other port of allowed host:port", Store{"domain": "log", "loki": "http://other.example:9090"}},
+		{"no scheme", Store{"domain": "log", "loki": "//evil.example/api"}},
+		{"file", Store{"domain": "log", "loki": "file:///etc/passwd"}},
+		{"template", Store{"domain": "log", "loki": `https://{{"evil.example"}}`}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			_, err := base.WithOverlay(&Config{Stores: []Store{x.store}})
+			assert.Error(t, err)
+		})
+	}
+}
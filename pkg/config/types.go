@@ -26,6 +26,12 @@ type Config struct {
 	// StatusRules generate statuses from Objects to annotate graph nodes.
 	StatusRules []StatusRule `json:"statusRules,omitempty"`
 
+	// Recipes are named correlation searches with parameters.
+	Recipes []Recipe `json:"recipes,omitempty"`
+
+	// Domains defines additional domains in configuration, see [Domain].
+	Domains []Domain `json:"domains,omitempty"`
+
 	// Tuning section has limits and optimizations.
 	// NOTE: This section is only allowed in the top-level configuration.
 	// It is not allowed in included configuration files.
@@ -44,6 +50,80 @@ type Template struct {
 	Template string `json:"template"`
 }
 
+// Domain defined in configuration, rather than built in to korrel8r.
+// Exactly one of the domain type fields, HTTP or Plugin, must be set.
+//
+// A domain defined in configuration is a normal domain: it needs a store configuration,
+// and rules can link its classes to classes in other domains.
+type Domain struct {
+	// Name of the domain, must not be the name of another domain.
+	Name string `json:"name"`
+
+	// Description of the domain.
+	Description string `json:"description,omitempty"`
+
+	// HTTP domain gets objects as JSON from HTTP endpoints.
+	HTTP *HTTPDomain `json:"http,omitempty"`
+
+	// Plugin domain is implemented by a separate process.
+	Plugin *PluginDomain `json:"plugin,omitempty"`
+}
+
+// PluginDomain is a domain implemented by a plugin: a separate process that serves the korrel8r plugin protocol.
+// Exactly one of URL or Command must be set.
+type PluginDomain struct {
+	// URL of a running plugin.
+	URL string `json:"url,omitempty"`
+
+	// Command runs the plugin as a subprocess: the path to the executable followed by arguments.
+	// The subprocess is started once, and shared by all sessions.
+	Command []string `json:"command,omitempty"`
+}
+
+// HTTPDomain is a domain where each class is an HTTP endpoint returning JSON.
+//
+// A query is DOMAIN:CLASS:PARAMETERS, where PARAMETERS is a JSON object.
+// The parameters are used to expand the class URL template.
+type HTTPDomain struct {
+	// Classes in the domain.
+	Classes []HTTPClass `json:"classes"`
+}
+
+// HTTPClass is a class of objects returned by an HTTP endpoint.
+//
+// Fields that select values from JSON documents are JSONPath expressions, as used by kubectl.
+// For example "{.items[*]}" or ".items[*]", the surrounding braces are optional.
+// An expression that is not valid JSONPath is parsed as a subset of jq, for example ".items[] | .name":
+// pipes of paths with ".key", "[n]" and "[]" steps.
+type HTTPClass struct {
+	// Name of the class.
+	Name string `json:"name"`
+
+	// Description of the class.
+	Description string `json:"description,omitempty"`
+
+	// URL is a Go template that generates the URL to get objects for a query. The template data has fields:
+	// .Query: the query parameters, .Store: the store configuration, .Constraint: the constraint, may be nil.
+	URL string `json:"url"`
+
+	// Objects is a JSONPath selecting the objects from the response.
+	// If omitted, a response that is a list is a list of objects, anything else is a single object.
+	Objects string `json:"objects,omitempty"`
+
+	// ID is a JSONPath selecting a unique identifier from an object, used to remove duplicates.
+	// If omitted, objects are identical only if their JSON is identical.
+	ID string `json:"id,omitempty"`
+
+	// Preview is a JSONPath selecting a short, human-readable description of an object.
+	// If omitted, the ID is used.
+	Preview string `json:"preview,omitempty"`
+
+	// Time is a JSONPath selecting the time of an object, used to apply the time range of a constraint.
+	// The value must be a RFC3339 string or a number of seconds since the Unix epoch.
+	// If omitted, objects are not filtered by time.
+	Time string `json:"time,omitempty"`
+}
+
 // Configs is a list of configs from different sources.
 type Configs []Config
 
@@ -53,11 +133,25 @@ type Store map[string]string
 
 // Store keys that may be used by any stores.
 const (
-	StoreKeyDomain     = "domain"               // Required domain name
-	StoreKeyError      = "error"                // Error message if store failed to load.
-	StoreKeyErrorCount = "errorCount"           // Count of errors on a store.
-	StoreKeyMock       = "mockData"             // Store loads mock data from a file or directory.
-	StoreKeyCA         = "certificateAuthority" // Path to CA certificate.
+	StoreKeyDomain      = "domain"               // Required domain name
+	StoreKeyError       = "error"                // Error message if store failed to load.
+	StoreKeyErrorCount  = "errorCount"           // Count of errors on a store.
+	StoreKeyMock        = "mockData"             // Store loads mock data from a file or directory.
+	StoreKeyCA          = "certificateAuthority" // Path to CA certificate.
+	StoreKeyReloadError = "reloadError"          // Error from the last failed configuration reload.
+	StoreKeyCluster     = "cluster"              // Name of the cluster the store belongs to.
+	StoreKeyKubeconfig  = "kubeconfig"           // Path to a kubeconfig file for cluster credentials.
+	StoreKeyContext     = "context"              // Kubeconfig context for cluster credentials.
+	StoreKeyRemote      = "remote"               // URL of a remote korrel8r to forward requests for any domain.
+	StoreKeyAuth        = "auth"                 // How requests are authorized: StoreAuthToken or StoreAuthImpersonate.
+)
+
+// Values for StoreKeyAuth.
+// If not set, the caller's bearer token is forwarded if there is one,
+// otherwise the store uses its own credentials.
+const (
+	StoreAuthToken       = "token"       // Always use the caller's bearer token, never the store's own credentials.
+	StoreAuthImpersonate = "impersonate" // Use the store's own credentials to impersonate the caller.
 )
 
 // Rule configures a template rule.
@@ -86,6 +180,10 @@ type Rule struct {
 	// Each template is applied to an object from one of the `start` classes.
 	// If any template yields a blank string or an error, the rule does not apply.
 	Result ResultSpec `json:"result"`
+
+	// CrossCluster allows the rule to find goal objects in any cluster.
+	// By default a rule only searches the cluster of its start object.
+	CrossCluster bool `json:"crossCluster,omitempty"`
 }
 
 // ClassSpec specifies one or more classes.
@@ -129,6 +227,84 @@ type StatusRule struct {
 	Status string `json:"status"`
 }
 
+// Recipe is a named correlation search with parameters.
+//
+// Start queries are Go templates, like rule templates.
+// Recipe parameters are available as template fields, for example {{.namespace}}.
+// A recipe must have exactly one of Goals or Depth.
+type Recipe struct {
+	// Name is used to run the recipe.
+	Name string `json:"name"`
+
+	// Description of what the recipe searches for.
+	Description string `json:"description,omitempty"`
+
+	// Params are the parameters of the recipe.
+	Params []RecipeParam `json:"params,omitempty"`
+
+	// Start specifies the starting point for the search.
+	Start RecipeStart `json:"start"`
+
+	// Goals are class names in DOMAIN:CLASS format, for a goal search.
+	Goals []string `json:"goals,omitempty"`
+
+	// Depth is the maximum depth for a neighbors search.
+	Depth int `json:"depth,omitempty"`
+
+	// Constraint on the objects included in search results.
+	Constraint *RecipeConstraint `json:"constraint,omitempty"`
+
+	// Options control the form of the returned graph.
+	Options *RecipeOptions `json:"options,omitempty"`
+}
+
+// RecipeParam is a recipe parameter.
+type RecipeParam struct {
+	// Name of the parameter, used as a template field.
+	Name string `json:"name"`
+
+	// Description of the parameter.
+	Description string `json:"description,omitempty"`
+
+	// Default value for the parameter.
+	// A parameter with no default is required.
+	Default string `json:"default,omitempty"`
+}
+
+// RecipeStart is the starting point of a recipe search.
+type RecipeStart struct {
+	// Class of the start queries in DOMAIN:CLASS format.
+	// If omitted, it is the class of the first query.
+	Class string `json:"class,omitempty"`
+
+	// Queries are templates that generate start queries in DOMAIN:CLASS:SELECTOR format.
+	Queries []string `json:"queries"`
+}
+
+// RecipeConstraint constrains the objects included in recipe results.
+type RecipeConstraint struct {
+	// Since ignores objects with timestamps older than this duration before the search.
+	Since Duration `json:"since,omitempty"`
+
+	// Limit number of objects per query.
+	Limit int `json:"limit,omitempty"`
+
+	// QueryLimit limits the number of queries per class.
+	QueryLimit int `json:"queryLimit,omitempty"`
+}
+
+// RecipeOptions control the form of the graph returned by a recipe.
+type RecipeOptions struct {
+	// Rules includes rule names in graph edges.
+	Rules bool `json:"rules,omitempty"`
+
+	// Results includes full results with each query.
+	Results bool `json:"results,omitempty"`
+
+	// Errors includes non-fatal error messages.
+	Errors bool `json:"errors,omitempty"`
+}
+
 // Tuning section for limits and optimizations.
 type Tuning struct {
 	// RequestTimeout cancels incoming or outgoing requests that last longer than this timeout.
@@ -143,6 +319,18 @@ type Tuning struct {
 	// Each session has a separate search engine, configuration and state.
 	SessionTimeout Duration `json:"sessionTimeout,omitempty"`
 
+	// SessionStore is a file to save sessions, so they are restored after a restart.
+	// Saved state includes the console state, configuration overlay and saved searches of each session.
+	// Saved sessions still expire after SessionTimeout.
+	// The file is an embedded database, created if it does not exist.
+	// If omitted, sessions are not saved.
+	SessionStore string `json:"sessionStore,omitempty"`
+
+	// OverlayHosts are hosts that stores in a session overlay may connect to, as "host" or "host:port".
+	// Overlay stores may always connect to the hosts of store URLs in the configuration.
+	// Hosts of store URLs that are templates are not known, they must be listed here.
+	OverlayHosts []string `json:"overlayHosts,omitempty"`
+
 	// UnsafeSharedSession skips authentication and uses a single shared session for all requests.
 	// WARNING: This disables per-user session isolation and should only be used for development or testing.
 	UnsafeSharedSession bool `json:"unsafeSharedSession,omitempty"`
@@ -151,6 +339,134 @@ type Tuning struct {
 	// This prevents a storm of expensive re-creation (DNS lookups, API discovery) on every failed query.
 	// Default is 10s if omitted or 0.
 	StoreRetryInterval Duration `json:"storeRetryInterval,omitempty"`
+
+	// ReloadInterval enables automatic configuration reload in server mode.
+	// The configuration file and included files are checked for changes at this interval.
+	// When a change is found, a new engine is built and replaces the engine in every session.
+	// If the new configuration is not valid, the previous engine keeps running.
+	// SessionTimeout and UnsafeSharedSession are not reloaded, they require a restart.
+	// If omitted or 0, configuration is never reloaded.
+	ReloadInterval Duration `json:"reloadInterval,omitempty"`
+
+	// ReloadURLs also checks included URLs for changes when ReloadInterval is set.
+	ReloadURLs bool `json:"reloadURLs,omitempty"`
+
+	// Authorization filters results by the permissions of the caller, see [Authorization].
+	// If omitted, results are not filtered.
+	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// Redaction replaces secrets and personal data in objects returned by the REST API and MCP tools, see [Redaction].
+	// If omitted, objects are returned unchanged.
+	Redaction *Redaction `json:"redaction,omitempty"`
+
+	// Audit writes a record of each REST request and MCP tool call in server mode, see [Audit].
+	// Audit is not reloaded, it requires a restart.
+	// If omitted, there is no audit log.
+	Audit *Audit `json:"audit,omitempty"`
+
+	// OIDC authenticates bearer tokens as signed JWTs from an OpenID Connect provider, see [OIDC].
+	// If omitted, bearer tokens are authenticated with a Kubernetes TokenReview.
+	// OIDC is not reloaded, it requires a restart.
+	OIDC *OIDC `json:"oidc,omitempty"`
+}
+
+// OIDC authentication of bearer tokens that are JSON Web Tokens (JWT).
+//
+// The token signature is verified with the provider's JSON Web Key Set (JWKS).
+// The keys are read from JWKSFile, JWKSURL, or the jwks_uri of the issuer's discovery document, in that order.
+type OIDC struct {
+	// Issuer must match the "iss" claim of the token.
+	Issuer string `json:"issuer"`
+
+	// Audience must be one of the values of the "aud" claim of the token.
+	Audience string `json:"audience"`
+
+	// JWKSURL is the URL of the JWKS. Keys are fetched again when a token is signed by an unknown key.
+	JWKSURL string `json:"jwksURL,omitempty"`
+
+	// JWKSFile is a local file containing the JWKS.
+	JWKSFile string `json:"jwksFile,omitempty"`
+
+	// UserClaim is the claim that identifies the user and session, default "sub".
+	UserClaim string `json:"userClaim,omitempty"`
+
+	// GroupsClaim is the claim listing the user's groups for impersonation, default "groups".
+	// Groups are only used with UsernameClaim.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// UsernameClaim is the claim with the Kubernetes username, used by stores with "auth: impersonate".
+	// If empty, OIDC users have no Kubernetes username and can't be impersonated.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// UsernamePrefix is required with UsernameClaim, and is prepended to the username and to each group,
+	// so they can't collide with existing cluster users and groups. For example "oidc:".
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+}
+
+// Audit log configuration.
+// Records are written as JSON lines to a file that is rotated when it reaches MaxSize.
+type Audit struct {
+	// File to write audit records, "-" means standard output.
+	File string `json:"file"`
+
+	// MaxSize in megabytes of the file before it is rotated, default 100.
+	MaxSize int `json:"maxSize,omitempty"`
+
+	// MaxFiles is the number of rotated files to keep, default 5.
+	MaxFiles int `json:"maxFiles,omitempty"`
+
+	// Redact maps record field names to a redaction: "remove" omits the field, "hash" replaces the value with a hash.
+	// Hashed values can still be matched between records, without revealing the value.
+	Redact map[string]string `json:"redact,omitempty"`
+}
+
+// Audit redaction modes, see [Audit.Redact].
+const (
+	RedactRemove = "remove"
+	RedactHash   = "hash"
+)
+
+// Redaction of secrets and personal data in returned objects.
+//
+// Built-in rules redact the data of Kubernetes Secrets, the values of environment variables
+// with secret names, bearer tokens and email addresses in any string value.
+// Patterns add more rules for string values.
+// When enabled, redaction applies to every response and cannot be turned off by a client.
+type Redaction struct {
+	// EnvNames is a regular expression for names of environment variables with secret values.
+	// If omitted, names containing PASSWORD, PASSWD, SECRET, TOKEN, KEY or CREDENTIAL are redacted, ignoring case.
+	EnvNames string `json:"envNames,omitempty"`
+
+	// Patterns are additional regular expressions, text matching a pattern is redacted in all string values.
+	Patterns []RedactionPattern `json:"patterns,omitempty"`
+}
+
+// RedactionPattern is a named regular expression for text to redact.
+type RedactionPattern struct {
+	// Name identifies the pattern in counts of redacted values.
+	Name string `json:"name"`
+
+	// Regex is the regular expression to match, in Go RE2 syntax.
+	Regex string `json:"regex"`
+}
+
+// Authorization drops objects the caller is not allowed to see from results.
+//
+// Objects of classes that know their namespace are checked: the caller must have
+// permission for Verb on Resource in the object's namespace.
+// Objects with no namespace require the permission in all namespaces.
+type Authorization struct {
+	// Domains to filter. If empty, all domains are filtered.
+	Domains []string `json:"domains,omitempty"`
+
+	// Verb to check, default "get".
+	Verb string `json:"verb,omitempty"`
+
+	// Group of the resource to check, default is the core group.
+	Group string `json:"group,omitempty"`
+
+	// Resource to check, default "pods": the caller must be allowed to see pods in the namespace.
+	Resource string `json:"resource,omitempty"`
 }
 
 // GetStoreRetryInterval applies the default value
@@ -0,0 +1,91 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"maps"
+	"net/url"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+)
+
+var log = logging.Log()
+
+// Watcher polls the sources of a configuration for changes.
+//
+// The sources are the top-level configuration file and all included files.
+// Included URLs are only polled if enabled, fetching a URL may be expensive.
+type Watcher struct {
+	source   string
+	interval time.Duration
+	urls     bool
+	digests  map[string][sha256.Size]byte
+}
+
+// NewWatcher returns a watcher for source, which was loaded as configs.
+// If urls is true, included URLs are polled as well as files.
+func NewWatcher(source string, configs Configs, interval time.Duration, urls bool) *Watcher {
+	w := &Watcher{source: source, interval: interval, urls: urls}
+	w.digests = w.digest(configs)
+	return w
+}
+
+// Changed returns true if any source has changed since the last call to Changed or [NewWatcher].
+func (w *Watcher) Changed() bool {
+	sources := make(Configs, 0, len(w.digests))
+	for source := range w.digests {
+		sources = append(sources, Config{Source: source})
+	}
+	digests := w.digest(sources)
+	changed := !maps.Equal(digests, w.digests)
+	w.digests = digests
+	return changed
+}
+
+// Watch polls for changes until ctx is canceled.
+//
+// When a source changes, the configuration is re-loaded and passed to reload.
+// If loading fails, reload is called with the error.
+// The set of watched sources is updated after each load, to follow changes in Include.
+func (w *Watcher) Watch(ctx context.Context, reload func(Configs, error)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !w.Changed() {
+				continue
+			}
+			log.V(1).Info("Configuration changed, reloading", "source", w.source)
+			configs, err := Load(w.source)
+			if err == nil {
+				w.digests = w.digest(configs)
+			}
+			reload(configs, err)
+		}
+	}
+}
+
+// digest computes a content digest for each watched source in configs.
+// A source that cannot be read has a zero digest, so it changes when it becomes readable.
+func (w *Watcher) digest(configs Configs) map[string][sha256.Size]byte {
+	digests := map[string][sha256.Size]byte{w.source: {}}
+	for _, c := range configs {
+		digests[c.Source] = [sha256.Size]byte{}
+	}
+	for source := range digests {
+		if u, err := url.Parse(source); err == nil && u.IsAbs() && !w.urls {
+			delete(digests, source)
+			continue
+		}
+		if b, err := readFileOrURL(source); err == nil {
+			digests[source] = sha256.Sum256(b)
+		}
+	}
+	return digests
+}
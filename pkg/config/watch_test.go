@@ -0,0 +1,50 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Changed(t *testing.T) {
+	dir := t.TempDir()
+	top := filepath.Join(dir, "korrel8r.yaml")
+	included := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(top, []byte("include: [rules.yaml]\n"), 0o644))
+	require.NoError(t, os.WriteFile(included, []byte("rules: []\n"), 0o644))
+	configs, err := Load(top)
+	require.NoError(t, err)
+
+	w := NewWatcher(top, configs, time.Hour, false)
+	assert.False(t, w.Changed())
+	require.NoError(t, os.WriteFile(included, []byte("aliases: []\n"), 0o644))
+	assert.True(t, w.Changed())
+	assert.False(t, w.Changed())
+	require.NoError(t, os.Remove(included))
+	assert.True(t, w.Changed())
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	dir := t.TempDir()
+	top := filepath.Join(dir, "korrel8r.yaml")
+	require.NoError(t, os.WriteFile(top, []byte("rules: []\n"), 0o644))
+	configs, err := Load(top)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan error)
+	go NewWatcher(top, configs, time.Millisecond, false).Watch(ctx, func(_ Configs, err error) { reloaded <- err })
+
+	require.NoError(t, os.WriteFile(top, []byte("include: [missing.yaml]\n"), 0o644))
+	assert.ErrorContains(t, <-reloaded, "missing.yaml")
+	require.NoError(t, os.WriteFile(top, []byte("aliases: []\n"), 0o644))
+	assert.NoError(t, <-reloaded)
+}
@@ -3,6 +3,7 @@
 package alert
 
 import (
+	"cmp"
 	"context"
 	_ "embed"
 	"fmt"
@@ -116,6 +117,67 @@ func (c Class) Preview(o korrel8r.Object) string {
 	return ""
 }
 
+func (c Class) Namespace(o korrel8r.Object) string {
+	if o, ok := o.(*Object); ok {
+		return o.Labels["namespace"]
+	}
+	return ""
+}
+
+// CompactFields keeps the labels, summary, status and times of an alert.
+func (c Class) CompactFields() []string {
+	return []string{"labels", "annotations.summary", "annotations.description", "status", "startsAt", "endsAt"}
+}
+
+// summaryLabels are labels that are summarized separately from the other labels.
+var summaryLabels = []string{"alertname", "severity", "namespace"}
+
+// Summary has the alert name, severity, namespace, status, summary annotation and times,
+// and the other labels that identify the alert.
+func (c Class) Summary(o korrel8r.Object) map[string]any {
+	a, ok := o.(*Object)
+	if !ok || a == nil {
+		return nil
+	}
+	s := map[string]any{"name": a.Labels["alertname"], "status": a.Status}
+	for _, k := range summaryLabels[1:] {
+		if v := a.Labels[k]; v != "" {
+			s[k] = v
+		}
+	}
+	labels := maps.Clone(a.Labels)
+	for _, k := range summaryLabels {
+		delete(labels, k)
+	}
+	if len(labels) > 0 {
+		s["labels"] = labels
+	}
+	if summary := cmp.Or(a.Annotations["summary"], a.Annotations["message"], a.Annotations["description"]); summary != "" {
+		s["summary"] = summary
+	}
+	if !a.StartsAt.IsZero() {
+		s["startsAt"] = a.StartsAt
+	}
+	if !a.EndsAt.IsZero() {
+		s["endsAt"] = a.EndsAt
+	}
+	if len(a.SilencedBy) > 0 {
+		s["silenced"] = true
+	}
+	if len(a.InhibitedBy) > 0 {
+		s["inhibited"] = true
+	}
+	return s
+}
+
+// Time returns the time the alert started.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, ok := o.(*Object); ok {
+		return o.StartsAt
+	}
+	return time.Time{}
+}
+
 // Object contains alert data, passed as *Object when used as a korrel8r.Object.
 type Object struct {
 	// Common fields.
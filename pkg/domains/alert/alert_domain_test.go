@@ -4,17 +4,37 @@ package alert_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/korrel8r/korrel8r/internal/pkg/must"
 	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
 	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/stretchr/testify/assert"
 )
 
 // TODO https://github.com/korrel8r/korrel8r/issues/148  store does not respect limits.
 // Remove ClusterSetup when fixed.
 var fixture = domain.Fixture{
-	Query:        &alert.Query{Qs: "{}"},
+	Query:        must.Must1(alert.Domain.Query("alert:alert:{}")),
 	ClusterSetup: func(testing.TB) bool { return false },
 }
 
 func TestAlertDomain(t *testing.T)      { fixture.Test(t) }
 func BenchmarkAlertDomain(b *testing.B) { fixture.Benchmark(b) }
+
+func TestClass_Summary(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := &alert.Object{
+		Labels:      map[string]string{"alertname": "KubePodCrashLooping", "severity": "warning", "namespace": "shop", "pod": "web-0"},
+		Annotations: map[string]string{"summary": "Pod is crash looping.", "runbook_url": "https://example.com"},
+		Status:      "firing",
+		StartsAt:    start,
+		SilencedBy:  []string{"s1"},
+		Fingerprint: "abc",
+	}
+	assert.Equal(t, map[string]any{
+		"name": "KubePodCrashLooping", "severity": "warning", "namespace": "shop", "status": "firing",
+		"labels": map[string]string{"pod": "web-0"}, "summary": "Pod is crash looping.", "startsAt": start, "silenced": true,
+	}, alert.Class{}.Summary(a))
+	assert.Nil(t, alert.Class{}.Summary("not an alert"))
+}
@@ -4,6 +4,7 @@ package domains
 
 import (
 	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/change"
 	"github.com/korrel8r/korrel8r/pkg/domains/incident"
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/domains/log"
@@ -21,4 +22,5 @@ var All = []korrel8r.Domain{
 	alert.Domain,
 	metric.Domain,
 	incident.Domain,
+	change.Domain,
 }
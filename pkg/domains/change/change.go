@@ -0,0 +1,487 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package change
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = impl.AssertDomainTypes(Domain, &Object{}, Class{}, &Query{}, &Store{})
+
+//go:embed doc.md
+var description string
+
+const name = "change"
+
+var Domain = &domain{impl.NewDomain(name, description, Class{})}
+
+type domain struct{ *impl.Domain }
+
+func (d *domain) Query(s string) (korrel8r.Query, error) {
+	_, query, err := impl.UnmarshalQueryString[Query](d, s)
+	return &query, err
+}
+
+// Store creates a store that reads the cluster using the same configuration as a k8s store, see [k8s.GetStoreConfig].
+func (*domain) Store(s any) (korrel8r.Store, error) {
+	var cs config.Store
+	if s != nil {
+		var err error
+		if cs, err = impl.TypeAssert[config.Store](s); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := k8s.GetStoreConfig(cs)
+	if err != nil {
+		return nil, err
+	}
+	c, err := k8s.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(c), nil
+}
+
+// Class represents any change. There is only a single class, named "change".
+type Class struct{}
+
+func (c Class) Domain() korrel8r.Domain                     { return Domain }
+func (c Class) Name() string                                { return name }
+func (c Class) String() string                              { return korrel8r.ClassString(c) }
+func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) { return impl.UnmarshalAs[*Object](b) }
+
+// ID identifies a change by type, resource, revision, manager and time.
+func (c Class) ID(o korrel8r.Object) any {
+	if o, ok := o.(*Object); ok {
+		return struct {
+			Type              Type
+			Resource          Resource
+			Revision, Manager string
+			Time              int64
+		}{o.Type, o.Resource, o.Revision, o.Manager, o.Time.UnixNano()}
+	}
+	return nil
+}
+
+// Preview describes the change, for example "Deployment web revision 3".
+func (c Class) Preview(x korrel8r.Object) string {
+	o, ok := x.(*Object)
+	if !ok {
+		return ""
+	}
+	switch o.Type {
+	case Rollout:
+		return fmt.Sprintf("%v %v revision %v", o.Resource.Kind, o.Resource.Name, o.Revision)
+	case Helm:
+		return strings.TrimSpace(fmt.Sprintf("Helm release %v revision %v %v", o.HelmRelease, o.Revision, o.Status))
+	default:
+		return fmt.Sprintf("%v %v updated by %v", o.Resource.Kind, o.Resource.Name, o.Manager)
+	}
+}
+
+// Namespace returns the namespace of the changed resource.
+func (c Class) Namespace(o korrel8r.Object) string {
+	if o, ok := o.(*Object); ok {
+		return o.Resource.Namespace
+	}
+	return ""
+}
+
+// Time returns the time of the change.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, ok := o.(*Object); ok {
+		return o.Time
+	}
+	return time.Time{}
+}
+
+// Type of change.
+type Type string
+
+const (
+	// Rollout is a new revision of a Deployment, StatefulSet or DaemonSet.
+	Rollout Type = "rollout"
+	// Config is an update to a ConfigMap or Secret.
+	Config Type = "config"
+	// Helm is a revision of a Helm release.
+	Helm Type = "helm"
+)
+
+// Object is a change to a cluster resource, passed as *Object when used as a korrel8r.Object.
+type Object struct {
+	// Time of the change.
+	Time time.Time `json:"time"`
+	// Type of change.
+	Type Type `json:"type"`
+	// Resource that changed: the workload for a rollout, the ConfigMap or Secret for a config change,
+	// the release Secret for a Helm release.
+	Resource Resource `json:"resource"`
+	// Revision of a workload rollout or Helm release.
+	Revision string `json:"revision,omitempty"`
+	// Manager that made a config change, from metadata.managedFields.
+	Manager string `json:"manager,omitempty"`
+	// Status of a Helm release revision, for example "deployed" or "failed".
+	Status string `json:"status,omitempty"`
+	// HelmRelease is the Helm release that manages the resource, if there is one.
+	HelmRelease string `json:"helmRelease,omitempty"`
+	// ArgoCDApplication is the ArgoCD application that manages the resource, if there is one.
+	ArgoCDApplication string `json:"argocdApplication,omitempty"`
+}
+
+// Resource identifies a k8s resource.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Query selects changes in a namespace, or changes to a single workload.
+type Query struct {
+	// Namespace of the changes, all namespaces if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Kind of workload: Deployment, StatefulSet or DaemonSet.
+	// Without Name, selects rollouts of all workloads of this kind.
+	Kind string `json:"kind,omitempty"`
+	// Name of the workload. Requires Namespace and Kind.
+	Name string `json:"name,omitempty"`
+}
+
+func (q *Query) Class() korrel8r.Class { return Class{} }
+func (q *Query) Data() string          { b, _ := json.Marshal(q); return string(b) }
+func (q *Query) String() string        { return korrel8r.QueryString(q) }
+
+// Store derives changes from the state of a cluster, using a k8s client.
+type Store struct {
+	*impl.Store
+	c client.Client
+}
+
+// NewStore returns a store that reads the cluster with c.
+func NewStore(c client.Client) *Store { return &Store{Store: impl.NewStore(Domain), c: c} }
+
+// Get changes matching the query, latest first.
+// Changes outside the constraint time interval are dropped, the constraint limit keeps the latest changes.
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) (err error) {
+	defer func() {
+		if apierrors.IsNotFound(err) { // "not found" is not an error.
+			err = nil
+		}
+	}()
+	// Type assertion errors are not store errors, treat as "not found".
+	q, ok := query.(*Query)
+	if !ok {
+		return nil
+	}
+	var changes []*Object
+	switch {
+	case q.Name != "":
+		changes, err = s.workloadChanges(ctx, q)
+	case q.Kind != "":
+		changes, err = s.kindChanges(ctx, q)
+	default:
+		changes, err = s.namespaceChanges(ctx, q.Namespace)
+	}
+	if err != nil {
+		return err
+	}
+	changes = slices.DeleteFunc(changes, func(o *Object) bool { return c.CompareTime(o.Time) != 0 })
+	slices.SortStableFunc(changes, func(a, b *Object) int {
+		return cmp.Or(b.Time.Compare(a.Time), cmp.Compare(a.Resource.Name, b.Resource.Name))
+	})
+	if limit := c.GetLimit(); limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	for _, o := range changes {
+		result.Append(o)
+	}
+	return nil
+}
+
+// namespaceChanges returns all changes in namespace ns, or in all namespaces if ns is empty.
+func (s *Store) namespaceChanges(ctx context.Context, ns string) ([]*Object, error) {
+	rollouts, err := s.rollouts(ctx, ns, nil)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := s.configs(ctx, ns, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	helm, err := s.helmReleases(ctx, ns, "")
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(rollouts, configs, helm), nil
+}
+
+// kindChanges returns the rollouts of all workloads of kind q.Kind in q.Namespace.
+func (s *Store) kindChanges(ctx context.Context, q *Query) ([]*Object, error) {
+	if !slices.Contains(workloadKinds, q.Kind) {
+		return nil, kindError(q)
+	}
+	return s.rollouts(ctx, q.Namespace, &metav1.OwnerReference{Kind: q.Kind})
+}
+
+// workloadChanges returns the rollouts of a workload, changes to the ConfigMaps and Secrets
+// used by its pod template, and revisions of the Helm release that manages it.
+func (s *Store) workloadChanges(ctx context.Context, q *Query) ([]*Object, error) {
+	if q.Namespace == "" {
+		return nil, fmt.Errorf("%v query with a name must have a namespace: %v", name, q)
+	}
+	var (
+		workload client.Object
+		template *corev1.PodTemplateSpec
+	)
+	switch q.Kind {
+	case "Deployment":
+		w := &appsv1.Deployment{}
+		workload, template = w, &w.Spec.Template
+	case "StatefulSet":
+		w := &appsv1.StatefulSet{}
+		workload, template = w, &w.Spec.Template
+	case "DaemonSet":
+		w := &appsv1.DaemonSet{}
+		workload, template = w, &w.Spec.Template
+	default:
+		return nil, kindError(q)
+	}
+	if err := s.c.Get(ctx, types.NamespacedName{Namespace: q.Namespace, Name: q.Name}, workload); err != nil {
+		return nil, err
+	}
+	owner := &metav1.OwnerReference{Kind: q.Kind, Name: q.Name}
+	rollouts, err := s.rollouts(ctx, q.Namespace, owner)
+	if err != nil {
+		return nil, err
+	}
+	configMaps, secrets := references(&template.Spec)
+	configs, err := s.configs(ctx, q.Namespace, configMaps, secrets)
+	if err != nil {
+		return nil, err
+	}
+	var helm []*Object
+	if release := workload.GetAnnotations()[helmReleaseAnnotation]; release != "" {
+		if helm, err = s.helmReleases(ctx, q.Namespace, release); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range rollouts { // Revisions don't always carry the workload annotations.
+		o.HelmRelease = cmp.Or(o.HelmRelease, helmRelease(workload))
+		o.ArgoCDApplication = cmp.Or(o.ArgoCDApplication, argoCDApplication(workload))
+	}
+	return slices.Concat(rollouts, configs, helm), nil
+}
+
+var workloadKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+func kindError(q *Query) error {
+	return fmt.Errorf("%v query kind must be %v: %v", name, strings.Join(workloadKinds, ", "), q)
+}
+
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	helmReleaseAnnotation = "meta.helm.sh/release-name"
+	argoCDAnnotation      = "argocd.argoproj.io/tracking-id"
+	argoCDLabel           = "argocd.argoproj.io/instance"
+	serviceAccountKey     = "kubernetes.io/service-account.name"
+)
+
+// rollouts returns workload revisions from ReplicaSets owned by Deployments,
+// and ControllerRevisions owned by StatefulSets and DaemonSets.
+// If owner is not nil, only revisions with an owner of the same kind are included,
+// and of the same name if owner.Name is not empty.
+func (s *Store) rollouts(ctx context.Context, ns string, owner *metav1.OwnerReference) ([]*Object, error) {
+	var changes []*Object
+	add := func(o metav1.Object, revision string, kinds ...string) {
+		for _, ref := range o.GetOwnerReferences() {
+			if !slices.Contains(kinds, ref.Kind) || (owner != nil && (ref.Kind != owner.Kind || (owner.Name != "" && ref.Name != owner.Name))) {
+				continue
+			}
+			changes = append(changes, &Object{
+				Time:              o.GetCreationTimestamp().UTC(),
+				Type:              Rollout,
+				Resource:          Resource{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: o.GetNamespace(), Name: ref.Name},
+				Revision:          revision,
+				HelmRelease:       helmRelease(o),
+				ArgoCDApplication: argoCDApplication(o),
+			})
+			return
+		}
+	}
+	if owner == nil || owner.Kind == "Deployment" {
+		replicaSets := &metav1.PartialObjectMetadataList{}
+		replicaSets.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSetList"))
+		if err := s.c.List(ctx, replicaSets, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		for i := range replicaSets.Items {
+			rs := &replicaSets.Items[i]
+			add(rs, rs.GetAnnotations()[revisionAnnotation], "Deployment")
+		}
+	}
+	if owner == nil || owner.Kind != "Deployment" {
+		revisions := &appsv1.ControllerRevisionList{}
+		if err := s.c.List(ctx, revisions, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		for i := range revisions.Items {
+			cr := &revisions.Items[i]
+			add(cr, strconv.FormatInt(cr.Revision, 10), "StatefulSet", "DaemonSet")
+		}
+	}
+	return changes, nil
+}
+
+// configs returns ConfigMap and Secret updates, one for each metadata.managedFields entry.
+// If configMaps or secrets is not nil, only resources with those names are included.
+// Helm release and service account token Secrets are not included.
+func (s *Store) configs(ctx context.Context, ns string, configMaps, secrets []string) ([]*Object, error) {
+	var changes []*Object
+	for _, x := range []struct {
+		kind  string
+		names []string
+	}{{"ConfigMap", configMaps}, {"Secret", secrets}} {
+		if x.names != nil && len(x.names) == 0 {
+			continue // Filtering with no names.
+		}
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(x.kind + "List"))
+		if err := s.c.List(ctx, list, client.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			o := &list.Items[i]
+			if (x.names != nil && !slices.Contains(x.names, o.Name)) || o.Labels["owner"] == "helm" || o.Annotations[serviceAccountKey] != "" {
+				continue
+			}
+			for _, mf := range o.ManagedFields {
+				if mf.Subresource != "" || mf.Time == nil {
+					continue
+				}
+				changes = append(changes, &Object{
+					Time:              mf.Time.UTC(),
+					Type:              Config,
+					Resource:          Resource{APIVersion: "v1", Kind: x.kind, Namespace: o.Namespace, Name: o.Name},
+					Manager:           mf.Manager,
+					HelmRelease:       helmRelease(o),
+					ArgoCDApplication: argoCDApplication(o),
+				})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// helmReleases returns revisions of Helm releases from the release Secrets created by Helm.
+// If release is not empty, only revisions of that release are included.
+func (s *Store) helmReleases(ctx context.Context, ns, release string) ([]*Object, error) {
+	labels := client.MatchingLabels{"owner": "helm"}
+	if release != "" {
+		labels["name"] = release
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := s.c.List(ctx, list, client.InNamespace(ns), labels); err != nil {
+		return nil, err
+	}
+	var changes []*Object
+	for i := range list.Items {
+		o := &list.Items[i]
+		changes = append(changes, &Object{
+			Time:        o.CreationTimestamp.UTC(),
+			Type:        Helm,
+			Resource:    Resource{APIVersion: "v1", Kind: "Secret", Namespace: o.Namespace, Name: o.Name},
+			Revision:    o.Labels["version"],
+			Status:      o.Labels["status"],
+			HelmRelease: o.Labels["name"],
+		})
+	}
+	return changes, nil
+}
+
+func helmRelease(o metav1.Object) string { return o.GetAnnotations()[helmReleaseAnnotation] }
+
+// argoCDApplication returns the application from an ArgoCD tracking annotation "app:group/kind:namespace/name",
+// or the ArgoCD instance label.
+func argoCDApplication(o metav1.Object) string {
+	if id := o.GetAnnotations()[argoCDAnnotation]; id != "" {
+		app, _, _ := strings.Cut(id, ":")
+		return app
+	}
+	return o.GetLabels()[argoCDLabel]
+}
+
+// references returns the names of ConfigMaps and Secrets used by a pod spec.
+// The returned slices are never nil.
+func references(spec *corev1.PodSpec) (configMaps, secrets []string) {
+	configMaps, secrets = []string{}, []string{}
+	addConfigMap := func(name string) {
+		if name != "" && !slices.Contains(configMaps, name) {
+			configMaps = append(configMaps, name)
+		}
+	}
+	addSecret := func(name string) {
+		if name != "" && !slices.Contains(secrets, name) {
+			secrets = append(secrets, name)
+		}
+	}
+	for _, v := range spec.Volumes {
+		if v.ConfigMap != nil {
+			addConfigMap(v.ConfigMap.Name)
+		}
+		if v.Secret != nil {
+			addSecret(v.Secret.SecretName)
+		}
+		if v.Projected != nil {
+			for _, p := range v.Projected.Sources {
+				if p.ConfigMap != nil {
+					addConfigMap(p.ConfigMap.Name)
+				}
+				if p.Secret != nil {
+					addSecret(p.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, c := range slices.Concat(spec.InitContainers, spec.Containers) {
+		for _, e := range c.EnvFrom {
+			if e.ConfigMapRef != nil {
+				addConfigMap(e.ConfigMapRef.Name)
+			}
+			if e.SecretRef != nil {
+				addSecret(e.SecretRef.Name)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+				addConfigMap(e.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				addSecret(e.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	for _, s := range spec.ImagePullSecrets {
+		addSecret(s.Name)
+	}
+	return configMaps, secrets
+}
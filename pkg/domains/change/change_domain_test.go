@@ -0,0 +1,18 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package change_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/pkg/domains/change"
+)
+
+var fixture = domain.Fixture{
+	Query:        &change.Query{Namespace: "demo"},
+	ClusterSetup: func(testing.TB) bool { return false },
+}
+
+func TestChangeDomain(t *testing.T)      { fixture.Test(t) }
+func BenchmarkChangeDomain(b *testing.B) { fixture.Benchmark(b) }
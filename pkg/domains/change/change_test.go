@@ -0,0 +1,325 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package change_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/change"
+	"github.com/korrel8r/korrel8r/pkg/domaintest"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var t0 = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func at(d time.Duration) metav1.Time { return metav1.NewTime(t0.Add(d)) }
+
+func meta(name string, created time.Duration, owner ...string) metav1.ObjectMeta {
+	m := metav1.ObjectMeta{Namespace: "demo", Name: name, CreationTimestamp: at(created)}
+	if len(owner) == 2 {
+		m.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: owner[0], Name: owner[1]}}
+	}
+	return m
+}
+
+func managed(m metav1.ObjectMeta, manager string, d time.Duration, subresource string) metav1.ObjectMeta {
+	tm := at(d)
+	m.ManagedFields = append(m.ManagedFields, metav1.ManagedFieldsEntry{
+		Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", Time: &tm, Subresource: subresource,
+		FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)},
+	})
+	return m
+}
+
+func replicaSet(owner string, revision int, d time.Duration) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{ObjectMeta: meta(fmt.Sprintf("%v-%v", owner, revision), d, "Deployment", owner)}
+	rs.Annotations = map[string]string{"deployment.kubernetes.io/revision": fmt.Sprint(revision)}
+	return rs
+}
+
+func objects() []client.Object {
+	web := &appsv1.Deployment{ObjectMeta: meta("web", -time.Hour)}
+	web.Annotations = map[string]string{"meta.helm.sh/release-name": "web", "argocd.argoproj.io/tracking-id": "shop:apps/Deployment:demo/web"}
+	web.Spec.Template.Spec = corev1.PodSpec{
+		Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}}},
+		Containers: []corev1.Container{{Name: "web", EnvFrom: []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-secret"}}}}}},
+	}
+	db := &appsv1.StatefulSet{ObjectMeta: meta("db", -time.Hour)}
+	webConfig := &corev1.ConfigMap{ObjectMeta: managed(managed(meta("web-config", -time.Hour), "kubectl-edit", -5*time.Minute, ""), "kubelet", -4*time.Minute, "status")}
+	webSecret := &corev1.Secret{ObjectMeta: managed(meta("web-secret", -time.Hour), "kubectl-create", -time.Hour, "")}
+	otherConfig := &corev1.ConfigMap{ObjectMeta: managed(meta("other", -time.Hour), "kubectl-apply", -3*time.Minute, "")}
+	helm := &corev1.Secret{ObjectMeta: meta("sh.helm.release.v1.web.v2", -10*time.Minute)}
+	helm.Labels = map[string]string{"owner": "helm", "name": "web", "version": "2", "status": "deployed"}
+	token := &corev1.Secret{ObjectMeta: managed(meta("token", -time.Hour), "kube-controller-manager", -2*time.Minute, "")}
+	token.Annotations = map[string]string{"kubernetes.io/service-account.name": "default"}
+	return []client.Object{
+		web, db, webConfig, webSecret, otherConfig, helm, token,
+		replicaSet("web", 1, -time.Hour), replicaSet("web", 2, -10*time.Minute),
+		&appsv1.ControllerRevision{ObjectMeta: meta("db-abc", -20*time.Minute, "StatefulSet", "db"), Revision: 3},
+	}
+}
+
+// preview returns previews of the changes returned by q.
+func preview(t *testing.T, s korrel8r.Store, q *change.Query, c *korrel8r.Constraint) []string {
+	t.Helper()
+	var r mock.Result
+	require.NoError(t, s.Get(context.Background(), q, c, &r))
+	var got []string
+	for _, o := range r {
+		got = append(got, change.Class{}.Preview(o))
+	}
+	return got
+}
+
+func TestStore_Get(t *testing.T) {
+	s := change.NewStore(fake.NewClientBuilder().WithObjects(objects()...).WithReturnManagedFields().Build())
+	for _, x := range []struct {
+		name  string
+		query change.Query
+		c     *korrel8r.Constraint
+		want  []string
+	}{
+		{"namespace", change.Query{Namespace: "demo"}, nil, []string{
+			"ConfigMap other updated by kubectl-apply",
+			"ConfigMap web-config updated by kubectl-edit",
+			"Helm release web revision 2 deployed",
+			"Deployment web revision 2",
+			"StatefulSet db revision 3",
+			"Deployment web revision 1",
+			"Secret web-secret updated by kubectl-create",
+		}},
+		{"workload", change.Query{Namespace: "demo", Kind: "Deployment", Name: "web"}, nil, []string{
+			"ConfigMap web-config updated by kubectl-edit",
+			"Helm release web revision 2 deployed",
+			"Deployment web revision 2",
+			"Deployment web revision 1",
+			"Secret web-secret updated by kubectl-create",
+		}},
+		{"statefulset", change.Query{Namespace: "demo", Kind: "StatefulSet", Name: "db"}, nil, []string{"StatefulSet db revision 3"}},
+		{"kind", change.Query{Namespace: "demo", Kind: "Deployment"}, nil, []string{"Deployment web revision 2", "Deployment web revision 1"}},
+		{"kind all namespaces", change.Query{Kind: "StatefulSet"}, nil, []string{"StatefulSet db revision 3"}},
+		{"time", change.Query{Namespace: "demo", Kind: "Deployment", Name: "web"}, &korrel8r.Constraint{Start: new(t0.Add(-15 * time.Minute))}, []string{
+			"ConfigMap web-config updated by kubectl-edit",
+			"Helm release web revision 2 deployed",
+			"Deployment web revision 2",
+		}},
+		{"limit", change.Query{Namespace: "demo"}, &korrel8r.Constraint{Limit: new(1)}, []string{"ConfigMap other updated by kubectl-apply"}},
+		{"not found", change.Query{Namespace: "demo", Kind: "Deployment", Name: "nonesuch"}, nil, nil},
+		{"other namespace", change.Query{Namespace: "nonesuch"}, nil, nil},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			assert.Equal(t, x.want, preview(t, s, &x.query, x.c))
+		})
+	}
+}
+
+func TestStore_Get_managedBy(t *testing.T) {
+	s := change.NewStore(fake.NewClientBuilder().WithObjects(objects()...).WithReturnManagedFields().Build())
+	var r mock.Result
+	require.NoError(t, s.Get(context.Background(), &change.Query{Namespace: "demo", Kind: "Deployment", Name: "web"}, nil, &r))
+	require.NotEmpty(t, r)
+	for _, o := range r {
+		o := o.(*change.Object)
+		if o.Type == change.Rollout {
+			assert.Equal(t, "web", o.HelmRelease)
+			assert.Equal(t, "shop", o.ArgoCDApplication)
+			assert.Equal(t, change.Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "demo", Name: "web"}, o.Resource)
+		}
+	}
+}
+
+func TestStore_Get_error(t *testing.T) {
+	s := change.NewStore(fake.NewClientBuilder().Build())
+	for _, q := range []*change.Query{{Name: "web", Kind: "Deployment"}, {Namespace: "demo", Name: "web", Kind: "Pod"}, {Namespace: "demo", Kind: "Pod"}} {
+		assert.Error(t, s.Get(context.Background(), q, nil, &mock.Result{}), "%v", q)
+	}
+}
+
+func TestStore_Suite(t *testing.T) {
+	var objs []client.Object
+	for i := range 10 {
+		objs = append(objs, replicaSet("web", i+1, time.Duration(i)*time.Minute))
+	}
+	s := &domaintest.Suite{
+		Domain: change.Domain,
+		Store:  change.NewStore(fake.NewClientBuilder().WithObjects(objs...).Build()),
+		Query:  &change.Query{Namespace: "demo"},
+		Len:    10,
+		Time:   change.Class{}.Time,
+	}
+	s.Run(t)
+}
+
+// fakeAPIServer serves discovery, gets and lists of objects to a real client, and records the request paths.
+// The fake client does not check what a real client sends, for example label selectors or metadata-only lists.
+type fakeAPIServer struct {
+	*httptest.Server
+	m     sync.Mutex
+	paths []string // Paths of get and list requests.
+}
+
+// resources maps object types to their API path prefix and resource name.
+var resources = map[reflect.Type][2]string{
+	reflect.TypeFor[*appsv1.Deployment]():         {"/apis/apps/v1", "deployments"},
+	reflect.TypeFor[*appsv1.StatefulSet]():        {"/apis/apps/v1", "statefulsets"},
+	reflect.TypeFor[*appsv1.ReplicaSet]():         {"/apis/apps/v1", "replicasets"},
+	reflect.TypeFor[*appsv1.ControllerRevision](): {"/apis/apps/v1", "controllerrevisions"},
+	reflect.TypeFor[*corev1.ConfigMap]():          {"/api/v1", "configmaps"},
+	reflect.TypeFor[*corev1.Secret]():             {"/api/v1", "secrets"},
+}
+
+// groupVersion returns the group version for an API path prefix, for example "apps/v1" for "/apis/apps/v1".
+func groupVersion(prefix string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(prefix, "/apis/"), "/api/")
+}
+
+func newFakeAPIServer(t *testing.T, objs []client.Object) *fakeAPIServer {
+	s := &fakeAPIServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.m.Lock()
+		defer s.m.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		write := func(v any) { _ = json.NewEncoder(w).Encode(v) }
+		switch r.URL.Path {
+		case "/api":
+			write(metav1.APIVersions{TypeMeta: metav1.TypeMeta{Kind: "APIVersions"}, Versions: []string{"v1"}})
+			return
+		case "/apis":
+			write(metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList"}, Groups: []metav1.APIGroup{{
+				Name:     "apps",
+				Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"}},
+			}}})
+			return
+		case "/api/v1", "/apis/apps/v1":
+			list := metav1.APIResourceList{TypeMeta: metav1.TypeMeta{Kind: "APIResourceList"}, GroupVersion: groupVersion(r.URL.Path)}
+			for typ, res := range resources {
+				if res[0] == r.URL.Path {
+					list.APIResources = append(list.APIResources, metav1.APIResource{
+						Name: res[1], Namespaced: true, Kind: typ.Elem().Name(), Verbs: []string{"get", "list"}})
+				}
+			}
+			write(list)
+			return
+		}
+		s.paths = append(s.paths, r.URL.Path)
+		selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+		require.NoError(t, err)
+		var (
+			items        []client.Object
+			list, prefix string
+		)
+		for _, o := range objs {
+			res := resources[reflect.TypeOf(o)]
+			ns := res[0] + "/namespaces/" + o.GetNamespace() + "/" + res[1]
+			switch r.URL.Path {
+			case ns + "/" + o.GetName():
+				write(o)
+				return
+			case ns, res[0] + "/" + res[1]:
+				list, prefix = reflect.TypeOf(o).Elem().Name()+"List", res[0]
+				if selector.Matches(labels.Set(o.GetLabels())) {
+					items = append(items, o)
+				}
+			}
+		}
+		if list == "" {
+			w.WriteHeader(http.StatusNotFound)
+			write(metav1.Status{TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}, Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound})
+			return
+		}
+		if strings.Contains(r.Header.Get("Accept"), "as=PartialObjectMetadataList") {
+			list := metav1.PartialObjectMetadataList{TypeMeta: metav1.TypeMeta{Kind: "PartialObjectMetadataList", APIVersion: "meta.k8s.io/v1"}}
+			for _, o := range items {
+				list.Items = append(list.Items, metav1.PartialObjectMetadata{
+					TypeMeta: metav1.TypeMeta{Kind: "PartialObjectMetadata", APIVersion: "meta.k8s.io/v1"}, ObjectMeta: *o.(metav1.ObjectMetaAccessor).GetObjectMeta().(*metav1.ObjectMeta)})
+			}
+			write(list)
+			return
+		}
+		write(map[string]any{"kind": list, "apiVersion": groupVersion(prefix), "metadata": map[string]any{}, "items": items})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// kubeconfig writes a kubeconfig for the server, returns the file name.
+func (s *fakeAPIServer) kubeconfig(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(file, []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster: {server: "`+s.URL+`", insecure-skip-tls-verify: true}
+users:
+- name: korrel8r
+  user: {token: "store-token"}
+contexts:
+- name: fake
+  context: {cluster: fake, user: korrel8r}
+current-context: fake
+`), 0o600))
+	return file
+}
+
+func TestStore_Get_apiServer(t *testing.T) {
+	for _, x := range []struct {
+		name      string
+		query     change.Query
+		want      []string
+		wantPaths []string
+	}{
+		{"kind", change.Query{Namespace: "demo", Kind: "Deployment"},
+			[]string{"Deployment web revision 2", "Deployment web revision 1"},
+			[]string{"/apis/apps/v1/namespaces/demo/replicasets"}},
+		{"kind all namespaces", change.Query{Kind: "StatefulSet"},
+			[]string{"StatefulSet db revision 3"},
+			[]string{"/apis/apps/v1/controllerrevisions"}},
+		{"workload", change.Query{Namespace: "demo", Kind: "Deployment", Name: "web"},
+			[]string{
+				"ConfigMap web-config updated by kubectl-edit",
+				"Helm release web revision 2 deployed",
+				"Deployment web revision 2",
+				"Deployment web revision 1",
+				"Secret web-secret updated by kubectl-create",
+			},
+			[]string{
+				"/apis/apps/v1/namespaces/demo/deployments/web",
+				"/apis/apps/v1/namespaces/demo/replicasets",
+				"/api/v1/namespaces/demo/configmaps",
+				"/api/v1/namespaces/demo/secrets",
+				"/api/v1/namespaces/demo/secrets",
+			}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			s := newFakeAPIServer(t, objects())
+			store, err := change.Domain.Store(config.Store{config.StoreKeyDomain: "change", config.StoreKeyKubeconfig: s.kubeconfig(t)})
+			require.NoError(t, err)
+			assert.Equal(t, x.want, preview(t, store, &x.query, nil))
+			assert.Equal(t, x.wantPaths, s.paths)
+		})
+	}
+}
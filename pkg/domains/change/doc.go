@@ -0,0 +1,63 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package change is a korrel8r domain for recent changes to cluster resources.
+//
+// Changes are derived from the current state of the cluster, no extra data store is needed:
+//
+//   - Rollouts: revisions of a Deployment from its ReplicaSets, revisions of a StatefulSet or DaemonSet from its ControllerRevisions.
+//   - Config: updates to ConfigMaps and Secrets, from the times in metadata.managedFields.
+//   - Helm: revisions of a Helm release, from the release Secrets created by Helm.
+//
+// Changes record the Helm release and ArgoCD application that manage a resource, if there are any.
+// The cluster keeps a limited history, older changes may not be available.
+//
+// # Classes
+//
+// There is a single class:
+//
+//	change:change
+//
+// # Object
+//
+// A change is a JSON object, for example:
+//
+//	{
+//	  "time": "2024-01-02T03:04:05Z",
+//	  "type": "rollout",
+//	  "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "demo", "name": "web"},
+//	  "revision": "3",
+//	  "helmRelease": "web"
+//	}
+//
+// The type is one of "rollout", "config" or "helm".
+// Config changes have a "manager" field naming the field manager that made the update.
+// Helm changes have a "status" field with the release status, for example "deployed" or "failed".
+//
+// # Query
+//
+// Query selectors are JSON objects. Results are limited to the time range of the constraint.
+//
+// All changes in a namespace, or in all namespaces if the namespace is omitted:
+//
+//	change:change:{"namespace":"demo"}
+//
+// Changes that affect a workload: rollouts of the workload, updates to ConfigMaps and Secrets
+// used by its pod template, and revisions of the Helm release that manages it.
+// The kind must be Deployment, StatefulSet or DaemonSet.
+//
+//	change:change:{"namespace":"demo","kind":"Deployment","name":"web"}
+//
+// Rollouts of all workloads of a kind, in a namespace or in all namespaces:
+//
+//	change:change:{"namespace":"demo","kind":"Deployment"}
+//
+// # Store
+//
+// The change store uses the same cluster connection as the k8s store. Store configuration:
+//
+//	domain: change
+//
+// The store needs permission to list ReplicaSets, ControllerRevisions, ConfigMaps and Secrets,
+// and to get Deployments, StatefulSets and DaemonSets.
+// Only Secret metadata is read, never the secret data.
+package change
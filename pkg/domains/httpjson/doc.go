@@ -0,0 +1,45 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package httpjson creates korrel8r domains from configuration, for HTTP endpoints that return JSON.
+//
+// Each domain is declared in the "domains" section of the configuration, see [config.Domain].
+// No Go code is needed to add a domain for an internal system, for example a deployment tracker or CMDB.
+//
+//	domains:
+//	  - name: cmdb
+//	    http:
+//	      classes:
+//	        - name: service
+//	          url: '{{.Store.url}}/api/services?namespace={{.Query.namespace | urlquery}}'
+//	          objects: '.results[*]'
+//	          id: '.id'
+//	          preview: '.name'
+//	          time: '.updated'
+//
+// # Classes
+//
+// Classes are declared by the domain configuration, see [config.HTTPClass].
+// Objects, IDs, previews and times are selected with JSONPath expressions, using the kubectl syntax,
+// or with a subset of jq: pipes of paths like ".items[] | .name".
+//
+// # Object
+//
+// Objects are decoded JSON values: map[string]any for a JSON object.
+//
+// # Query
+//
+// Query selectors are JSON objects, used as parameters for the class URL template.
+//
+//	cmdb:service:{"namespace":"billing"}
+//
+// # Store
+//
+// A client of the HTTP endpoints. The store configuration is available to URL templates as .Store.
+//
+//	domain: cmdb
+//	url: https://cmdb.example.com
+//	certificateAuthority: /path/to/ca.crt # Optional.
+//	auth: token                           # Optional, forward the caller's bearer token.
+//
+// The caller's bearer token is only forwarded if the store "auth" field is "token".
+package httpjson
@@ -0,0 +1,228 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package httpjson
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/jsonpath"
+	"github.com/korrel8r/korrel8r/internal/pkg/storeclient"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+)
+
+var _ = impl.AssertDomainTypes(&Domain{}, map[string]any{}, &Class{}, Query{}, &Store{})
+
+// Domain is an HTTP/JSON domain created from configuration.
+type Domain struct {
+	*impl.Domain
+}
+
+// New creates a domain from configuration.
+func New(cfg config.Domain) (*Domain, error) {
+	if cfg.HTTP == nil {
+		return nil, fmt.Errorf("domain %q: no http section", cfg.Name)
+	}
+	if cfg.Name == "" || strings.Contains(cfg.Name, ":") {
+		return nil, fmt.Errorf("invalid domain name: %q", cfg.Name)
+	}
+	if len(cfg.HTTP.Classes) == 0 {
+		return nil, fmt.Errorf("domain %q: no classes", cfg.Name)
+	}
+	d := &Domain{}
+	var classes []korrel8r.Class
+	seen := map[string]bool{}
+	for _, cc := range cfg.HTTP.Classes {
+		c, err := newClass(d, cc)
+		if err != nil {
+			return nil, fmt.Errorf("domain %q: %w", cfg.Name, err)
+		}
+		if seen[c.name] {
+			return nil, fmt.Errorf("domain %q: duplicate class name %q", cfg.Name, c.name)
+		}
+		seen[c.name] = true
+		classes = append(classes, c)
+	}
+	d.Domain = impl.NewDomain(cfg.Name, cfg.Description, classes...)
+	return d, nil
+}
+
+// Query parses a query string, the data must be a JSON object.
+func (d *Domain) Query(s string) (korrel8r.Query, error) {
+	c, data, err := impl.ParseQuery(d, s)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(data) == "" {
+		data = "{}"
+	}
+	var params map[string]any
+	if err := json.Unmarshal([]byte(data), &params); err != nil {
+		return nil, fmt.Errorf("invalid query: %w: %v", err, data)
+	}
+	// Normalize the data so equal parameters give equal queries.
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return Query{class: c.(*Class), data: string(b)}, nil
+}
+
+// Store creates a store from a [config.Store].
+func (d *Domain) Store(s any) (korrel8r.Store, error) {
+	cs, err := impl.TypeAssert[config.Store](s)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := storeclient.New(cs, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Store: impl.NewStore(d), config: cs, client: hc}, nil
+}
+
+// Class is an HTTP endpoint that returns JSON objects.
+type Class struct {
+	domain                       *Domain
+	name, description            string
+	url                          *template.Template
+	objects, id, preview, timeAt *jsonpath.Path
+}
+
+var _ interface {
+	korrel8r.IDer
+	korrel8r.Previewer
+} = &Class{}
+
+func newClass(d *Domain, cc config.HTTPClass) (c *Class, err error) {
+	if cc.Name == "" || strings.Contains(cc.Name, ":") {
+		return nil, fmt.Errorf("invalid class name: %q", cc.Name)
+	}
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("class %q: %w", cc.Name, err)
+		}
+	}()
+	if cc.URL == "" {
+		return nil, errors.New("no url")
+	}
+	c = &Class{domain: d, name: cc.Name, description: cc.Description}
+	if c.url, err = template.New(cc.Name).Funcs(sprig.TxtFuncMap()).Parse(cc.URL); err != nil {
+		return nil, err
+	}
+	for _, p := range []struct {
+		field **jsonpath.Path
+		expr  string
+	}{{&c.objects, cc.Objects}, {&c.id, cc.ID}, {&c.preview, cc.Preview}, {&c.timeAt, cc.Time}} {
+		if *p.field, err = jsonpath.NewExpr(p.expr); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Class) Domain() korrel8r.Domain { return c.domain }
+func (c *Class) Name() string            { return c.name }
+func (c *Class) String() string          { return korrel8r.ClassString(c) }
+func (c *Class) Description() string     { return c.description }
+
+func (c *Class) Unmarshal(b []byte) (korrel8r.Object, error) {
+	var o any
+	err := json.Unmarshal(b, &o)
+	return o, err
+}
+
+// ID returns the value selected by the class ID path, or the JSON encoding of the object if there is none.
+func (c *Class) ID(o korrel8r.Object) any {
+	if id := c.id.FindString(o); id != "" {
+		return id
+	}
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+// Preview returns the value selected by the class preview path, or the ID if there is none.
+func (c *Class) Preview(o korrel8r.Object) string {
+	return cmp.Or(c.preview.FindString(o), c.id.FindString(o))
+}
+
+// Time returns the time of an object, the zero time if the class has no time path or the value is not a time.
+func (c *Class) Time(o korrel8r.Object) time.Time { return c.timeAt.FindTime(o) }
+
+// Query has a class and normalized JSON parameters.
+type Query struct {
+	class *Class
+	data  string
+}
+
+func (q Query) Class() korrel8r.Class { return q.class }
+func (q Query) Data() string          { return q.data }
+func (q Query) String() string        { return korrel8r.QueryString(q) }
+
+// Store gets objects from HTTP endpoints.
+type Store struct {
+	*impl.Store
+	config config.Store
+	client *http.Client
+}
+
+// Get expands the URL template of the query class, and appends the objects in the response.
+// Objects outside the constraint time range are dropped if the class has a time path.
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[Query](query)
+	if err != nil {
+		return err
+	}
+	if q.class.domain != s.Domain() {
+		return fmt.Errorf("wrong domain for store %v: %v", s.Domain().Name(), query)
+	}
+	var params map[string]any
+	if err := json.Unmarshal([]byte(q.data), &params); err != nil {
+		return err
+	}
+	var w bytes.Buffer
+	data := map[string]any{"Query": params, "Store": maps.Clone(s.config), "Constraint": c}
+	if err := q.class.url.Execute(&w, data); err != nil {
+		return err
+	}
+	u, err := url.Parse(strings.TrimSpace(w.String()))
+	if err != nil {
+		return err
+	}
+	var body any
+	if err := impl.Get(ctx, u, s.client, &body); err != nil {
+		return err
+	}
+	var objects []any
+	if q.class.objects != nil {
+		objects = q.class.objects.FindAll(body)
+	} else if list, ok := body.([]any); ok {
+		objects = list
+	} else if body != nil {
+		objects = []any{body}
+	}
+	limit, n := c.GetLimit(), 0
+	for _, o := range objects {
+		if limit > 0 && n >= limit {
+			break
+		}
+		if c.CompareTime(q.class.Time(o)) == 0 {
+			result.Append(o)
+			n++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package httpjson_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/httpjson"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cmdb = config.Domain{
+	Name:        "cmdb",
+	Description: "Configuration management database.",
+	HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{
+		{
+			Name:    "service",
+			URL:     `{{.Store.url}}/services?namespace={{.Query.namespace | urlquery}}`,
+			Objects: ".results[*]",
+			ID:      ".id",
+			Preview: "{.name}",
+			Time:    ".updated",
+		},
+		{
+			Name: "owner",
+			URL:  `{{.Store.url}}/owners/{{.Query.name}}`,
+		},
+	}},
+}
+
+// newServer serves JSON for the cmdb domain, and records the last Authorization header.
+func newServer(t *testing.T) (srv *httptest.Server, authorization *string) {
+	authorization = new(string)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+		if r.URL.Query().Get("namespace") != "billing" {
+			_, _ = w.Write([]byte(`{"results": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [
+			{"id": "s1", "name": "invoices", "updated": "2026-01-01T10:00:00Z"},
+			{"id": "s2", "name": "payments", "updated": 1767265200},
+			{"id": "s1", "name": "invoices", "updated": "2026-01-01T10:00:00Z"}
+		]}`))
+	})
+	mux.HandleFunc("/owners/alice", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "alice", "team": "sre"}`))
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, authorization
+}
+
+func TestNew_Error(t *testing.T) {
+	for _, x := range []struct {
+		cfg config.Domain
+		err string
+	}{
+		{config.Domain{Name: "x"}, `domain "x": no http section`},
+		{config.Domain{Name: "a:b", HTTP: &config.HTTPDomain{}}, `invalid domain name: "a:b"`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{}}, `domain "x": no classes`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{{Name: "c"}}}}, `class "c": no url`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{{Name: "c", URL: "{{"}}}}, `class "c": template`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{{Name: "c", URL: "u", ID: ".a["}}}}, `invalid JSONPath`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{{Name: "c", URL: "u", ID: ".a | length"}}}}, `invalid jq`},
+		{config.Domain{Name: "x", HTTP: &config.HTTPDomain{Classes: []config.HTTPClass{{Name: "c", URL: "u"}, {Name: "c", URL: "u"}}}}, `duplicate class name "c"`},
+	} {
+		t.Run(x.err, func(t *testing.T) {
+			_, err := httpjson.New(x.cfg)
+			assert.ErrorContains(t, err, x.err)
+		})
+	}
+}
+
+func TestDomain_Query(t *testing.T) {
+	d, err := httpjson.New(cmdb)
+	require.NoError(t, err)
+	assert.Equal(t, "cmdb", d.Name())
+	assert.Len(t, d.Classes(), 2)
+	q, err := d.Query(`cmdb:service:{"namespace": "billing", "a": 1}`)
+	require.NoError(t, err)
+	assert.Equal(t, `cmdb:service:{"a":1,"namespace":"billing"}`, q.String())
+	q2, err := d.Query(`cmdb:service:{"a":1,"namespace":"billing"}`)
+	require.NoError(t, err)
+	assert.Equal(t, q, q2, "normalized queries are equal")
+	q, err = d.Query(`cmdb:owner:`)
+	require.NoError(t, err)
+	assert.Equal(t, `cmdb:owner:{}`, q.String())
+	_, err = d.Query(`cmdb:service:["x"]`)
+	assert.ErrorContains(t, err, "invalid query")
+	_, err = d.Query(`cmdb:nonesuch:{}`)
+	assert.Error(t, err)
+}
+
+func TestStore_Get(t *testing.T) {
+	srv, authorization := newServer(t)
+	d, err := httpjson.New(cmdb)
+	require.NoError(t, err)
+	s, err := d.Store(config.Store{"domain": "cmdb", "url": srv.URL})
+	require.NoError(t, err)
+	ctx := auth.WithToken(context.Background(), "secret")
+
+	q, err := d.Query(`cmdb:service:{"namespace":"billing"}`)
+	require.NoError(t, err)
+	r := result.New(q.Class())
+	require.NoError(t, s.Get(ctx, q, nil, r))
+	require.Len(t, r.List(), 2, "duplicates removed")
+	class := q.Class().(*httpjson.Class)
+	assert.Equal(t, []string{"invoices", "payments"}, []string{class.Preview(r.List()[0]), class.Preview(r.List()[1])})
+	assert.Equal(t, "s2", class.ID(r.List()[1]))
+	assert.Equal(t, time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), class.Time(r.List()[1]).UTC())
+	assert.Empty(t, *authorization, "token not forwarded by default")
+
+	t.Run("constraint", func(t *testing.T) {
+		start := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+		r := result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, &korrel8r.Constraint{Start: &start}, r))
+		assert.Equal(t, []string{"s2"}, []string{class.ID(r.List()[0]).(string)})
+		r = result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, &korrel8r.Constraint{Limit: new(1)}, r))
+		assert.Len(t, r.List(), 1)
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		q, err := d.Query(`cmdb:owner:{"name":"alice"}`)
+		require.NoError(t, err)
+		r := result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, nil, r))
+		assert.Equal(t, []korrel8r.Object{map[string]any{"name": "alice", "team": "sre"}}, r.List())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		q, err := d.Query(`cmdb:owner:{"name":"bob"}`)
+		require.NoError(t, err)
+		assert.ErrorContains(t, s.Get(ctx, q, nil, result.New(q.Class())), "404")
+	})
+
+	t.Run("jq", func(t *testing.T) {
+		cfg := cmdb
+		cfg.HTTP = &config.HTTPDomain{Classes: []config.HTTPClass{{
+			Name: "service", URL: cmdb.HTTP.Classes[0].URL, Objects: ".results | .[]", ID: ".id", Preview: `. | .["name"]`,
+		}}}
+		d, err := httpjson.New(cfg)
+		require.NoError(t, err)
+		s, err := d.Store(config.Store{"domain": "cmdb", "url": srv.URL})
+		require.NoError(t, err)
+		q, err := d.Query(`cmdb:service:{"namespace":"billing"}`)
+		require.NoError(t, err)
+		r := result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, nil, r))
+		class := q.Class().(*httpjson.Class)
+		require.Len(t, r.List(), 2, "duplicates removed")
+		assert.Equal(t, []string{"invoices", "payments"}, []string{class.Preview(r.List()[0]), class.Preview(r.List()[1])})
+	})
+
+	t.Run("auth token", func(t *testing.T) {
+		s, err := d.Store(config.Store{"domain": "cmdb", "url": srv.URL, "auth": "token"})
+		require.NoError(t, err)
+		require.NoError(t, s.Get(ctx, q, nil, result.New(q.Class())))
+		assert.Equal(t, "Bearer secret", *authorization)
+		_, err = d.Store(config.Store{"domain": "cmdb", "auth": "impersonate"})
+		assert.ErrorContains(t, err, "invalid store auth")
+	})
+}
+
+func TestEngine(t *testing.T) {
+	srv, _ := newServer(t)
+	e, err := engine.Build().Domains(mock.NewDomain("mock", "namespace")).Config(config.Configs{
+		{
+			Stores: []config.Store{{"domain": "cmdb", "url": srv.URL}},
+			Rules: []config.Rule{{
+				Name:   "NamespaceToService",
+				Start:  config.ClassSpec{Domain: "mock", Classes: []string{"namespace"}},
+				Goal:   config.ClassSpec{Domain: "cmdb", Classes: []string{"service"}},
+				Result: config.ResultSpec{Query: `cmdb:service:{"namespace":"{{.}}"}`},
+			}},
+		},
+		{Domains: []config.Domain{cmdb}}, // Domains may be defined after they are used.
+	}).Engine()
+	require.NoError(t, err)
+	queries, err := e.Rule("NamespaceToService").Apply("billing")
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+	r := result.New(queries[0].Class())
+	start, end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, e.Get(context.Background(), queries[0], &korrel8r.Constraint{Start: &start, End: &end}, r))
+	assert.Len(t, r.List(), 2)
+
+	_, err = engine.Build().Domains(mock.NewDomain("cmdb")).Config(config.Configs{{Domains: []config.Domain{cmdb}}}).Engine()
+	assert.ErrorContains(t, err, "duplicate domain name: cmdb")
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/unique"
 	"github.com/prometheus/client_golang/api"
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
@@ -83,11 +85,58 @@ func (c Class) Preview(o korrel8r.Object) string {
 	return ""
 }
 
+// severities in increasing order of severity.
+var severities = []string{"none", "info", "warning", "critical"}
+
+// Summary has the incident ID, last time seen, the number of alerts,
+// the names and namespaces of the alerts and the highest alert severity.
+func (c Class) Summary(o korrel8r.Object) map[string]any {
+	i, ok := o.(*Object)
+	if !ok || i == nil {
+		return nil
+	}
+	s := map[string]any{"id": i.Id, "alerts": len(i.AlertsLabels)}
+	if !i.Time.IsZero() {
+		s["time"] = i.Time
+	}
+	names, namespaces := unique.NewList[string](), unique.NewList[string]()
+	severity := -1
+	for _, labels := range i.AlertsLabels {
+		if v := labels["alertname"]; v != "" {
+			names.Add(v)
+		}
+		if v := labels["namespace"]; v != "" {
+			namespaces.Add(v)
+		}
+		severity = max(severity, slices.Index(severities, labels["severity"]))
+	}
+	if len(names.List) > 0 {
+		s["alertNames"] = names.List
+	}
+	if len(namespaces.List) > 0 {
+		s["namespaces"] = namespaces.List
+	}
+	if severity >= 0 {
+		s["severity"] = severities[severity]
+	}
+	return s
+}
+
+// Time returns the time the incident was last seen.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, ok := o.(*Object); ok {
+		return o.Time
+	}
+	return time.Time{}
+}
+
 // Object contains incident data, passed as *Object when used as a korrel8r.Object.
 type Object struct {
 	// Common fields.
 	Id           string              `json:"id"`
 	AlertsLabels []map[string]string `json:"alertsLabels"`
+	// Time of the latest sample for the incident.
+	Time time.Time `json:"time,omitzero"`
 
 	// Prometheus fields.
 	Value string `json:"value"`
@@ -211,6 +260,9 @@ func loadObjects(data model.Vector) []*Object {
 		if labels["type"] == "alert" {
 			i.AlertsLabels = append(i.AlertsLabels, srcLabels)
 		}
+		if t := s.Timestamp.Time(); t.After(i.Time) {
+			i.Time = t
+		}
 	}
 
 	ret := make([]*Object, 0, len(incidents))
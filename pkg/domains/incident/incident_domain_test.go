@@ -4,9 +4,11 @@ package incident_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
 	"github.com/korrel8r/korrel8r/pkg/domains/incident"
+	"github.com/stretchr/testify/assert"
 )
 
 var fixture = domain.Fixture{
@@ -16,3 +18,21 @@ var fixture = domain.Fixture{
 
 func TestAlertDomain(t *testing.T)      { fixture.Test(t) }
 func BenchmarkAlertDomain(b *testing.B) { fixture.Benchmark(b) }
+
+func TestClass_Summary(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	i := &incident.Object{
+		Id:   "inc-1",
+		Time: now,
+		AlertsLabels: []map[string]string{
+			{"alertname": "KubePodCrashLooping", "namespace": "shop", "severity": "warning"},
+			{"alertname": "TargetDown", "namespace": "monitoring", "severity": "critical"},
+			{"alertname": "KubePodCrashLooping", "namespace": "shop", "severity": "warning"},
+		},
+	}
+	assert.Equal(t, map[string]any{
+		"id": "inc-1", "time": now, "alerts": 3, "severity": "critical",
+		"alertNames": []string{"KubePodCrashLooping", "TargetDown"}, "namespaces": []string{"shop", "monitoring"},
+	}, incident.Class{}.Summary(i))
+	assert.Nil(t, incident.Class{}.Summary("not an incident"))
+}
@@ -3,12 +3,16 @@
 package k8s
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/go-logr/logr"
-	kconfig "github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	kconfig "github.com/korrel8r/korrel8r/pkg/config"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	klog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -32,8 +36,14 @@ func NewClient(cfg *rest.Config) (c client.WithWatch, err error) {
 }
 
 // NewHTTPClient returns a new client with TLS settings from Store config.
+// Credentials come from the store's kubeconfig and context, see [GetStoreConfig].
+// Impersonation is a feature of the Kubernetes API server, it is not allowed for HTTP stores.
 func NewHTTPClient(s kconfig.Store) (*http.Client, error) {
-	cfg, err := GetConfig()
+	if s[kconfig.StoreKeyAuth] == kconfig.StoreAuthImpersonate {
+		return nil, fmt.Errorf("store %v %q is only supported by the Kubernetes API server, use %q",
+			kconfig.StoreKeyAuth, kconfig.StoreAuthImpersonate, kconfig.StoreAuthToken)
+	}
+	cfg, err := GetStoreConfig(s)
 	if err != nil {
 		return nil, err
 	}
@@ -48,13 +58,85 @@ func NewHTTPClient(s kconfig.Store) (*http.Client, error) {
 const kubeFlowLimit = 1000
 
 // GetConfig returns a rest.Config with settings for use by korrel8r.
+// The caller's bearer token is forwarded if there is one, see [auth.Wrap].
 func GetConfig() (*rest.Config, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return nil, err
 	}
+	return storeAuth(tune(cfg), "")
+}
+
+// GetStoreConfig returns a rest.Config using the kubeconfig file and context from Store config.
+// Uses the default kube config if neither is set.
+//
+// The `auth` store key sets how requests are authorized, see [kconfig.StoreKeyAuth].
+func GetStoreConfig(s kconfig.Store) (*rest.Config, error) {
+	kubeconfig, context := s[kconfig.StoreKeyKubeconfig], s[kconfig.StoreKeyContext]
+	var (
+		cfg *rest.Config
+		err error
+	)
+	if kubeconfig == "" && context == "" {
+		cfg, err = config.GetConfig()
+	} else {
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		if kubeconfig != "" {
+			rules.ExplicitPath = kubeconfig
+		}
+		cfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+			&clientcmd.ConfigOverrides{CurrentContext: context}).ClientConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return storeAuth(tune(cfg), s[kconfig.StoreKeyAuth])
+}
+
+func tune(cfg *rest.Config) *rest.Config {
 	cfg.QPS = float32(kubeFlowLimit)
 	cfg.Burst = kubeFlowLimit
-	cfg.Wrap(auth.Wrap)
+	return cfg
+}
+
+// storeAuth sets up authorization of requests for a [kconfig.StoreKeyAuth] value.
+func storeAuth(cfg *rest.Config, mode string) (*rest.Config, error) {
+	switch mode {
+	case "":
+		cfg.Wrap(auth.Wrap)
+	case kconfig.StoreAuthToken:
+		// Drop the configured credentials so they can never be used in place of the caller's.
+		cfg = rest.AnonymousClientConfig(cfg)
+		cfg.Wrap(auth.Require)
+	case kconfig.StoreAuthImpersonate:
+		cfg.Wrap(impersonate)
+	default:
+		return nil, fmt.Errorf("invalid store %v: %q", kconfig.StoreKeyAuth, mode)
+	}
 	return cfg, nil
 }
+
+// errNoUser is returned when impersonating a request that has no authenticated user.
+var errNoUser = errors.New("no authenticated user to impersonate")
+
+// errNoUsername is returned when impersonating a user that has no username, for example a user identified by UID.
+var errNoUsername = errors.New("authenticated user has no username to impersonate")
+
+// impersonate adds impersonation headers for the user in the request context, see [auth.WithUser].
+func impersonate(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		u := auth.ContextUser(req.Context())
+		if u == nil {
+			return nil, errNoUser
+		}
+		if u.Username == "" {
+			return nil, errNoUsername
+		}
+		return transport.NewImpersonatingRoundTripper(
+			transport.ImpersonationConfig{UserName: u.Username, Groups: u.Groups}, next).RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
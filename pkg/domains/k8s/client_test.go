@@ -0,0 +1,160 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIServer serves discovery and a single pod, and records request headers.
+// It uses TLS, kube clients do not send credentials to plain HTTP servers.
+type fakeAPIServer struct {
+	*httptest.Server
+	m         sync.Mutex
+	discovery []http.Header // Headers of discovery requests.
+	objects   []http.Header // Headers of object and HTTP store requests.
+}
+
+func newFakeAPIServer(t *testing.T) *fakeAPIServer {
+	s := &fakeAPIServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.m.Lock()
+		defer s.m.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		body := ""
+		switch r.URL.Path {
+		case "/api":
+			body = `{"kind":"APIVersions","versions":["v1"]}`
+		case "/apis":
+			body = `{"kind":"APIGroupList","groups":[]}`
+		case "/api/v1":
+			body = `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","namespaced":true,"kind":"Pod","verbs":["get","list"]}]}`
+		default:
+			s.objects = append(s.objects, r.Header.Clone())
+			body = `{"apiVersion":"v1","kind":"Pod","metadata":{"namespace":"ns","name":"p"}}`
+		}
+		if strings.HasPrefix(r.URL.Path, "/api") && r.URL.Path != "/api/v1/namespaces/ns/pods/p" {
+			s.discovery = append(s.discovery, r.Header.Clone())
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// kubeconfig writes a kubeconfig for the server with a store token, returns the file name.
+func (s *fakeAPIServer) kubeconfig(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(file, []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster: {server: "`+s.URL+`", insecure-skip-tls-verify: true}
+users:
+- name: korrel8r
+  user: {token: "store-token"}
+contexts:
+- name: fake
+  context: {cluster: fake, user: korrel8r}
+current-context: fake
+`), 0o600))
+	return file
+}
+
+func TestStoreAuth(t *testing.T) {
+	alice := &auth.User{Name: "alice", Username: "alice", Groups: []string{"dev", "ops"}}
+	for _, x := range []struct {
+		name, mode  string
+		token       string
+		user        *auth.User
+		wantAuth    string
+		wantUser    string
+		wantGroups  []string
+		wantErr     string
+		wantHTTPErr string
+	}{
+		{name: "default with token", token: "caller", wantAuth: "Bearer caller"},
+		{name: "default no token", wantAuth: "Bearer store-token"},
+		{name: "token", mode: config.StoreAuthToken, token: "caller", wantAuth: "Bearer caller"},
+		{name: "token missing", mode: config.StoreAuthToken, wantErr: auth.ErrNoToken.Error()},
+		{
+			name: "impersonate", mode: config.StoreAuthImpersonate, token: "caller", user: alice,
+			wantAuth: "Bearer store-token", wantUser: "alice", wantGroups: []string{"dev", "ops"},
+			wantHTTPErr: "only supported by the Kubernetes API server",
+		},
+		{
+			name: "impersonate no user", mode: config.StoreAuthImpersonate, token: "caller",
+			wantErr: errNoUser.Error(), wantHTTPErr: "only supported by the Kubernetes API server",
+		},
+		{
+			name: "impersonate no username", mode: config.StoreAuthImpersonate, token: "caller", user: &auth.User{Name: "uid-123"},
+			wantErr: errNoUsername.Error(), wantHTTPErr: "only supported by the Kubernetes API server",
+		},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			s := newFakeAPIServer(t)
+			cs := config.Store{config.StoreKeyDomain: "k8s", config.StoreKeyKubeconfig: s.kubeconfig(t), config.StoreKeyAuth: x.mode}
+			ctx := auth.WithUser(auth.WithToken(context.Background(), x.token), x.user)
+
+			t.Run("k8s", func(t *testing.T) {
+				store, err := Domain.NewStoreForConfig(cs)
+				require.NoError(t, err)
+				r := result.New(pod)
+				err = store.Get(ctx, newQuery(pod, "ns", "p", nil, nil), nil, r)
+				if x.wantErr != "" {
+					require.ErrorContains(t, err, x.wantErr)
+					assert.Empty(t, s.objects, "request must not be sent")
+					return
+				}
+				require.NoError(t, err)
+				assert.Len(t, r.List(), 1)
+				require.Len(t, s.objects, 1)
+				h := s.objects[0]
+				assert.Equal(t, x.wantAuth, h.Get("Authorization"))
+				assert.Equal(t, x.wantUser, h.Get("Impersonate-User"))
+				assert.Equal(t, x.wantGroups, h.Values("Impersonate-Group"))
+				for _, h := range s.discovery {
+					assert.Equal(t, "Bearer store-token", h.Get("Authorization"), "discovery uses store credentials")
+				}
+			})
+
+			t.Run("http", func(t *testing.T) {
+				s.objects = nil
+				hc, err := NewHTTPClient(cs)
+				if x.wantHTTPErr != "" {
+					require.ErrorContains(t, err, x.wantHTTPErr)
+					return
+				}
+				require.NoError(t, err)
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/loki/api/v1/query_range", nil)
+				require.NoError(t, err)
+				resp, err := hc.Do(req)
+				if x.wantErr != "" {
+					require.ErrorContains(t, err, x.wantErr)
+					assert.Empty(t, s.objects, "request must not be sent")
+					return
+				}
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				require.Len(t, s.objects, 1)
+				assert.Equal(t, x.wantAuth, s.objects[0].Get("Authorization"))
+			})
+		})
+	}
+	_, err := GetStoreConfig(config.Store{config.StoreKeyKubeconfig: newFakeAPIServer(t).kubeconfig(t), config.StoreKeyAuth: "nonesuch"})
+	assert.ErrorContains(t, err, `invalid store auth: "nonesuch"`)
+}
@@ -46,6 +46,19 @@
 //	stores:
 //	    domain: k8s
 //
+// To connect to a different cluster, set the path to a kubeconfig file and/or a kubeconfig context.
+// Tag the store with a cluster name to correlate across multiple clusters:
+//
+//	stores:
+//	    domain: k8s
+//	    cluster: east
+//	    kubeconfig: /path/to/kubeconfig
+//	    context: east-admin
+//
+// To act on behalf of the caller, so users only see objects their own RBAC allows,
+// set `auth: token` to use the caller's bearer token,
+// or `auth: impersonate` to impersonate the caller's user and groups with korrel8r's credentials.
+//
 // # Field Selectors
 //
 // Kubernetes defines [field selectors],
@@ -7,14 +7,17 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"maps"
 	"net/url"
 	"regexp"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	kconfig "github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
 	"github.com/korrel8r/korrel8r/pkg/unique"
@@ -29,6 +32,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 //go:embed doc.md
@@ -66,6 +70,17 @@ type Selector struct {
 //
 //	 stores:
 //		  domain: k8s
+//
+// To connect to other clusters, set a kubeconfig file and/or context, and tag the store with a cluster name:
+//
+//	 stores:
+//		  domain: k8s
+//		  cluster: east
+//		  context: east-admin
+//		  kubeconfig: /path/to/kubeconfig
+//
+// To act on behalf of the caller of each request, set `auth` to `token` or `impersonate`.
+// See [kconfig.StoreKeyAuth].
 type Store struct {
 	cfg      *rest.Config
 	c        client.WithWatch
@@ -152,8 +167,55 @@ func (d *domain) addClasses(groups []*metav1.APIGroup, resourceLists []*metav1.A
 	}
 }
 
-// Store connects to the kube config default cluster. The config parameter is ignored.
-func (d *domain) Store(any) (korrel8r.Store, error) { return d.NewStore(nil, nil) }
+// Store connects to the cluster selected by the kubeconfig and context store keys,
+// or the kube config default cluster if they are not set.
+func (d *domain) Store(s any) (korrel8r.Store, error) {
+	var cs kconfig.Store
+	if s != nil {
+		var err error
+		if cs, err = impl.TypeAssert[kconfig.Store](s); err != nil {
+			return nil, err
+		}
+	}
+	return d.NewStoreForConfig(cs)
+}
+
+// NewStoreForConfig creates a store from store configuration, see [GetStoreConfig].
+//
+// If the store has an `auth` mode, API discovery still uses the store's own credentials.
+// Discovery is not specific to the caller, and is done outside of any caller's request.
+func (d *domain) NewStoreForConfig(cs kconfig.Store) (*Store, error) {
+	cfg, err := GetStoreConfig(cs)
+	if err != nil {
+		return nil, err
+	}
+	if cs[kconfig.StoreKeyAuth] == "" {
+		return d.NewStore(nil, cfg)
+	}
+	dcs := maps.Clone(cs)
+	delete(dcs, kconfig.StoreKeyAuth)
+	dcfg, err := GetStoreConfig(dcs)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := rest.HTTPClientFor(dcfg)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(dcfg, hc)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.NewWithWatch(cfg, client.Options{Mapper: mapper})
+	if err != nil {
+		return nil, err
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(dcfg)
+	if err != nil {
+		return nil, err
+	}
+	return d.NewStoreWithDiscovery(c, cfg, dc)
+}
 
 // classRE regexp matching for KIND[.VERSION][.GROUP]
 var classRE = regexp.MustCompile(`^([^./]+)(?:\.(v[0-9]+(?:(?:alpha|beta)[0-9]*)?))?(?:\.([^/]*))?$`)
@@ -240,13 +302,99 @@ func (c Class) ID(o korrel8r.Object) any {
 	return nil
 }
 
+func (c Class) Namespace(o korrel8r.Object) string {
+	if o, _ := o.(Object); o != nil {
+		return ToUnstructured(o).GetNamespace()
+	}
+	return ""
+}
+
 func (c Class) Preview(o korrel8r.Object) string {
 	switch o := o.(type) {
 	case *corev1.Event:
 		return o.Message
-	default:
-		return fmt.Sprintf("%v", c.ID(o))
+	case Object:
+		if c.Kind == "Event" {
+			if msg := cmp.Or(stringField(o, "message"), stringField(o, "note")); msg != "" {
+				return msg
+			}
+		}
+	}
+	return fmt.Sprintf("%v", c.ID(o))
+}
+
+// eventTimeFields are the fields of core and events.k8s.io Events that may hold the time of the event,
+// in order of preference: the latest occurrence first.
+var eventTimeFields = [][]string{
+	{"series", "lastObservedTime"},
+	{"lastTimestamp"},
+	{"deprecatedLastTimestamp"},
+	{"eventTime"},
+	{"firstTimestamp"},
+	{"deprecatedFirstTimestamp"},
+	{"metadata", "creationTimestamp"},
+}
+
+// Time returns the time of the latest occurrence of an Event.
+// Other kinds of resource have no time.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, _ := o.(Object); o != nil && c.Kind == "Event" {
+		for _, f := range eventTimeFields {
+			if t, err := time.Parse(time.RFC3339Nano, stringField(o, f...)); err == nil {
+				return t
+			}
+		}
 	}
+	return time.Time{}
+}
+
+// compactFields are the fields of the compact form of all resources.
+var compactFields = []string{
+	"apiVersion",
+	"kind",
+	"metadata.name",
+	"metadata.namespace",
+	"metadata.labels",
+	"metadata.ownerReferences[*].kind",
+	"metadata.ownerReferences[*].name",
+	"metadata.creationTimestamp",
+	"status.phase",
+	"status.conditions[*].type",
+	"status.conditions[*].status",
+	"status.conditions[*].reason",
+	"status.conditions[*].message",
+}
+
+// compactKindFields are additional fields of the compact form for some kinds of resource.
+var compactKindFields = map[string][]string{
+	"Pod": {
+		"spec.nodeName",
+		"status.containerStatuses[*].name",
+		"status.containerStatuses[*].ready",
+		"status.containerStatuses[*].restartCount",
+		"status.containerStatuses[*].state",
+		"status.containerStatuses[*].lastState",
+	},
+	"Event": {
+		"type", "reason", "message", "note", "count",
+		"involvedObject", "regarding",
+		"lastTimestamp", "eventTime", "series.lastObservedTime",
+	},
+	"Deployment":  {"spec.replicas", "status.replicas", "status.readyReplicas", "status.availableReplicas"},
+	"StatefulSet": {"spec.replicas", "status.replicas", "status.readyReplicas"},
+	"ReplicaSet":  {"spec.replicas", "status.replicas", "status.readyReplicas"},
+	"DaemonSet":   {"status.desiredNumberScheduled", "status.numberReady", "status.numberUnavailable"},
+}
+
+// CompactFields keeps the identity, labels, owners and status conditions of a resource,
+// with some extra fields for common kinds.
+func (c Class) CompactFields() []string {
+	return slices.Concat(compactFields, compactKindFields[c.Kind])
+}
+
+func stringField(o Object, fields ...string) string {
+	s, _, _ := unstructured.NestedString(o, fields...)
+	return s
 }
 
 func (c Class) Domain() korrel8r.Domain      { return Domain }
@@ -5,10 +5,13 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,8 +199,115 @@ func TestDomain_DefaultClasses(t *testing.T) {
 	assert.Subset(t, Domain.Classes(), want)
 }
 
+func TestClass_Event(t *testing.T) {
+	event := Class{Version: "v1", Kind: "Event"}
+	newEvent := Class{Group: "events.k8s.io", Version: "v1", Kind: "Event"}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, x := range []struct {
+		class Class
+		o     Object
+	}{
+		{event, Object{"message": "hello", "lastTimestamp": "2024-01-02T03:04:05Z", "firstTimestamp": "2023-01-01T00:00:00Z"}},
+		{event, Object{"message": "hello", "eventTime": "2024-01-02T03:04:05.000000Z", "lastTimestamp": nil}},
+		{newEvent, Object{"note": "hello", "series": map[string]any{"lastObservedTime": "2024-01-02T03:04:05Z"}, "eventTime": "2023-01-01T00:00:00Z"}},
+	} {
+		assert.Equal(t, want, x.class.Time(x.o).UTC(), "%v", x.o)
+		assert.Equal(t, "hello", x.class.Preview(x.o), "%v", x.o)
+	}
+	assert.True(t, pod.Time(Object{"metadata": map[string]any{"creationTimestamp": "2024-01-02T03:04:05Z"}}).IsZero())
+}
+
+func TestClass_Summary(t *testing.T) {
+	for _, x := range []struct {
+		class Class
+		o     string
+		want  map[string]any
+	}{
+		{pod, `{
+  "apiVersion": "v1", "kind": "Pod",
+  "metadata": {"name": "web-0", "namespace": "shop", "creationTimestamp": "2024-01-02T03:04:05Z",
+    "ownerReferences": [{"kind": "StatefulSet", "name": "web", "controller": true}], "managedFields": [{}]},
+  "spec": {"nodeName": "n1", "containers": [{"name": "app"}, {"name": "proxy"}]},
+  "status": {"phase": "Running", "startTime": "2024-01-02T03:04:06Z",
+    "conditions": [{"type": "Ready", "status": "False", "reason": "ContainersNotReady"}, {"type": "PodScheduled", "status": "True"}],
+    "containerStatuses": [
+      {"name": "app", "ready": false, "restartCount": 5,
+       "state": {"waiting": {"reason": "CrashLoopBackOff"}}, "lastState": {"terminated": {"reason": "OOMKilled", "exitCode": 137}}},
+      {"name": "proxy", "ready": true, "restartCount": 0, "state": {"running": {"startedAt": "2024-01-02T03:04:07Z"}}}
+    ]}
+}`, map[string]any{
+			"kind": "Pod", "name": "web-0", "namespace": "shop", "owner": "StatefulSet/web", "created": "2024-01-02T03:04:05Z",
+			"phase": "Running", "conditions": map[string]string{"Ready": "False: ContainersNotReady", "PodScheduled": "True"},
+			"node": "n1", "started": "2024-01-02T03:04:06Z", "ready": "1/2", "restarts": int64(5),
+			"containers": []any{
+				map[string]any{"name": "app", "restarts": int64(5), "state": "waiting: CrashLoopBackOff", "lastState": "terminated: OOMKilled (exit 137)"},
+				map[string]any{"name": "proxy", "restarts": int64(0), "state": "running"},
+			},
+		}},
+		{deployment, `{
+  "apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "web", "namespace": "shop"},
+  "spec": {"replicas": 3}, "status": {"replicas": 3, "readyReplicas": 2, "updatedReplicas": 3}
+}`, map[string]any{
+			"kind": "Deployment", "name": "web", "namespace": "shop",
+			"desired": int64(3), "replicas": int64(3), "readyReplicas": int64(2), "availableReplicas": int64(0), "updatedReplicas": int64(3),
+		}},
+		{Class{Version: "v1", Kind: "Event"}, `{
+  "apiVersion": "v1", "kind": "Event", "metadata": {"name": "e1", "namespace": "shop"},
+  "type": "Warning", "reason": "BackOff", "message": "Back-off restarting failed container", "count": 12,
+  "involvedObject": {"kind": "Pod", "namespace": "shop", "name": "web-0"}, "lastTimestamp": "2024-01-02T03:04:05Z"
+}`, map[string]any{
+			"kind": "Event", "name": "e1", "namespace": "shop",
+			"type": "Warning", "reason": "BackOff", "message": "Back-off restarting failed container", "count": int64(12),
+			"object": "Pod/shop/web-0", "time": "2024-01-02T03:04:05Z",
+		}},
+		{namespace, `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "shop"}, "status": {"phase": "Active"}}`,
+			map[string]any{"kind": "Namespace", "name": "shop", "phase": "Active"}},
+	} {
+		t.Run(x.class.Kind, func(t *testing.T) {
+			o, err := x.class.Unmarshal([]byte(x.o))
+			require.NoError(t, err)
+			assert.Equal(t, x.want, x.class.Summary(o))
+		})
+	}
+	assert.Nil(t, pod.Summary("not an object"))
+}
+
 func TestClass_DefaultNamespaceed(t *testing.T) {
 	assert.False(t, namespace.Namespaced())
 	assert.True(t, deployment.Namespaced())
 	assert.True(t, pod.Namespaced())
 }
+
+func TestGetStoreConfig(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: east
+  cluster: {server: "https://east.example.com:6443"}
+- name: west
+  cluster: {server: "https://west.example.com:6443"}
+users:
+- name: admin
+  user: {token: "xxx"}
+contexts:
+- name: east
+  context: {cluster: east, user: admin}
+- name: west
+  context: {cluster: west, user: admin}
+current-context: east
+`), 0o600))
+	for _, x := range []struct{ context, want string }{
+		{"", "https://east.example.com:6443"},
+		{"west", "https://west.example.com:6443"},
+	} {
+		t.Run(x.context, func(t *testing.T) {
+			cfg, err := GetStoreConfig(config.Store{config.StoreKeyKubeconfig: kubeconfig, config.StoreKeyContext: x.context})
+			require.NoError(t, err)
+			assert.Equal(t, x.want, cfg.Host)
+		})
+	}
+	_, err := GetStoreConfig(config.Store{config.StoreKeyKubeconfig: kubeconfig, config.StoreKeyContext: "nonesuch"})
+	assert.ErrorContains(t, err, `context "nonesuch" does not exist`)
+}
@@ -0,0 +1,215 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package k8s
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Summary has the identity, owner, creation time, phase and status conditions of a resource,
+// with health indicators for common kinds, see kindSummaries.
+func (c Class) Summary(o korrel8r.Object) map[string]any {
+	u, _ := o.(Object)
+	if u == nil {
+		return nil
+	}
+	s := map[string]any{"kind": c.Kind, "name": stringField(u, "metadata", "name")}
+	putString(s, "namespace", stringField(u, "metadata", "namespace"))
+	putString(s, "owner", owner(u))
+	putString(s, "created", stringField(u, "metadata", "creationTimestamp"))
+	putString(s, "phase", stringField(u, "status", "phase"))
+	if conditions := conditions(u); len(conditions) > 0 {
+		s["conditions"] = conditions
+	}
+	if f := kindSummaries[c.Kind]; f != nil {
+		f(c, u, s)
+	}
+	return s
+}
+
+// kindSummaries add health indicators for some kinds of resource to a summary.
+var kindSummaries = map[string]func(Class, Object, map[string]any){
+	"Pod":         podSummary,
+	"Deployment":  replicaSummary("replicas", "readyReplicas", "availableReplicas", "updatedReplicas"),
+	"StatefulSet": replicaSummary("replicas", "readyReplicas", "updatedReplicas"),
+	"ReplicaSet":  replicaSummary("replicas", "readyReplicas", "availableReplicas"),
+	"DaemonSet":   replicaSummary("desiredNumberScheduled", "numberReady", "numberUnavailable", "numberMisscheduled"),
+	"Job":         jobSummary,
+	"Node":        nodeSummary,
+	"Event":       eventSummary,
+}
+
+func podSummary(_ Class, u Object, s map[string]any) {
+	putString(s, "node", stringField(u, "spec", "nodeName"))
+	putString(s, "reason", stringField(u, "status", "reason"))
+	putString(s, "message", stringField(u, "status", "message"))
+	putString(s, "started", stringField(u, "status", "startTime"))
+	specContainers, _, _ := unstructured.NestedSlice(u, "spec", "containers")
+	statuses, _, _ := unstructured.NestedSlice(u, "status", "containerStatuses")
+	var ready, restarts int64
+	var containers []any
+	for _, cs := range statuses {
+		cs, _ := cs.(map[string]any)
+		if cs == nil {
+			continue
+		}
+		if ok, _ := cs["ready"].(bool); ok {
+			ready++
+		}
+		n, _ := intValue(cs["restartCount"])
+		restarts += n
+		summary := map[string]any{"name": cs["name"], "restarts": n}
+		putString(summary, "state", containerState(cs["state"]))
+		putString(summary, "lastState", containerState(cs["lastState"]))
+		containers = append(containers, summary)
+	}
+	s["ready"] = fmt.Sprintf("%v/%v", ready, max(len(specContainers), len(statuses)))
+	s["restarts"] = restarts
+	if len(containers) > 0 {
+		s["containers"] = containers
+	}
+}
+
+// containerState summarizes a container state as "running", "waiting: REASON" or "terminated: REASON (exit CODE)".
+func containerState(v any) string {
+	state, _ := v.(map[string]any)
+	for _, name := range []string{"waiting", "terminated", "running"} {
+		details, ok := state[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		reason, _ := details["reason"].(string)
+		if code, ok := intValue(details["exitCode"]); ok {
+			reason = strings.TrimSpace(fmt.Sprintf("%v (exit %v)", reason, code))
+		}
+		if reason == "" {
+			return name
+		}
+		return name + ": " + reason
+	}
+	return ""
+}
+
+// replicaSummary adds spec.replicas as "desired" if present, and status counts, which are 0 if missing.
+func replicaSummary(counts ...string) func(Class, Object, map[string]any) {
+	return func(_ Class, u Object, s map[string]any) {
+		if n, ok := intField(u, "spec", "replicas"); ok {
+			s["desired"] = n
+		}
+		for _, f := range counts {
+			n, _ := intField(u, "status", f)
+			s[f] = n
+		}
+	}
+}
+
+func jobSummary(_ Class, u Object, s map[string]any) {
+	for _, f := range []string{"active", "succeeded", "failed"} {
+		n, _ := intField(u, "status", f)
+		s[f] = n
+	}
+	putString(s, "started", stringField(u, "status", "startTime"))
+	putString(s, "completed", stringField(u, "status", "completionTime"))
+}
+
+func nodeSummary(_ Class, u Object, s map[string]any) {
+	if unschedulable, _, _ := unstructured.NestedBool(u, "spec", "unschedulable"); unschedulable {
+		s["unschedulable"] = true
+	}
+	putString(s, "kubeletVersion", stringField(u, "status", "nodeInfo", "kubeletVersion"))
+}
+
+func eventSummary(c Class, u Object, s map[string]any) {
+	putString(s, "type", stringField(u, "type"))
+	putString(s, "reason", stringField(u, "reason"))
+	putString(s, "message", cmp.Or(stringField(u, "message"), stringField(u, "note")))
+	for _, f := range [][]string{{"series", "count"}, {"count"}} {
+		if n, ok := intField(u, f...); ok {
+			s["count"] = n
+			break
+		}
+	}
+	for _, f := range []string{"involvedObject", "regarding"} {
+		if ref, ok := u[f].(map[string]any); ok {
+			kind, _ := ref["kind"].(string)
+			name, _ := ref["name"].(string)
+			namespace, _ := ref["namespace"].(string)
+			s["object"] = kind + "/" + strings.TrimPrefix(namespace+"/"+name, "/")
+			break
+		}
+	}
+	if t := c.Time(u); !t.IsZero() {
+		s["time"] = t.Format(time.RFC3339)
+	}
+}
+
+// owner returns "KIND/NAME" of the controller owner, or the first owner if there is no controller.
+func owner(u Object) string {
+	refs, _, _ := unstructured.NestedSlice(u, "metadata", "ownerReferences")
+	var found map[string]any
+	for _, ref := range refs {
+		if ref, ok := ref.(map[string]any); ok {
+			if controller, _ := ref["controller"].(bool); controller || found == nil {
+				found = ref
+			}
+		}
+	}
+	if found == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v/%v", found["kind"], found["name"])
+}
+
+// conditions maps condition types to "STATUS" or "STATUS: REASON".
+func conditions(u Object) map[string]string {
+	list, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	conditions := map[string]string{}
+	for _, c := range list {
+		c, _ := c.(map[string]any)
+		t, _ := c["type"].(string)
+		status, _ := c["status"].(string)
+		if t == "" {
+			continue
+		}
+		if reason, _ := c["reason"].(string); reason != "" {
+			status += ": " + reason
+		}
+		conditions[t] = status
+	}
+	return conditions
+}
+
+func intField(o Object, fields ...string) (int64, bool) {
+	v, ok, _ := unstructured.NestedFieldNoCopy(o, fields...)
+	if !ok {
+		return 0, false
+	}
+	return intValue(v)
+}
+
+// intValue converts a number decoded from JSON or YAML.
+func intValue(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func putString(m map[string]any, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
@@ -3,6 +3,9 @@
 package k8s
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/rhobs/kube-health/pkg/analyze"
 	khstatus "github.com/rhobs/kube-health/pkg/status"
@@ -23,6 +26,10 @@ import (
 //		Returns "Error", "Warning", or "" for healthy/unknown objects.
 //		Analyzes observed generation and standard Kubernetes conditions.
 //
+//	k8sContainerStatus
+//		Takes a k8s Object, returns the failure reasons of a Pod and its containers, one per line.
+//		For example "CrashLoopBackOff", "OOMKilled", "ImagePullBackOff" or "Evicted".
+//
 //	k8sCRDName
 //	    Takes string arguments (apiVersion, kind).
 //	    Returns the CustomResourceDefinition name (plural.group) for the resource,
@@ -36,8 +43,9 @@ func (d *domain) TemplateFuncs() map[string]any {
 			kc, ok := c.(Class)
 			return ok && kc.Namespaced()
 		},
-		"k8sHealthStatus": HealthStatus,
-		"k8sCRDName":      func(apiVersion, kind string) string { return d.CRDName(apiVersion, kind) },
+		"k8sHealthStatus":    HealthStatus,
+		"k8sContainerStatus": func(o Object) string { return strings.Join(ContainerStatus(o), "\n") },
+		"k8sCRDName":         func(apiVersion, kind string) string { return d.CRDName(apiVersion, kind) },
 	}
 }
 
@@ -86,3 +94,39 @@ func HealthStatus(o Object) string {
 		return ""
 	}
 }
+
+// failureReasons are the container and pod state reasons reported by [ContainerStatus].
+var failureReasons = []string{
+	"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName",
+	"CreateContainerConfigError", "CreateContainerError", "RunContainerError",
+	"OOMKilled", "ContainerCannotRun", "DeadlineExceeded", "Evicted",
+}
+
+// ContainerStatus returns the distinct failure reasons of a Pod and its containers.
+// It looks at the pod status reason, and the current and last state of regular and init containers.
+// Returns nil for objects that are not pods, or have no failures.
+func ContainerStatus(o Object) (reasons []string) {
+	add := func(reason string) {
+		if slices.Contains(failureReasons, reason) && !slices.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	status, _ := o["status"].(map[string]any)
+	reason, _ := status["reason"].(string)
+	add(reason)
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		containers, _ := status[field].([]any)
+		for _, c := range containers {
+			c, _ := c.(map[string]any)
+			for _, state := range []string{"state", "lastState"} {
+				s, _ := c[state].(map[string]any)
+				for _, phase := range []string{"waiting", "terminated"} {
+					p, _ := s[phase].(map[string]any)
+					reason, _ := p["reason"].(string)
+					add(reason)
+				}
+			}
+		}
+	}
+	return reasons
+}
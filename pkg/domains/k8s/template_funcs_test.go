@@ -85,3 +85,39 @@ func TestK8sHealthStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerStatus(t *testing.T) {
+	waiting := func(reason string) Object { return Object{"waiting": Object{"reason": reason}} }
+	terminated := func(reason string) Object { return Object{"terminated": Object{"reason": reason}} }
+	for _, tt := range []struct {
+		name   string
+		status Object
+		want   []string
+	}{
+		{name: "no status"},
+		{name: "running", status: Object{"containerStatuses": []any{Object{"state": Object{"running": Object{}}}}}},
+		{
+			name: "crash loop",
+			status: Object{"containerStatuses": []any{
+				Object{"state": waiting("CrashLoopBackOff"), "lastState": terminated("OOMKilled")},
+				Object{"state": waiting("CrashLoopBackOff"), "lastState": terminated("Error")},
+			}},
+			want: []string{"CrashLoopBackOff", "OOMKilled"},
+		},
+		{
+			name:   "init container",
+			status: Object{"initContainerStatuses": []any{Object{"state": waiting("ImagePullBackOff")}}},
+			want:   []string{"ImagePullBackOff"},
+		},
+		{name: "evicted", status: Object{"phase": "Failed", "reason": "Evicted"}, want: []string{"Evicted"}},
+		{name: "not a failure", status: Object{"containerStatuses": []any{Object{"state": waiting("ContainerCreating")}}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Object{"apiVersion": "v1", "kind": "Pod", "metadata": Object{"name": "p", "namespace": "ns"}}
+			if tt.status != nil {
+				o["status"] = tt.status
+			}
+			assert.Equal(t, tt.want, ContainerStatus(o))
+		})
+	}
+}
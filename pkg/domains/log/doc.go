@@ -79,6 +79,26 @@
 //
 // Korrel8r will be updated to use OTEL directly in future.
 //
+// # Patterns
+//
+// Large log results can be summarized as patterns: templates of similar log lines
+// with the parts that vary replaced by "<*>".
+// Each pattern has the number of matching lines, the time of the first and last line, and an example line.
+// Patterns are found using a simplified form of the [Drain] algorithm.
+//
+// Use the "patterns" graph option with the REST API, or --patterns on the command line,
+// to include patterns in log nodes of a graph. MCP graph tools always include patterns.
+// The logPattern template function returns the pattern of a single log line,
+// it can be used in status rules to label log lines with their pattern.
+//
+// # Counting
+//
+// Logs can be counted grouped by field values instead of being returned, using the "groupBy" parameter of the
+// REST /objects API or --group-by on the command line.
+// Loki stores count in the backend with a LogQL metric query, so all matching logs are counted, not just the limit.
+// Stream labels, structured metadata and JSON fields can be counted in the backend, for example "level" or "kubernetes_container_name".
+// The body and timestamp fields are counted in memory.
+//
 // # Store Configuration
 //
 //	domain: log
@@ -92,4 +112,5 @@
 // At least one of lokiStack and direct must be set.
 //
 // [LogQL]: https://grafana.com/docs/loki/latest/query
+// [Drain]: https://jiemingzhu.github.io/pub/pjhe_icws2017.pdf
 package log
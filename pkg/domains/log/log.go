@@ -3,6 +3,7 @@
 package log
 
 import (
+	"cmp"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -41,7 +42,7 @@ func (*domain) Store(s any) (korrel8r.Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	ks, err := k8s.Domain.NewStore(nil, nil)
+	ks, err := k8s.Domain.NewStoreForConfig(cs)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +101,61 @@ func (c Class) String() string                              { return korrel8r.Cl
 func (c Class) Unmarshal(b []byte) (korrel8r.Object, error) { return impl.UnmarshalAs[Object](b) }
 func (c Class) Preview(o korrel8r.Object) (line string)     { return Preview(o) }
 
+// CompactFields keeps the log body, time, level and source container.
+func (c Class) CompactFields() []string {
+	return []string{
+		AttrBody, AttrTimestamp, "level",
+		AttrK8sNamespaceName, AttrK8sPodName, AttrK8sContainerName,
+		AttrKubernetesNamespaceName, AttrKubernetesPodName, AttrKubernetesContainerName,
+	}
+}
+
+// summaryBodyLen is the maximum length of the body in a summary, longer bodies are truncated.
+const summaryBodyLen = 500
+
+// Summary has the time, level, source container and body of a log, the body is truncated if it is very long.
+func (c Class) Summary(o korrel8r.Object) map[string]any {
+	l, _ := o.(Object)
+	if l == nil {
+		return nil
+	}
+	s := map[string]any{}
+	if t := c.Time(l); !t.IsZero() {
+		s["time"] = t
+	}
+	for k, v := range map[string]string{
+		"level":     l["level"],
+		"namespace": cmp.Or(l[AttrK8sNamespaceName], l[AttrKubernetesNamespaceName]),
+		"pod":       cmp.Or(l[AttrK8sPodName], l[AttrKubernetesPodName]),
+		"container": cmp.Or(l[AttrK8sContainerName], l[AttrKubernetesContainerName]),
+	} {
+		if v != "" {
+			s[k] = v
+		}
+	}
+	body := cmp.Or(l[AttrBody], l[AttrMessage])
+	if r := []rune(body); len(r) > summaryBodyLen {
+		body = string(r[:summaryBodyLen]) + "..."
+	}
+	s["body"] = body
+	return s
+}
+
+// Time returns the log timestamp, or the observed timestamp if there is none.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, _ := o.(Object); o != nil {
+		t, _ := o.SortTime()
+		return t
+	}
+	return time.Time{}
+}
+func (c Class) Namespace(o korrel8r.Object) string {
+	if o, _ := o.(Object); o != nil {
+		return cmp.Or(o[AttrK8sNamespaceName], o[AttrKubernetesNamespaceName])
+	}
+	return ""
+}
+
 func Preview(x korrel8r.Object) string {
 	if o, _ := x.(Object); o != nil {
 		return o[AttrBody]
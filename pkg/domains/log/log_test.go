@@ -4,6 +4,7 @@ package log
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -132,6 +133,14 @@ func TestClassPreview(t *testing.T) {
 	})
 }
 
+func TestClassTime(t *testing.T) {
+	want := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, want, Application.Time(Object{AttrTimestamp: "2023-01-01T00:00:00Z", AttrObservedTimestamp: "2024-01-01T00:00:00Z"}))
+	assert.Equal(t, want, Application.Time(Object{AttrObservedTimestamp: "2023-01-01T00:00:00Z"}))
+	assert.True(t, Application.Time(Object{AttrBody: "no time"}).IsZero())
+	assert.True(t, Application.Time("not an object").IsZero())
+}
+
 func TestNewObject(t *testing.T) {
 	testTime := time.Now()
 
@@ -450,3 +459,17 @@ func TestContainerSelector_IsContainerSelected(t *testing.T) {
 		})
 	}
 }
+
+func TestClassSummary(t *testing.T) {
+	o := Object{
+		AttrTimestamp: "2023-01-01T00:00:00Z", AttrBody: "failed to connect", "level": "error",
+		AttrK8sNamespaceName: "ns", AttrK8sPodName: "pod", AttrK8sContainerName: "app", "extra": "dropped",
+	}
+	assert.Equal(t, map[string]any{
+		"time": time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "body": "failed to connect", "level": "error",
+		"namespace": "ns", "pod": "pod", "container": "app",
+	}, Application.Summary(o))
+	long := Application.Summary(Object{AttrBody: strings.Repeat("x", summaryBodyLen+10)})
+	assert.Equal(t, strings.Repeat("x", summaryBodyLen)+"...", long["body"])
+	assert.Nil(t, Application.Summary("not an object"))
+}
@@ -4,6 +4,7 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"maps"
 	"net/http"
 	"net/url"
@@ -34,6 +35,15 @@ func (s *lokiStore) Get(ctx context.Context, query korrel8r.Query, constraint *k
 	return s.Client.Get(ctx, parseJSON(q.logQL), constraint, func(l *loki.Log) { r.Append(newObject(l)) })
 }
 
+// Aggregate counts logs in Loki, grouped by labels, see [loki.Client.Count].
+func (s *lokiStore) Aggregate(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	q, err := aggregateQuery(query, fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.Count(ctx, parseJSON(q.logQL), fields, constraint)
+}
+
 type lokiStackStore struct{ *lokiStore }
 
 // NewLokiStackStore returns a store that uses a LokiStack observatorium-style URLs.
@@ -50,6 +60,31 @@ func (s *lokiStackStore) Get(ctx context.Context, query korrel8r.Query, constrai
 	return s.GetStack(ctx, parseJSON(q.logQL), string(q.class), constraint, func(l *loki.Log) { r.Append(newObject(l)) })
 }
 
+// Aggregate counts logs in the LokiStack tenant for the query class, grouped by labels.
+func (s *lokiStackStore) Aggregate(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	q, err := aggregateQuery(query, fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.CountStack(ctx, parseJSON(q.logQL), string(q.class), fields, constraint)
+}
+
+// aggregateQuery returns the query if the fields can be counted by Loki.
+// Fields computed from the log line or timestamp are not Loki labels.
+func aggregateQuery(query korrel8r.Query, fields []string) (*Query, error) {
+	q, ok := query.(*Query)
+	if !ok {
+		return nil, korrel8r.ErrNotAggregated
+	}
+	for _, f := range fields {
+		switch f {
+		case AttrBody, AttrTimestamp, AttrObservedTimestamp:
+			return nil, fmt.Errorf("%w: %q is not a Loki label", korrel8r.ErrNotAggregated, f)
+		}
+	}
+	return q, nil
+}
+
 var jsonRE = regexp.MustCompile(`\|\s*json\b`)
 
 func parseJSON(logql string) string {
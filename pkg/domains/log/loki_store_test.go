@@ -0,0 +1,38 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package log
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLokiStackStore_Aggregate(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query().Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"level":"error"},"value":[0,"7"]}]}}`))
+	}))
+	defer server.Close()
+	base, _ := url.Parse(server.URL)
+	s := NewLokiStackStore(base, server.Client()).(korrel8r.Aggregator)
+	q, err := NewQuery(`log:application:{kubernetes_namespace_name="demo"}`)
+	require.NoError(t, err)
+
+	groups, err := s.Aggregate(context.Background(), q, nil, []string{"level"})
+	require.NoError(t, err)
+	assert.Equal(t, []korrel8r.Group{{Fields: map[string]string{"level": "error"}, Count: 7}}, groups)
+	assert.Equal(t, "/api/logs/v1/application/loki/api/v1/query", path)
+	assert.Contains(t, query, `sum by (level) (count_over_time({kubernetes_namespace_name="demo"}|json [`)
+
+	_, err = s.Aggregate(context.Background(), q, nil, []string{AttrBody})
+	assert.ErrorIs(t, err, korrel8r.ErrNotAggregated)
+}
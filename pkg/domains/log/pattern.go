@@ -0,0 +1,148 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package log
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Wildcard replaces variable tokens in a pattern template.
+const Wildcard = "<*>"
+
+// PatternSimilarity is the fraction of matching tokens needed to add a line to an existing pattern.
+const PatternSimilarity = 0.5
+
+// patternPrefix is the number of leading tokens that must match exactly, unless they are variable.
+// This is the Drain parse tree depth, less the token count level.
+const patternPrefix = 1
+
+// Pattern is a template for similar log lines, with variable tokens replaced by [Wildcard].
+type Pattern struct {
+	// Template of the log lines, tokens that vary between lines are replaced by [Wildcard].
+	Template string `json:"template"`
+	// Count of log lines that match the pattern.
+	Count int `json:"count"`
+	// First and Last are the earliest and latest times of the lines, zero if the lines have no time.
+	First time.Time `json:"first,omitzero"`
+	Last  time.Time `json:"last,omitzero"`
+	// Example is the first line added to the pattern.
+	Example string `json:"example"`
+}
+
+// Patterns clusters log lines into patterns using a simplified [Drain] algorithm.
+//
+// Tokens containing digits are treated as variable. Lines are grouped by token count and leading tokens,
+// then added to the most similar pattern in the group, or start a new pattern.
+// Tokens of a pattern that differ from an added line become [Wildcard].
+//
+// [Drain]: https://jiemingzhu.github.io/pub/pjhe_icws2017.pdf
+type Patterns struct {
+	groups   map[string][]*patternCluster
+	clusters []*patternCluster
+}
+
+type patternCluster struct {
+	tokens []string
+	Pattern
+}
+
+// NewPatterns returns an empty set of patterns.
+func NewPatterns() *Patterns { return &Patterns{groups: map[string][]*patternCluster{}} }
+
+// Add a log line with time t, which may be zero.
+func (p *Patterns) Add(line string, t time.Time) {
+	tokens := maskTokens(strings.Fields(line))
+	key := fmt.Sprint(len(tokens), tokens[:min(len(tokens), patternPrefix)])
+	var best *patternCluster
+	bestSim := -1.0
+	for _, c := range p.groups[key] {
+		if sim := similarity(c.tokens, tokens); sim > bestSim {
+			best, bestSim = c, sim
+		}
+	}
+	if best == nil || bestSim < PatternSimilarity {
+		best = &patternCluster{tokens: tokens, Pattern: Pattern{Example: line}}
+		p.groups[key] = append(p.groups[key], best)
+		p.clusters = append(p.clusters, best)
+	} else {
+		for i, tok := range tokens {
+			if best.tokens[i] != tok {
+				best.tokens[i] = Wildcard
+			}
+		}
+	}
+	best.Count++
+	if !t.IsZero() {
+		if best.First.IsZero() || t.Before(best.First) {
+			best.First = t
+		}
+		if t.After(best.Last) {
+			best.Last = t
+		}
+	}
+}
+
+// List returns the patterns, most frequent first.
+func (p *Patterns) List() []Pattern {
+	patterns := make([]Pattern, 0, len(p.clusters))
+	for _, c := range p.clusters {
+		c.Template = strings.Join(c.tokens, " ")
+		patterns = append(patterns, c.Pattern)
+	}
+	slices.SortStableFunc(patterns, func(a, b Pattern) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), a.First.Compare(b.First))
+	})
+	return patterns
+}
+
+// FindPatterns returns the patterns of the bodies of log objects, most frequent first.
+// Objects that are not log objects are ignored.
+func FindPatterns(objects []korrel8r.Object) []Pattern {
+	p := NewPatterns()
+	for _, o := range objects {
+		if o, ok := o.(Object); ok {
+			t, _ := o.SortTime()
+			p.Add(o.Body(), t)
+		}
+	}
+	return p.List()
+}
+
+// Mask returns the template of a single log line: tokens containing digits are replaced by [Wildcard].
+// The value of a "key=value" token is replaced, not the key.
+func Mask(line string) string { return strings.Join(maskTokens(strings.Fields(line)), " ") }
+
+func maskTokens(tokens []string) []string {
+	for i, tok := range tokens {
+		if !strings.ContainsFunc(tok, unicode.IsDigit) {
+			continue
+		}
+		if k, _, ok := strings.Cut(tok, "="); ok && k != "" && !strings.ContainsFunc(k, unicode.IsDigit) {
+			tokens[i] = k + "=" + Wildcard
+		} else {
+			tokens[i] = Wildcard
+		}
+	}
+	return tokens
+}
+
+// similarity is the fraction of tokens in a line that match the pattern, wildcards in the pattern match any token.
+func similarity(pattern, tokens []string) float64 {
+	if len(tokens) == 0 {
+		return 1
+	}
+	var n int
+	for i, tok := range tokens {
+		if pattern[i] == tok || pattern[i] == Wildcard {
+			n++
+		}
+	}
+	return float64(n) / float64(len(tokens))
+}
@@ -0,0 +1,67 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	for _, x := range [][2]string{
+		{"connected to 10.0.0.1:8080 in 35ms", "connected to <*> in <*>"},
+		{"retry attempt=3 user=bob", "retry attempt=<*> user=bob"},
+		{"pod web-7d4b9c-x2x9z ready", "pod <*> ready"},
+		{"  no   digits here ", "no digits here"},
+		{"", ""},
+	} {
+		t.Run(x[0], func(t *testing.T) { assert.Equal(t, x[1], Mask(x[0])) })
+	}
+}
+
+func TestFindPatterns(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	line := func(body string, min int) korrel8r.Object {
+		return Object{AttrBody: body, AttrTimestamp: t0.Add(time.Duration(min) * time.Minute).Format(time.RFC3339Nano)}
+	}
+	got := FindPatterns([]korrel8r.Object{
+		line("GET /api/users status=200 took 12ms", 2),
+		line("failed to connect to database: connection refused", 1),
+		line("GET /api/orders status=500 took 40ms", 0),
+		line("GET /api/users status=200 took 9ms", 5),
+		line("failed to connect to cache: connection refused", 3),
+		line("starting server", 4),
+		"not a log object",
+	})
+	assert.Equal(t, []Pattern{
+		{Template: "GET <*> status=<*> took <*>", Count: 3, First: t0, Last: t0.Add(5 * time.Minute), Example: "GET /api/users status=200 took 12ms"},
+		{Template: "failed to connect to <*> connection refused", Count: 2, First: t0.Add(time.Minute), Last: t0.Add(3 * time.Minute), Example: "failed to connect to database: connection refused"},
+		{Template: "starting server", Count: 1, First: t0.Add(4 * time.Minute), Last: t0.Add(4 * time.Minute), Example: "starting server"},
+	}, got)
+}
+
+func TestPatterns_dissimilar(t *testing.T) {
+	p := NewPatterns()
+	p.Add("user alice logged in from web", time.Time{})
+	p.Add("user bob deleted a file now", time.Time{})
+	p.Add("user carol logged in from web", time.Time{})
+	got := p.List()
+	require.Len(t, got, 2)
+	assert.Equal(t, Pattern{Template: "user <*> logged in from web", Count: 2, Example: "user alice logged in from web"}, got[0])
+	assert.Equal(t, "user bob deleted a file now", got[1].Template)
+	assert.True(t, got[1].First.IsZero())
+}
+
+func TestLinePattern(t *testing.T) {
+	for _, v := range []any{"took 12ms", Object{AttrBody: "took 12ms"}, map[string]string{AttrBody: "took 12ms"}} {
+		got, err := LinePattern(v)
+		require.NoError(t, err)
+		assert.Equal(t, "took <*>", got)
+	}
+	_, err := LinePattern(1)
+	assert.Error(t, err)
+}
@@ -19,9 +19,13 @@ import (
 //	logSafeLabels map[string]string
 //	    Returns a map where each key is replaced by the result of logSafeLabel.
 //
+//	logPattern (string|log.Object)
+//	    Returns the pattern of a log line or log object body: tokens containing digits are replaced by "<*>".
+//
 // [LogQL]: https://grafana.com/docs/loki/latest/query
 func (domain) TemplateFuncs() map[string]any {
 	return map[string]any{
+		"logPattern":          LinePattern,
 		"logSafeLabel":        SafeLabel,
 		"logSafeLabels":       SafeLabels,
 		"logTypeForNamespace": TypeForNamespace,
@@ -47,6 +51,20 @@ func SafeLabels(labelMap any) (any, error) {
 	return out.Interface(), nil
 }
 
+// LinePattern returns the pattern of a string, or the body of a log object, see [Mask].
+func LinePattern(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return Mask(v), nil
+	case Object:
+		return Mask(v.Body()), nil
+	case map[string]string:
+		return Mask(v[AttrBody]), nil
+	default:
+		return "", fmt.Errorf("logPattern: expecting string or log object, got %T", v)
+	}
+}
+
 // TypeForNamespace returns the log type ("application" or "infrastructure") for the given namespace.
 func TypeForNamespace(namespace string) string {
 	if infraNamespace.MatchString(namespace) {
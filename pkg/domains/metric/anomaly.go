@@ -0,0 +1,254 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package metric
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/prometheus"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/prometheus/common/model"
+)
+
+// Anomaly statuses.
+const (
+	Spike    = "Spike"    // Values are much higher than the baseline.
+	Drop     = "Drop"     // Values are much lower than the baseline.
+	Flatline = "Flatline" // Values stopped changing at a normal level, the baseline was not constant.
+	Gap      = "Gap"      // Samples are missing, the baseline had samples.
+)
+
+// Anomaly detection parameters.
+const (
+	// ZScoreThreshold is the number of baseline standard deviations for a Spike or Drop.
+	ZScoreThreshold = 3.0
+	// PercentChangeThreshold is the change in mean value, as a percentage of the baseline mean, for a Spike or Drop.
+	PercentChangeThreshold = 50.0
+	// GapRatio is the fraction of expected samples that must be missing for a Gap.
+	GapRatio = 0.5
+	// AnomalyWindow is the detection window if the constraint has no start time.
+	AnomalyWindow = 15 * time.Minute
+	// MaxAnomalySeries is the maximum number of series checked for anomalies by one query.
+	MaxAnomalySeries = 50
+	// AnomalyWorkers is the maximum number of concurrent range queries for anomaly detection in one query.
+	AnomalyWorkers = 4
+	// AnomalyTimeout is the maximum time spent on anomaly detection in one query.
+	// Series that are not checked in time have no [Object.Anomaly].
+	AnomalyTimeout = 10 * time.Second
+	// anomalySteps is the number of steps in a range query for the baseline and window.
+	anomalySteps = 240
+	// minStep is the smallest range query step.
+	minStep = 15 * time.Second
+)
+
+// Anomaly compares the samples of a series in a detection window to a baseline window before it.
+type Anomaly struct {
+	// Statuses are the anomaly signals found: Spike, Drop, Flatline or Gap.
+	Statuses []string `json:"statuses,omitempty"`
+	// Score between 0 (normal) and 1 (very abnormal).
+	Score float64 `json:"score"`
+	// Time of the first abnormal sample, or the start of the window for signals that are not tied to a sample.
+	Time *time.Time `json:"time,omitempty"`
+	// ZScore is the window sample furthest from the baseline mean, in baseline standard deviations.
+	// It is 0 if the baseline is constant.
+	ZScore float64 `json:"zScore"`
+	// PercentChange of the window mean from the baseline mean. It is 0 if the baseline mean is 0.
+	PercentChange float64 `json:"percentChange"`
+	// BaselineMean and WindowMean are the mean sample values.
+	BaselineMean float64 `json:"baselineMean"`
+	WindowMean   float64 `json:"windowMean"`
+}
+
+// Detect compares window samples between start and end to baseline samples, step is the expected sample interval.
+// Returns nil if there are no baseline samples to compare with.
+func Detect(baseline, window []model.SamplePair, start, end time.Time, step time.Duration) *Anomaly {
+	if len(baseline) == 0 {
+		return nil
+	}
+	bMean, bStdDev := meanStdDev(baseline)
+	a := &Anomaly{BaselineMean: bMean}
+	signal := func(status string, score float64, at time.Time) {
+		a.Statuses = append(a.Statuses, status)
+		a.Score = max(a.Score, min(score, 1))
+		if a.Time == nil || at.Before(*a.Time) {
+			a.Time = &at
+		}
+	}
+	if expected := int(end.Sub(start)/step) + 1; step > 0 && float64(len(window)) < float64(expected)*(1-GapRatio) {
+		signal(Gap, 0.5, start)
+	}
+	if len(window) == 0 {
+		return a
+	}
+	a.WindowMean, _ = meanStdDev(window)
+	var direction float64 // Sign of the strongest shift from the baseline.
+	var shiftScore float64
+	var shiftTime time.Time
+	if bStdDev > 0 {
+		for _, s := range window {
+			z := (float64(s.Value) - bMean) / bStdDev
+			if math.Abs(z) > math.Abs(a.ZScore) {
+				a.ZScore = z
+			}
+			if math.Abs(z) >= ZScoreThreshold && shiftTime.IsZero() {
+				shiftTime = s.Timestamp.Time().UTC()
+			}
+		}
+		if math.Abs(a.ZScore) >= ZScoreThreshold {
+			direction, shiftScore = a.ZScore, math.Abs(a.ZScore)/(2*ZScoreThreshold)
+		}
+	}
+	if bMean != 0 {
+		a.PercentChange = (a.WindowMean - bMean) / math.Abs(bMean) * 100
+		if math.Abs(a.PercentChange) >= PercentChangeThreshold {
+			if direction == 0 {
+				direction = a.PercentChange
+			}
+			shiftScore = max(shiftScore, math.Abs(a.PercentChange)/(2*PercentChangeThreshold))
+		}
+	} else if bStdDev == 0 && a.WindowMean != 0 { // Change from a constant zero.
+		direction, shiftScore = a.WindowMean, 1
+	}
+	if direction != 0 {
+		if shiftTime.IsZero() {
+			shiftTime = window[0].Timestamp.Time().UTC()
+		}
+		if direction > 0 {
+			signal(Spike, shiftScore, shiftTime)
+		} else {
+			signal(Drop, shiftScore, shiftTime)
+		}
+	}
+	if direction == 0 && len(window) >= 3 && bStdDev > 0 && flat(window) { // Stuck at a normal level.
+		signal(Flatline, 0.5, window[0].Timestamp.Time().UTC())
+	}
+	return a
+}
+
+func meanStdDev(samples []model.SamplePair) (mean, stdDev float64) {
+	for _, s := range samples {
+		mean += float64(s.Value)
+	}
+	mean /= float64(len(samples))
+	for _, s := range samples {
+		d := float64(s.Value) - mean
+		stdDev += d * d
+	}
+	return mean, math.Sqrt(stdDev / float64(len(samples)))
+}
+
+func flat(samples []model.SamplePair) bool {
+	for _, s := range samples[1:] {
+		if s.Value != samples[0].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Time returns the time of the anomaly, or the zero time if the series is not abnormal.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if o, ok := o.(Object); ok && o.Anomaly != nil && len(o.Anomaly.Statuses) > 0 && o.Anomaly.Time != nil {
+		return *o.Anomaly.Time
+	}
+	return time.Time{}
+}
+
+// Score returns the anomaly score, 0 if anomalies were not checked.
+func (c Class) Score(o korrel8r.Object) float64 {
+	if o, ok := o.(Object); ok && o.Anomaly != nil {
+		return o.Anomaly.Score
+	}
+	return 0
+}
+
+// detectAnomalies sets [Object.Anomaly] for up to [MaxAnomalySeries] objects.
+// Range queries run concurrently, up to [AnomalyWorkers] at a time, and stop after [AnomalyTimeout].
+// Errors are logged and the object is left unchanged, anomalies are extra information for the series.
+func (s *Store) detectAnomalies(ctx context.Context, baseURL *url.URL, objects []Object, c *korrel8r.Constraint) {
+	end := time.Now()
+	if c != nil && c.End != nil && !c.End.IsZero() {
+		end = *c.End
+	}
+	start := end.Add(-AnomalyWindow)
+	if c != nil && c.Start != nil && !c.Start.IsZero() {
+		start = *c.Start
+	}
+	from := start.Add(-s.anomalyBaseline)
+	step := max(end.Sub(from)/anomalySteps, minStep).Round(time.Second)
+	ctx, cancel := context.WithTimeout(ctx, AnomalyTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, AnomalyWorkers)
+	for i := range objects[:min(len(objects), MaxAnomalySeries)] {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			log.V(2).Info("metric anomaly detection stopped", "checked", i, "error", ctx.Err())
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-workers }()
+			o := &objects[i]
+			samples, err := s.queryRange(ctx, baseURL, o.Labels, from, end, step)
+			if err != nil {
+				log.V(2).Info("metric anomaly query failed", "series", o.String(), "error", err)
+				return
+			}
+			var baseline, window []model.SamplePair
+			for _, sp := range samples {
+				if sp.Timestamp.Time().Before(start) {
+					baseline = append(baseline, sp)
+				} else {
+					window = append(window, sp)
+				}
+			}
+			o.Anomaly = Detect(baseline, window, start, end, step)
+		})
+	}
+	wg.Wait()
+}
+
+// queryRange returns the samples of the series with the given labels.
+func (s *Store) queryRange(ctx context.Context, baseURL *url.URL, labels map[string]string, start, end time.Time, step time.Duration) ([]model.SamplePair, error) {
+	m := model.Metric{}
+	for k, v := range labels {
+		m[model.LabelName(k)] = model.LabelValue(v)
+	}
+	q := url.Values{}
+	q.Set("query", m.String())
+	q.Set("start", formatTime(start))
+	q.Set("end", formatTime(end))
+	q.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	if ns := labels["namespace"]; ns != "" {
+		prometheus.AddNamespaceParams(q, map[string]bool{ns: true})
+	}
+	u := baseURL.JoinPath("query_range")
+	u.RawQuery = q.Encode()
+	var r struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result model.Matrix `json:"result"`
+		} `json:"data"`
+	}
+	if err := impl.Get(ctx, u, s.Client, &r); err != nil {
+		return nil, err
+	}
+	if r.Status != "success" {
+		return nil, fmt.Errorf("GET %v: unexpected status: %v", u, r.Status)
+	}
+	var samples []model.SamplePair
+	for _, ss := range r.Data.Result {
+		samples = append(samples, ss.Values...)
+	}
+	return samples, nil
+}
@@ -0,0 +1,258 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package metric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var t0 = time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+
+// samples returns one sample per minute from t0+from, with values from f.
+func samples(from time.Duration, n int, f func(i int) float64) []model.SamplePair {
+	var s []model.SamplePair
+	for i := range n {
+		s = append(s, model.SamplePair{Timestamp: model.TimeFromUnixNano(t0.Add(from + time.Duration(i)*time.Minute).UnixNano()), Value: model.SampleValue(f(i))})
+	}
+	return s
+}
+
+func alternate(a, b float64) func(int) float64 {
+	return func(i int) float64 {
+		if i%2 == 0 {
+			return a
+		}
+		return b
+	}
+}
+
+func constant(v float64) func(int) float64 { return func(int) float64 { return v } }
+
+func TestDetect(t *testing.T) {
+	baseline := samples(-10*time.Minute, 10, alternate(10, 12))
+	start, end := t0, t0.Add(9*time.Minute)
+	for _, x := range []struct {
+		name     string
+		baseline []model.SamplePair
+		window   []model.SamplePair
+		statuses []string
+		time     time.Time
+	}{
+		{"normal", baseline, samples(0, 10, alternate(12, 10)), nil, time.Time{}},
+		{"spike", baseline, samples(0, 10, func(i int) float64 { return []float64{11, 11, 20}[min(i, 2)] }), []string{Spike}, t0.Add(2 * time.Minute)},
+		{"drop", baseline, samples(0, 10, alternate(4, 5)), []string{Drop}, t0},
+		{"flatline", baseline, samples(0, 10, constant(11)), []string{Flatline}, t0},
+		{"gap", baseline, samples(0, 3, alternate(10, 12)), []string{Gap}, t0},
+		{"empty", baseline, nil, []string{Gap}, t0},
+		{"from zero", samples(-10*time.Minute, 10, constant(0)), samples(0, 10, constant(1)), []string{Spike}, t0},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			a := Detect(x.baseline, x.window, start, end, time.Minute)
+			require.NotNil(t, a)
+			assert.Equal(t, x.statuses, a.Statuses)
+			if x.statuses == nil {
+				assert.Zero(t, a.Score)
+				assert.Nil(t, a.Time)
+			} else {
+				assert.Greater(t, a.Score, 0.0)
+				assert.LessOrEqual(t, a.Score, 1.0)
+				require.NotNil(t, a.Time)
+				assert.Equal(t, x.time, *a.Time)
+			}
+		})
+	}
+	assert.Nil(t, Detect(nil, baseline, start, end, time.Minute), "no baseline")
+	a := Detect(baseline, samples(0, 10, alternate(20, 21)), start, end, time.Minute)
+	assert.InDelta(t, 10, a.ZScore, 1e-9)
+	assert.InDelta(t, (20.5-11)/11*100, a.PercentChange, 1e-9)
+	assert.Equal(t, 1.0, a.Score)
+}
+
+// prometheusServer serves series and range queries for series with a "series" label.
+// Window samples start at t0, values come from window[series] and baseline values alternate between 10 and 12.
+func prometheusServer(t *testing.T, window map[string]func(int) float64) *httptest.Server {
+	srv := httptest.NewServer(prometheusHandler(t, window))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// prometheusHandler serves series with a normal baseline before t0, and values from window after t0.
+func prometheusHandler(t *testing.T, window map[string]func(int) float64) *http.ServeMux {
+	reply := func(w http.ResponseWriter, data any) {
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"status": "success", "data": data}))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/series", func(w http.ResponseWriter, r *http.Request) {
+		var data []model.Metric
+		for name := range window {
+			data = append(data, model.Metric{"__name__": "requests", "series": model.LabelValue(name)})
+		}
+		reply(w, data)
+	})
+	mux.HandleFunc("/api/v1/query_range", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		start, _ := strconv.ParseFloat(q.Get("start"), 64)
+		end, _ := strconv.ParseFloat(q.Get("end"), 64)
+		step, _ := strconv.ParseFloat(q.Get("step"), 64)
+		var name string
+		for n := range window {
+			if strings.Contains(q.Get("query"), `series="`+n+`"`) {
+				name = n
+			}
+		}
+		ss := &model.SampleStream{Metric: model.Metric{"series": model.LabelValue(name)}}
+		i, j := 0, 0
+		for ts := start; ts <= end; ts += step {
+			tm := time.Unix(0, int64(ts*1e9))
+			if tm.Before(t0) {
+				ss.Values = append(ss.Values, model.SamplePair{Timestamp: model.TimeFromUnixNano(tm.UnixNano()), Value: model.SampleValue(alternate(10, 12)(i))})
+				i++
+			} else if v := window[name](j); !isGap(v) {
+				ss.Values = append(ss.Values, model.SamplePair{Timestamp: model.TimeFromUnixNano(tm.UnixNano()), Value: model.SampleValue(v)})
+				j++
+			}
+		}
+		reply(w, map[string]any{"resultType": "matrix", "result": model.Matrix{ss}})
+	})
+	return mux
+}
+
+const gapValue = -1
+
+func isGap(v float64) bool { return v == gapValue }
+
+func TestStore_Get_anomaly(t *testing.T) {
+	srv := prometheusServer(t, map[string]func(int) float64{
+		"normal":   alternate(12, 10),
+		"spike":    constant(30),
+		"flatline": constant(11),
+		"gap":      constant(gapValue),
+	})
+	s := anomalyStore(t, srv)
+	ctx := auth.WithToken(context.Background(), "anomaly-test")
+	var r []korrel8r.Object
+	c := &korrel8r.Constraint{Start: new(t0), End: new(t0.Add(10 * time.Minute))}
+	require.NoError(t, s.Get(ctx, Query(`requests`), c, korrel8r.AppenderFunc(func(o ...korrel8r.Object) { r = append(r, o...) })))
+	got := map[string][]string{}
+	for _, o := range r {
+		o := o.(Object)
+		require.NotNil(t, o.Anomaly, o.String())
+		got[o.Labels["series"]] = o.Anomaly.Statuses
+		if len(o.Anomaly.Statuses) > 0 {
+			assert.Equal(t, o.Anomaly.Score, Class{}.Score(o))
+			assert.False(t, Class{}.Time(o).Before(t0), "anomaly time %v", Class{}.Time(o))
+		} else {
+			assert.Zero(t, Class{}.Score(o))
+			assert.True(t, Class{}.Time(o).IsZero())
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"normal":   nil,
+		"spike":    {Spike},
+		"flatline": {Flatline},
+		"gap":      {Gap},
+	}, got)
+}
+
+// anomalyStore returns a store for srv that checks anomalies.
+func anomalyStore(t *testing.T, srv *httptest.Server) *Store {
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return &Store{
+		Client:          srv.Client(),
+		baseURL:         u,
+		k8sClient:       fake.NewClientBuilder().WithInterceptorFuncs(allowPrometheusAPI).Build(),
+		anomalyBaseline: time.Hour,
+		Store:           impl.NewStore(Domain),
+	}
+}
+
+func TestStore_Get_anomalyWorkers(t *testing.T) {
+	window := map[string]func(int) float64{}
+	for i := range 2 * AnomalyWorkers {
+		window[strconv.Itoa(i)] = constant(11)
+	}
+	var (
+		mu                  sync.Mutex
+		running, maxRunning int
+	)
+	mux := prometheusHandler(t, window)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/query_range") {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	s := anomalyStore(t, srv)
+	var r []korrel8r.Object
+	c := &korrel8r.Constraint{Start: new(t0), End: new(t0.Add(10 * time.Minute))}
+	require.NoError(t, s.Get(auth.WithToken(context.Background(), "anomaly-test"), Query(`requests`), c,
+		korrel8r.AppenderFunc(func(o ...korrel8r.Object) { r = append(r, o...) })))
+	require.Len(t, r, len(window))
+	for _, o := range r {
+		assert.NotNil(t, o.(Object).Anomaly, o)
+	}
+	assert.Greater(t, maxRunning, 1)
+	assert.LessOrEqual(t, maxRunning, AnomalyWorkers)
+}
+
+func TestStore_Get_anomalyDeadline(t *testing.T) {
+	mux := prometheusHandler(t, map[string]func(int) float64{"a": constant(11), "b": constant(11)})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/query_range") {
+			<-r.Context().Done() // Never answer.
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	s := anomalyStore(t, srv)
+	ctx, cancel := context.WithTimeout(auth.WithToken(context.Background(), "anomaly-test"), 200*time.Millisecond)
+	defer cancel()
+	var r []korrel8r.Object
+	begin := time.Now()
+	// Series are returned without anomalies when detection does not finish in time.
+	require.NoError(t, s.Get(ctx, Query(`requests`), nil, korrel8r.AppenderFunc(func(o ...korrel8r.Object) { r = append(r, o...) })))
+	assert.Less(t, time.Since(begin), AnomalyTimeout)
+	require.Len(t, r, 2)
+	for _, o := range r {
+		assert.Nil(t, o.(Object).Anomaly, o)
+	}
+}
+
+var allowPrometheusAPI = interceptor.Funcs{
+	Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		if sar, ok := obj.(*authv1.SelfSubjectAccessReview); ok {
+			sar.Status.Allowed = true
+		}
+		return nil
+	},
+}
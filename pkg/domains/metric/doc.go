@@ -12,6 +12,22 @@
 // for correlation, it does not use sample values. If a korrel8r search has time
 // constraints, then metrics with no values that meet the constraint are ignored.
 //
+// # Anomalies
+//
+// If the store has an anomalyBaseline, each series returned by a query is checked for anomalies.
+// Samples in the constraint time window are compared to samples in a baseline window of that length before it.
+// The window is the last 15 minutes if the constraint has no start time.
+// The [Anomaly] field of the object holds the z-score, percent change and these statuses:
+//
+//   - Spike or Drop: a window sample is 3 standard deviations from the baseline mean,
+//     or the window mean changed by 50% or more.
+//   - Flatline: the window values are constant at a normal level, the baseline values were not.
+//   - Gap: half or more of the expected window samples are missing.
+//
+// The MetricAnomaly status rule reports these statuses. Abnormal series also have a score and a time,
+// used for ranking, timelines and root cause candidates. At most 50 series are checked per query,
+// with up to 4 range queries at a time, for at most 10 seconds. Series not checked in time have no anomaly.
+//
 // # Query
 //
 // Selector is a [PromQL] query string.
@@ -30,6 +46,7 @@
 //
 //	domain: metric
 //	metric: URL_OF_PROMETHEUS
+//	anomalyBaseline: 1h # Optional, enables anomaly detection.
 //
 // [PromQL]: https://prometheus.io/docs/prometheus/latest/querying/basics/
 package metric
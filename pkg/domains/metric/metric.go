@@ -45,7 +45,12 @@ func (d domain) Query(s string) (korrel8r.Query, error) {
 	return Query(qs), err
 }
 
-const StoreKeyMetricURL = name
+const (
+	StoreKeyMetricURL = name
+	// StoreKeyAnomalyBaseline is a duration like "1h". If set, series are checked for anomalies,
+	// comparing the constraint window to a baseline window of this length before it. See [Anomaly].
+	StoreKeyAnomalyBaseline = "anomalyBaseline"
+)
 
 func (domain) Store(s any) (korrel8r.Store, error) {
 	cs, err := impl.TypeAssert[config.Store](s)
@@ -56,7 +61,17 @@ func (domain) Store(s any) (korrel8r.Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewStore(cs[StoreKeyMetricURL], hc)
+	var baseline time.Duration
+	if b := cs[StoreKeyAnomalyBaseline]; b != "" {
+		if baseline, err = time.ParseDuration(b); err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", StoreKeyAnomalyBaseline, err)
+		}
+	}
+	store, err := newStore(cs[StoreKeyMetricURL], hc, baseline)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
 }
 
 func (o Object) String() string {
@@ -92,9 +107,18 @@ func (c Class) ID(o korrel8r.Object) any {
 	return nil
 }
 
+func (c Class) Namespace(o korrel8r.Object) string {
+	if o, ok := o.(Object); ok {
+		return o.Labels["namespace"]
+	}
+	return ""
+}
+
 type Object struct {
 	Labels      map[string]string `json:"labels"`
 	Fingerprint string            `json:"fingerprint"`
+	// Anomaly is set if the store checks series for anomalies, see [StoreKeyAnomalyBaseline].
+	Anomaly *Anomaly `json:"anomaly,omitempty"`
 }
 
 func convertMetricToMap(m model.Metric) map[string]string {
@@ -114,10 +138,20 @@ type Store struct {
 	baseURL        *url.URL      // Original URL from configuration
 	configuredPort string        // Port from configuration (e.g., "9091")
 	k8sClient      client.Client // For RBAC permission checks
+	// anomalyBaseline is the length of the baseline window for anomaly detection, 0 if disabled.
+	anomalyBaseline time.Duration
 	*impl.Store
 }
 
 func NewStore(baseURL string, hc *http.Client) (korrel8r.Store, error) {
+	s, err := newStore(baseURL, hc, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newStore(baseURL string, hc *http.Client, anomalyBaseline time.Duration) (*Store, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
@@ -130,11 +164,12 @@ func NewStore(baseURL string, hc *http.Client) (korrel8r.Store, error) {
 	}
 
 	return &Store{
-		Client:         hc,
-		baseURL:        u,
-		configuredPort: u.Port(),
-		k8sClient:      k8sClient,
-		Store:          impl.NewStore(Domain),
+		Client:          hc,
+		baseURL:         u,
+		configuredPort:  u.Port(),
+		k8sClient:       k8sClient,
+		anomalyBaseline: anomalyBaseline,
+		Store:           impl.NewStore(Domain),
 	}, nil
 }
 
@@ -195,12 +230,19 @@ func (s *Store) Get(ctx context.Context, kquery korrel8r.Query, c *korrel8r.Cons
 	if r.Status != "success" {
 		return fmt.Errorf("GET %v: unexpected status: %v", u, r.Status)
 	}
+	objects := make([]Object, 0, len(r.Data))
 	for _, m := range r.Data {
-		result.Append(Object{
+		objects = append(objects, Object{
 			Labels:      convertMetricToMap(m),
 			Fingerprint: m.Fingerprint().String(),
 		})
 	}
+	if s.anomalyBaseline > 0 {
+		s.detectAnomalies(ctx, baseURL, objects, c)
+	}
+	for _, o := range objects {
+		result.Append(o)
+	}
 	return nil
 }
 
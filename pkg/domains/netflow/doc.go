@@ -18,6 +18,12 @@
 //	netflow:network:{SrcK8S_Type="Pod", SrcK8S_Namespace="myNamespace"}
 //	netflow:network:{DstK8S_Namespace="openshift-apiserver", DstK8S_OwnerName="apiserver"}
 //
+// # Counting
+//
+// Flows can be counted grouped by field values instead of being returned, using the "groupBy" parameter of the
+// REST /objects API or --group-by on the command line, for example "DstK8S_OwnerName,DstPort".
+// Counting is done in Loki with a LogQL metric query, so all matching flows are counted, not just the limit.
+//
 // # Store
 //
 // To connect to a netflow lokiStack store use this configuration:
@@ -3,6 +3,7 @@
 package netflow
 
 import (
+	"cmp"
 	"context"
 	_ "embed"
 	"fmt"
@@ -10,7 +11,9 @@ import (
 	"maps"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/loki"
@@ -125,6 +128,79 @@ func (c Class) ID(ko korrel8r.Object) any {
 	return hash.Sum64()
 }
 
+// CompactFields keeps the source and destination workloads, ports, protocol and size of a flow.
+func (c Class) CompactFields() []string {
+	return []string{
+		"SrcK8S_Namespace", "SrcK8S_Name", "SrcK8S_OwnerName", "SrcK8S_Type", "SrcAddr", "SrcPort",
+		"DstK8S_Namespace", "DstK8S_Name", "DstK8S_OwnerName", "DstK8S_Type", "DstAddr", "DstPort",
+		"Proto", "Bytes", "Packets", "TimeFlowStartMs", "TimeFlowEndMs",
+	}
+}
+
+// protocols names common IP protocol numbers.
+var protocols = map[float64]string{1: "ICMP", 6: "TCP", 17: "UDP", 58: "ICMPv6", 132: "SCTP"}
+
+// endpointFields maps summary field names to flow fields without the "Src" or "Dst" prefix.
+var endpointFields = map[string]string{
+	"namespace": "K8S_Namespace",
+	"name":      "K8S_Name",
+	"kind":      "K8S_Type",
+	"owner":     "K8S_OwnerName",
+	"ownerKind": "K8S_OwnerType",
+	"address":   "Addr",
+	"port":      "Port",
+}
+
+// Summary has the source and destination, protocol, size and times of a flow,
+// and packet drops, DNS errors and round-trip time if they are present.
+func (c Class) Summary(ko korrel8r.Object) map[string]any {
+	o, _ := ko.(Object)
+	if o == nil {
+		return nil
+	}
+	s := map[string]any{"source": o.endpoint("Src"), "destination": o.endpoint("Dst")}
+	switch p := o["Proto"].(type) {
+	case float64:
+		s["protocol"] = cmp.Or(protocols[p], fmt.Sprint(p))
+	case string:
+		s["protocol"] = p
+	}
+	for k, f := range map[string]string{
+		"bytes":          "Bytes",
+		"packets":        "Packets",
+		"droppedPackets": "PktDropPackets",
+		"dropCause":      "PktDropLatestDropCause",
+		"dnsErrno":       "DnsErrno",
+	} {
+		if v, ok := o[f]; ok && v != "" && v != float64(0) {
+			s[k] = v
+		}
+	}
+	if rcode, _ := o["DnsFlagsResponseCode"].(string); rcode != "" && rcode != "NoError" {
+		s["dnsResponseCode"] = rcode
+	}
+	if ns, ok := o["TimeFlowRttNs"].(float64); ok {
+		s["rtt"] = time.Duration(ns).String()
+	}
+	for k, f := range map[string]string{"start": "TimeFlowStartMs", "end": "TimeFlowEndMs"} {
+		if ms, ok := o[f].(float64); ok {
+			s[k] = time.UnixMilli(int64(ms)).UTC()
+		}
+	}
+	return s
+}
+
+// endpoint returns the fields of the source or destination of a flow, depending on prefix.
+func (o Object) endpoint(prefix string) map[string]any {
+	e := map[string]any{}
+	for k, f := range endpointFields {
+		if v, ok := o[prefix+f]; ok && v != "" && v != nil {
+			e[k] = v
+		}
+	}
+	return e
+}
+
 // Object is a map holding netflow entries
 type Object map[string]any
 
@@ -176,6 +252,15 @@ func (s *store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Const
 	return s.Client.Get(ctx, q.Data(), c, func(e *loki.Log) { result.Append(NewObject(e)) })
 }
 
+// Aggregate counts flow logs in Loki, grouped by labels or JSON fields.
+func (s *store) Aggregate(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	q, ok := query.(Query)
+	if !ok {
+		return nil, korrel8r.ErrNotAggregated
+	}
+	return s.Count(ctx, q.parseJSON(), fields, c)
+}
+
 type stackStore struct{ store }
 
 func (stackStore) Domain() korrel8r.Domain { return Domain }
@@ -189,6 +274,25 @@ func (s *stackStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.
 	return s.GetStack(ctx, q.Data(), "network", c, func(e *loki.Log) { result.Append(NewObject(e)) })
 }
 
+// Aggregate counts flow logs in the LokiStack "network" tenant, grouped by labels or JSON fields.
+func (s *stackStore) Aggregate(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	q, ok := query.(Query)
+	if !ok {
+		return nil, korrel8r.ErrNotAggregated
+	}
+	return s.CountStack(ctx, q.parseJSON(), "network", fields, c)
+}
+
+var jsonRE = regexp.MustCompile(`\|\s*json\b`)
+
+// parseJSON returns the LogQL query with a json parser, so flow fields are available as labels for counting.
+func (q Query) parseJSON() string {
+	if !jsonRE.MatchString(string(q)) {
+		return string(q) + "|json"
+	}
+	return string(q)
+}
+
 // Attributes to use when constructing an ID for de-duplication.
 // Choose attributes that help identify endpoints and don't vary during a single conversation.
 var idKeys = []string{
@@ -4,12 +4,35 @@ package netflow_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
 	"github.com/korrel8r/korrel8r/pkg/domains/netflow"
+	"github.com/stretchr/testify/assert"
 )
 
 var fixture = domain.Fixture{Query: netflow.NewQuery(`{DstK8S_Namespace="netobserv"}`)}
 
 func TestNetflowDomain(t *testing.T)      { fixture.Test(t) }
 func BenchmarkNetflowDomain(b *testing.B) { fixture.Benchmark(b) }
+
+func TestClass_Summary(t *testing.T) {
+	o := netflow.Object{
+		"SrcK8S_Namespace": "shop", "SrcK8S_Name": "web-0", "SrcK8S_Type": "Pod", "SrcK8S_OwnerName": "web", "SrcK8S_OwnerType": "StatefulSet",
+		"SrcAddr": "10.0.0.1", "SrcPort": float64(34567),
+		"DstAddr": "10.0.0.2", "DstPort": float64(5432), "DstK8S_Name": "db",
+		"Proto": float64(6), "Bytes": float64(1200), "Packets": float64(4), "PktDropPackets": float64(0),
+		"DnsFlagsResponseCode": "NoError", "TimeFlowRttNs": float64(2000000),
+		"TimeFlowStartMs": float64(1704164645000), "TimeFlowEndMs": float64(1704164646000),
+	}
+	assert.Equal(t, map[string]any{
+		"source": map[string]any{
+			"namespace": "shop", "name": "web-0", "kind": "Pod", "owner": "web", "ownerKind": "StatefulSet",
+			"address": "10.0.0.1", "port": float64(34567),
+		},
+		"destination": map[string]any{"name": "db", "address": "10.0.0.2", "port": float64(5432)},
+		"protocol":    "TCP", "bytes": float64(1200), "packets": float64(4), "rtt": "2ms",
+		"start": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "end": time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+	}, netflow.Class{}.Summary(o))
+	assert.Nil(t, netflow.Class{}.Summary("not a flow"))
+}
@@ -30,6 +30,14 @@
 //
 //	trace:span:a7880cc221e84e0d07b15993358811b7,b7880cc221e84e0d07b15993358811b7
 //
+// # Counting
+//
+// Spans can be counted grouped by field values instead of being returned, using the "groupBy" parameter of the
+// REST /objects API or --group-by on the command line.
+// Tempo counts spans in the backend using TraceQL metrics for these fields:
+// "name", "status.statusCode" and "attributes.NAME", for example "attributes.k8s.namespace.name".
+// Other fields are counted in memory.
+//
 // # Store
 //
 // The trace domain accepts an optional "tempoStack" field with a URL to connect.
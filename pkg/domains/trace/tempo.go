@@ -5,6 +5,7 @@ package trace
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -124,6 +125,113 @@ func (c *client) get(ctx context.Context, traceQL string, constraint *korrel8r.C
 	return nil
 }
 
+const (
+	searchPath  = "/api/search"
+	metricsPath = "/api/metrics/query_range"
+)
+
+// Count uses the TraceQL metrics API to count spans for a TraceQL query in the constraint time range,
+// grouped by span fields. Fields are mapped to TraceQL as follows:
+//   - "name" is the trace root span name, rootName.
+//   - "status.statusCode" is the span status.
+//   - "attributes.service.name" is the trace root service name, rootServiceName.
+//   - "attributes.X" is the unscoped attribute .X
+//
+// Returns [korrel8r.ErrNotAggregated] for other fields, or if the store URL is not a search URL.
+func (c *client) Count(ctx context.Context, traceQL string, fields []string, constraint *korrel8r.Constraint) ([]korrel8r.Group, error) {
+	if !strings.HasSuffix(c.base.Path, searchPath) {
+		return nil, fmt.Errorf("%w: no metrics API for %v", korrel8r.ErrNotAggregated, c.base)
+	}
+	attrs := make([]string, len(fields))
+	for i, f := range fields {
+		switch {
+		case f == "name":
+			attrs[i] = "rootName"
+		case f == "status.statusCode":
+			attrs[i] = statusAttr
+		case f == "attributes."+otel.AttrServiceName:
+			attrs[i] = "rootServiceName"
+		case strings.HasPrefix(f, "attributes."):
+			attrs[i] = strings.TrimPrefix(f, "attributes")
+		default:
+			return nil, fmt.Errorf("%w: no TraceQL attribute for %q", korrel8r.ErrNotAggregated, f)
+		}
+	}
+	end := constraint.GetEnd()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := constraint.GetStart()
+	if start.IsZero() || !start.Before(end) {
+		start = end.Add(-time.Hour)
+	}
+	metricQL := traceQL + "|count_over_time()"
+	if len(attrs) > 0 {
+		metricQL = fmt.Sprintf("%v by (%v)", metricQL, strings.Join(attrs, ","))
+	}
+	u := *c.base // Copy, don't modify base.
+	u.Path = strings.TrimSuffix(u.Path, searchPath) + metricsPath
+	u.RawQuery = url.Values{
+		query:   []string{metricQL},
+		"start": []string{formatTime(start)},
+		"end":   []string{formatTime(end)},
+		"step":  []string{fmt.Sprintf("%vs", int64(math.Ceil(end.Sub(start).Seconds())))},
+	}.Encode()
+
+	var response struct {
+		Series []struct {
+			Labels  otel.KeyValueList `json:"labels"`
+			Samples []struct {
+				Value float64 `json:"value"`
+			} `json:"samples"`
+		} `json:"series"`
+	}
+	if err := impl.Get(ctx, &u, c.hc, &response); err != nil {
+		return nil, err
+	}
+	groups := make([]korrel8r.Group, 0, len(response.Series))
+	for _, series := range response.Series {
+		labels := series.Labels.Map()
+		g := korrel8r.Group{Fields: make(map[string]string, len(fields))}
+		for i, f := range fields {
+			v, ok := labels[attrs[i]]
+			if !ok { // Unscoped attribute labels may omit the leading "."
+				v = labels[strings.TrimPrefix(attrs[i], ".")]
+			}
+			g.Fields[f] = labelString(attrs[i], v)
+		}
+		var n float64
+		for _, sample := range series.Samples {
+			n += sample.Value
+		}
+		g.Count = int(math.Round(n))
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// labelString converts a metrics label value to the string form of the Span field.
+func labelString(attr string, v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if attr == statusAttr { // TraceQL status values are lower case
+			switch v {
+			case "error":
+				return string(StatusError)
+			case "ok":
+				return string(StatusOK)
+			case "unset":
+				return string(StatusUnset)
+			}
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // collect calls collect() on each *Span.
 func (r *tempoResponse) collect(collect func(*Span)) {
 	for _, tt := range r.Traces {
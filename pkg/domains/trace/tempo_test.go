@@ -3,10 +3,16 @@
 package trace
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +125,34 @@ func TestUnmarshal(t *testing.T) {
 	}
 	assert.Equal(t, want, spans)
 }
+
+func TestClient_Count(t *testing.T) {
+	var path string
+	var params url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, params = r.URL.Path, r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"series":[
+{"labels":[{"key":"status","value":{"stringValue":"error"}},{"key":".k8s.namespace.name","value":{"stringValue":"demo"}}],
+ "samples":[{"timestampMs":"1","value":2},{"timestampMs":"2","value":3}]},
+{"labels":[{"key":"status","value":{"stringValue":"unset"}}],"samples":[{"timestampMs":"1","value":10}]}]}`))
+	}))
+	defer server.Close()
+	base, _ := url.Parse(server.URL + "/api/traces/v1/platform/tempo/api/search")
+	c := newClient(server.Client(), base)
+	end := time.Unix(1000, 0)
+	constraint := &korrel8r.Constraint{Start: new(end.Add(-time.Minute)), End: &end}
+
+	groups, err := c.Count(context.Background(), `{resource.service.name="x"}`, []string{"status.statusCode", "attributes.k8s.namespace.name"}, constraint)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/traces/v1/platform/tempo/api/metrics/query_range", path)
+	assert.Equal(t, `{resource.service.name="x"}|count_over_time() by (status,.k8s.namespace.name)`, params.Get("q"))
+	assert.Equal(t, url.Values{"q": params["q"], "start": {"940"}, "end": {"1000"}, "step": {"60s"}}, params)
+	assert.Equal(t, []korrel8r.Group{
+		{Fields: map[string]string{"status.statusCode": "Error", "attributes.k8s.namespace.name": "demo"}, Count: 5},
+		{Fields: map[string]string{"status.statusCode": "Unset", "attributes.k8s.namespace.name": ""}, Count: 10},
+	}, groups)
+
+	_, err = c.Count(context.Background(), `{}`, []string{"context.traceID"}, constraint)
+	assert.ErrorIs(t, err, korrel8r.ErrNotAggregated)
+}
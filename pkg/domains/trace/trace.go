@@ -3,6 +3,7 @@
 package trace
 
 import (
+	"cmp"
 	"context"
 	_ "embed"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/otel"
 )
 
 var _ = impl.AssertDomainTypes(Domain, Object(nil), Class{}, Query(""), &stackStore{})
@@ -75,6 +77,65 @@ func (c Class) ID(o korrel8r.Object) any {
 	return nil
 }
 
+func (c Class) Namespace(o korrel8r.Object) string {
+	if span, _ := o.(Object); span != nil {
+		ns, _ := span.Attributes[otel.AttrK8sNamespaceName].(string)
+		return ns
+	}
+	return ""
+}
+
+// CompactFields keeps the span name, identity, times, status and the attributes that identify its source.
+func (c Class) CompactFields() []string {
+	return []string{
+		"name", "context", "startTime", "endtime", "status",
+		`attributes["` + otel.AttrServiceName + `"]`,
+		`attributes["` + otel.AttrK8sNamespaceName + `"]`,
+		`attributes["k8s.pod.name"]`,
+		`attributes["http.status_code"]`,
+	}
+}
+
+// Summary has the span name, source, identity, start time, duration and status.
+// A root span has no parent.
+func (c Class) Summary(o korrel8r.Object) map[string]any {
+	span, _ := o.(Object)
+	if span == nil {
+		return nil
+	}
+	s := map[string]any{
+		"name":     span.Name,
+		"traceID":  span.Context.TraceID,
+		"spanID":   span.Context.SpanID,
+		"root":     span.ParentID == nil,
+		"start":    span.StartTime,
+		"duration": span.Duration().String(),
+		"status":   cmp.Or(span.Status.Code, StatusUnset),
+	}
+	if span.Status.Description != "" {
+		s["statusDescription"] = span.Status.Description
+	}
+	for k, attr := range map[string]string{
+		"service":        otel.AttrServiceName,
+		"namespace":      otel.AttrK8sNamespaceName,
+		"pod":            "k8s.pod.name",
+		"httpStatusCode": "http.status_code",
+	} {
+		if v, ok := span.Attributes[attr]; ok && v != "" {
+			s[k] = v
+		}
+	}
+	return s
+}
+
+// Time returns the start time of the span.
+func (c Class) Time(o korrel8r.Object) time.Time {
+	if span, _ := o.(Object); span != nil {
+		return span.StartTime
+	}
+	return time.Time{}
+}
+
 type Object = *Span
 
 // TraceID is a hex-encoded 16 byte identifier.
@@ -165,3 +226,12 @@ func (s *stackStore) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.
 
 	return s.GetStack(ctx, q.Data(), c, func(s *Span) { result.Append(s) })
 }
+
+// Aggregate counts spans with the TraceQL metrics API, see [client.Count].
+func (s *stackStore) Aggregate(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	q, ok := query.(Query)
+	if !ok {
+		return nil, korrel8r.ErrNotAggregated
+	}
+	return s.Count(ctx, q.Data(), fields, c)
+}
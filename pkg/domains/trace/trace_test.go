@@ -4,9 +4,11 @@ package trace_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
 	"github.com/korrel8r/korrel8r/pkg/domains/trace"
+	"github.com/stretchr/testify/assert"
 )
 
 // TODO tempo limits number of traces, not spans. Remove ClusterSetup when fixed.
@@ -17,3 +19,23 @@ var fixture = domain.Fixture{
 
 func TestTraceDomain(t *testing.T)     { fixture.Test(t) }
 func BenchmarTraceDomain(b *testing.B) { fixture.Benchmark(b) }
+
+func TestClass_Summary(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	parent := trace.SpanID("p1")
+	span := &trace.Span{
+		Name:       "GET /cart",
+		Context:    trace.SpanContext{TraceID: "t1", SpanID: "s1"},
+		ParentID:   &parent,
+		StartTime:  start,
+		EndTime:    start.Add(1500 * time.Millisecond),
+		Attributes: map[string]any{"service.name": "cart", "k8s.namespace.name": "shop", "http.status_code": float64(500), "other": "x"},
+		Status:     trace.Status{Code: trace.StatusError, Description: "timeout"},
+	}
+	assert.Equal(t, map[string]any{
+		"name": "GET /cart", "traceID": trace.TraceID("t1"), "spanID": trace.SpanID("s1"), "root": false,
+		"start": start, "duration": "1.5s", "status": trace.StatusError, "statusDescription": "timeout",
+		"service": "cart", "namespace": "shop", "httpStatusCode": float64(500),
+	}, trace.Class{}.Summary(span))
+	assert.Nil(t, trace.Class{}.Summary("not a span"))
+}
@@ -0,0 +1,285 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package domaintest checks that a domain implementation follows the korrel8r contract.
+//
+// It is intended for authors of domains outside this repository, as well as the built-in domains.
+// Create a [Suite] in a test and call [Suite.Run]:
+//
+//	func TestDomain(t *testing.T) {
+//		s := &domaintest.Suite{
+//			Domain: mydomain.Domain,
+//			Store:  mydomain.NewStore(testServer.URL), // A stand-in store with known data.
+//			Query:  mydomain.NewQuery("selects 10 objects"),
+//			Len:    10,
+//			Time:   mydomain.Time, // Optional, enables time constraint tests.
+//		}
+//		s.Run(t)
+//	}
+//
+//	func BenchmarkDomain(b *testing.B) { s.Benchmark(b) }
+//
+// The individual tests can also be run separately, they are the Test* methods of [Suite].
+package domaintest
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Suite is a set of conformance tests for a domain.
+//
+// Domain is required. Tests that need objects are skipped if Store or Query is nil.
+type Suite struct {
+	// Domain under test.
+	Domain korrel8r.Domain
+	// Store is a store for Domain, it can be a stand-in for the real data source.
+	Store korrel8r.Store
+	// Query selects exactly Len objects from Store when there is no constraint.
+	Query korrel8r.Query
+	// Len is the number of objects returned by Query, it must be at least 2.
+	Len int
+	// Time returns the time of an object, optional.
+	// If set, Store must drop objects outside the constraint start and end times.
+	Time func(korrel8r.Object) time.Time
+}
+
+// Run all the tests as sub-tests of t.
+func (s *Suite) Run(t *testing.T) {
+	t.Helper()
+	for _, x := range []struct {
+		name string
+		f    func(*testing.T)
+	}{
+		{"Domain", s.TestDomain},
+		{"Classes", s.TestClasses},
+		{"Query", s.TestQuery},
+		{"Get", s.TestGet},
+		{"Limit", s.TestLimit},
+		{"Time", s.TestTime},
+		{"Unmarshal", s.TestUnmarshal},
+		{"IDer", s.TestIDer},
+		{"Previewer", s.TestPreviewer},
+	} {
+		t.Run(x.name, x.f)
+	}
+}
+
+// TestDomain checks the domain name and description.
+func (s *Suite) TestDomain(t *testing.T) {
+	require.NotNil(t, s.Domain, "Suite.Domain is required")
+	assert.NotEmpty(t, s.Domain.Name(), "domain name")
+	assert.NotContains(t, s.Domain.Name(), ":", "domain name")
+	assert.NotEmpty(t, s.Domain.Description(), "domain description")
+}
+
+// TestClasses checks that each class belongs to the domain, and can be found by name.
+// If Query is set, its class must also be found by name.
+func (s *Suite) TestClasses(t *testing.T) {
+	d := s.Domain
+	classes := d.Classes()
+	if s.Query != nil {
+		classes = append(classes, s.Query.Class())
+	}
+	if len(classes) == 0 {
+		t.Skip("domain classes are only known from a store")
+	}
+	for _, c := range classes {
+		assert.NotEmpty(t, c.Name(), "class name")
+		assert.NotContains(t, c.Name(), ":", "class name")
+		assert.Equal(t, d.Name(), c.Domain().Name(), "class domain: %v", c)
+		assert.Equal(t, d.Name()+":"+c.Name(), c.String())
+		assert.Equal(t, c, d.Class(c.Name()), "domain.Class(%q)", c.Name())
+	}
+}
+
+// TestQuery checks that the query string parses to an equal query, directly and via [korrel8r.Domains].
+func (s *Suite) TestQuery(t *testing.T) {
+	q := s.requireQuery(t)
+	assert.NotPanics(t, func() { _ = map[korrel8r.Query]bool{q: true} }, "query must be comparable")
+	assert.Equal(t, q.Class().String()+":"+q.Data(), q.String())
+	q2, err := s.Domain.Query(q.String())
+	require.NoError(t, err)
+	assert.Equal(t, q, q2)
+	ds := korrel8r.NewDomains()
+	ds.Add(s.Domain)
+	q3, err := ds.Query(q.String())
+	require.NoError(t, err)
+	assert.Equal(t, q, q3)
+	assert.Equal(t, q.String(), q3.String())
+	_, err = s.Domain.Query(s.Domain.Name() + ":")
+	assert.Error(t, err, "query with no class")
+}
+
+// TestGet checks that the store returns Len objects for the query.
+func (s *Suite) TestGet(t *testing.T) {
+	s.requireStore(t)
+	assert.Equal(t, s.Domain.Name(), s.Store.Domain().Name(), "store domain")
+	assert.Len(t, s.get(t, nil), s.Len)
+	assert.Len(t, s.get(t, &korrel8r.Constraint{}), s.Len, "empty constraint")
+}
+
+// TestLimit checks that the store respects the constraint limit.
+func (s *Suite) TestLimit(t *testing.T) {
+	s.requireStore(t)
+	for _, limit := range []int{1, s.Len - 1, s.Len + 1} {
+		assert.Len(t, s.get(t, &korrel8r.Constraint{Limit: &limit}), min(limit, s.Len), "limit %v", limit)
+	}
+}
+
+// TestTime checks that the store drops objects outside of the constraint time range.
+// Skipped if Suite.Time is nil or the objects have no time.
+func (s *Suite) TestTime(t *testing.T) {
+	s.requireStore(t)
+	if s.Time == nil {
+		t.Skip("Suite.Time is nil")
+	}
+	var all, times []time.Time
+	for _, o := range s.get(t, nil) {
+		tm := s.Time(o)
+		all = append(all, tm)
+		if !tm.IsZero() {
+			times = append(times, tm)
+		}
+	}
+	if len(times) == 0 {
+		t.Skip("objects have no time")
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	mid := times[len(times)/2]
+	// Objects with no time are never outside the constraint, see [korrel8r.Constraint.CompareTime].
+	count := func(keep func(time.Time) bool) (n int) {
+		for _, tm := range all {
+			if keep(tm) {
+				n++
+			}
+		}
+		return n
+	}
+	for _, x := range []struct {
+		name string
+		c    *korrel8r.Constraint
+	}{
+		{"start", &korrel8r.Constraint{Start: &mid}},
+		{"end", &korrel8r.Constraint{End: &mid}},
+		{"start and end", &korrel8r.Constraint{Start: &times[0], End: &mid}},
+		{"start after", &korrel8r.Constraint{Start: new(times[len(times)-1].Add(time.Second))}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			got := s.get(t, x.c)
+			for _, o := range got {
+				assert.Zero(t, x.c.CompareTime(s.Time(o)), "object time %v outside constraint %v", s.Time(o), x.c)
+			}
+			assert.Len(t, got, count(func(tm time.Time) bool { return x.c.CompareTime(tm) == 0 }))
+		})
+	}
+}
+
+// TestUnmarshal checks that objects survive a JSON round trip through [korrel8r.Class.Unmarshal].
+func (s *Suite) TestUnmarshal(t *testing.T) {
+	s.requireStore(t)
+	c := s.Query.Class()
+	for _, o := range s.get(t, nil) {
+		b, err := json.Marshal(o)
+		require.NoError(t, err)
+		o2, err := c.Unmarshal(b)
+		require.NoError(t, err)
+		require.Equal(t, o, o2)
+	}
+}
+
+// TestIDer checks that IDs are comparable, and that objects from repeated Get calls are removed as duplicates.
+// Skipped if the query class does not implement [korrel8r.IDer].
+func (s *Suite) TestIDer(t *testing.T) {
+	s.requireStore(t)
+	ider, ok := s.Query.Class().(korrel8r.IDer)
+	if !ok {
+		t.Skip("class does not implement IDer")
+	}
+	ids := map[any]bool{}
+	for _, o := range s.get(t, nil) {
+		require.NotPanics(t, func() { ids[ider.ID(o)] = true }, "ID must be comparable")
+	}
+	r := result.New(s.Query.Class())
+	for range 2 {
+		require.NoError(t, s.Store.Get(context.Background(), s.Query, nil, r))
+	}
+	assert.Len(t, r.List(), s.Len, "duplicates not removed")
+}
+
+// TestPreviewer checks that objects have a non-empty, single line preview.
+// Skipped if the query class does not implement [korrel8r.Previewer].
+func (s *Suite) TestPreviewer(t *testing.T) {
+	s.requireStore(t)
+	p, ok := s.Query.Class().(korrel8r.Previewer)
+	if !ok {
+		t.Skip("class does not implement Previewer")
+	}
+	for _, o := range s.get(t, nil) {
+		preview := p.Preview(o)
+		assert.NotEmpty(t, preview, "preview of %v", o)
+		assert.NotContains(t, strings.TrimSpace(preview), "\n", "preview of %v", o)
+	}
+}
+
+// Benchmark the query parser, store and unmarshal.
+func (s *Suite) Benchmark(b *testing.B) {
+	b.Run("Query", func(b *testing.B) {
+		q := s.requireQuery(b).String()
+		for b.Loop() {
+			_, err := s.Domain.Query(q)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("Get", func(b *testing.B) {
+		s.requireStore(b)
+		for b.Loop() {
+			r := result.New(s.Query.Class())
+			require.NoError(b, s.Store.Get(context.Background(), s.Query, nil, r))
+			require.Len(b, r.List(), s.Len)
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		s.requireStore(b)
+		o := s.get(b, &korrel8r.Constraint{Limit: new(1)})[0]
+		data, err := json.Marshal(o)
+		require.NoError(b, err)
+		for b.Loop() {
+			_, err := s.Query.Class().Unmarshal(data)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func (s *Suite) requireQuery(t testing.TB) korrel8r.Query {
+	t.Helper()
+	if s.Query == nil {
+		t.Skip("Suite.Query is nil")
+	}
+	return s.Query
+}
+
+func (s *Suite) requireStore(t testing.TB) {
+	t.Helper()
+	s.requireQuery(t)
+	if s.Store == nil {
+		t.Skip("Suite.Store is nil")
+	}
+	require.GreaterOrEqual(t, s.Len, 2, "Suite.Len must be at least 2")
+}
+
+// get the objects for the query, duplicates are removed if the class implements [korrel8r.IDer].
+func (s *Suite) get(t testing.TB, c *korrel8r.Constraint) []korrel8r.Object {
+	t.Helper()
+	r := result.New(s.Query.Class())
+	require.NoError(t, s.Store.Get(context.Background(), s.Query, c, r))
+	return r.List()
+}
@@ -0,0 +1,73 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/unique"
+)
+
+// Aggregate counts the objects selected by query, grouped by the values of fields.
+//
+// Stores that implement [korrel8r.Aggregator] count all matching objects in the backend.
+// Objects from other stores, or stores that fail to aggregate, are retrieved with Get and counted in memory,
+// so only objects up to the constraint limit are counted.
+// If authorization filtering is enabled, all counting is in memory: objects must be checked one by one.
+func (e *Engine) Aggregate(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	groups := impl.NewGroups(fields)
+	if e.authz != nil {
+		err := e.Get(ctx, query, constraint, groups)
+		return groups.List(), err
+	}
+	constraint = constraint.Default()
+	ss := e.storeHolders[query.Class().Domain()]
+	if ss == nil || len(ss.stores) == 0 {
+		return nil, fmt.Errorf("no stores found for domain %v", query.Class().Domain().Name())
+	}
+	errs := unique.NewList[string]()
+	ok := false
+	for _, s := range ss.stores {
+		if want := constraint.GetCluster(); want != "" && s.Cluster() != "" && s.Cluster() != want {
+			continue
+		}
+		err := s.aggregate(ctx, query, constraint, groups)
+		if err != nil {
+			errs.Add(err.Error())
+		}
+		ok = (err == nil) || ok
+	}
+	if ok {
+		if len(errs.List) > 0 {
+			log.V(2).Info("Aggregate succeeded with non-fatal errors", "errors", errs.List)
+		}
+		return groups.List(), nil
+	}
+	if len(errs.List) == 0 {
+		return nil, ss.notFound(constraint)
+	}
+	return nil, fmt.Errorf("Aggregate failed: %v", errs.List)
+}
+
+// aggregate adds counts from the store to groups, in the backend if possible, otherwise in memory.
+func (s *storeHolder) aggregate(ctx context.Context, q korrel8r.Query, c *korrel8r.Constraint, groups *impl.Groups) error {
+	store, err := s.Ensure()
+	if err != nil {
+		return err
+	}
+	if a, ok := store.(korrel8r.Aggregator); ok {
+		result, err := a.Aggregate(ctx, q, c, groups.Fields())
+		if err == nil {
+			groups.AddGroups(result...)
+			return nil
+		}
+		if !errors.Is(err, korrel8r.ErrNotAggregated) {
+			log.V(2).Info("Aggregate failed, counting in memory", "domain", s.domain.Name(), "query", q, "error", err)
+		}
+	}
+	return s.Get(ctx, q, c, groups)
+}
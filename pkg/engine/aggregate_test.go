@@ -0,0 +1,83 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aggregatorStore is a mock store that returns fixed groups from Aggregate, or err.
+type aggregatorStore struct {
+	*mock.Store
+	groups []korrel8r.Group
+	err    error
+}
+
+func (s aggregatorStore) Aggregate(_ context.Context, _ korrel8r.Query, _ *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	return s.groups, s.err
+}
+
+func TestEngine_Aggregate(t *testing.T) {
+	d := mock.NewDomain("mock", "foo")
+	q := mock.NewQuery(d.Class("foo"), "x")
+	objects := []korrel8r.Object{
+		map[string]string{"level": "info"},
+		map[string]string{"level": "error"},
+		map[string]string{"level": "info"},
+	}
+	newStore := func() *mock.Store {
+		s := mock.NewStore(d)
+		s.AddQuery(q, objects)
+		return s
+	}
+	pushed := aggregatorStore{Store: newStore(), groups: []korrel8r.Group{{Fields: map[string]string{"level": "error"}, Count: 100}}}
+	unsupported := aggregatorStore{Store: newStore(), err: korrel8r.ErrNotAggregated}
+	failed := aggregatorStore{Store: newStore(), err: errors.New("backend failed")}
+
+	for _, x := range []struct {
+		name   string
+		stores []korrel8r.Store
+		want   []korrel8r.Group
+	}{
+		{"in memory", list[korrel8r.Store](newStore()), []korrel8r.Group{
+			{Fields: map[string]string{"level": "info"}, Count: 2},
+			{Fields: map[string]string{"level": "error"}, Count: 1},
+		}},
+		{"pushed down", list[korrel8r.Store](pushed), []korrel8r.Group{
+			{Fields: map[string]string{"level": "error"}, Count: 100},
+		}},
+		{"fallback", list[korrel8r.Store](unsupported, failed), []korrel8r.Group{
+			{Fields: map[string]string{"level": "info"}, Count: 4},
+			{Fields: map[string]string{"level": "error"}, Count: 2},
+		}},
+		{"mixed", list[korrel8r.Store](pushed, newStore()), []korrel8r.Group{
+			{Fields: map[string]string{"level": "error"}, Count: 101},
+			{Fields: map[string]string{"level": "info"}, Count: 2},
+		}},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			e, err := engine.Build().Domains(d).Stores(x.stores...).Engine()
+			require.NoError(t, err)
+			got, err := e.Aggregate(context.Background(), q, nil, []string{"level"})
+			require.NoError(t, err)
+			assert.Equal(t, x.want, got)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		s := mock.NewStore(d)
+		s.AddLookup(func(korrel8r.Query) ([]korrel8r.Object, error) { return nil, errors.New("get failed") })
+		e, err := engine.Build().Domains(d).Stores(s).Engine()
+		require.NoError(t, err)
+		_, err = e.Aggregate(context.Background(), q, nil, []string{"level"})
+		assert.ErrorContains(t, err, "get failed")
+	})
+}
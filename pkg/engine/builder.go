@@ -9,9 +9,13 @@ import (
 	"maps"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/korrel8r/korrel8r/pkg/authz"
 	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/httpjson"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/plugin"
+	"github.com/korrel8r/korrel8r/pkg/redact"
 	"github.com/korrel8r/korrel8r/pkg/rules"
 	"github.com/korrel8r/korrel8r/pkg/status"
 	"github.com/korrel8r/korrel8r/pkg/unique"
@@ -39,6 +43,8 @@ func Build() *Builder {
 		storeHolders:     map[korrel8r.Domain]*storeHolders{},
 		rulesByName:      map[string]korrel8r.Rule{},
 		statuses:         map[string][]status.Rule{},
+		recipes:          map[string]*Recipe{},
+		crossCluster:     unique.NewSet[string](),
 		storeMetricAttrs: map[string][2]metric.MeasurementOption{},
 	}
 	// Add template functions that are always available.
@@ -156,6 +162,14 @@ func (b *Builder) StatusRules(rules ...status.Rule) *Builder {
 	return b
 }
 
+// CrossCluster marks the named rules as crossing clusters, see [Engine.CrossCluster].
+func (b *Builder) CrossCluster(ruleNames ...string) *Builder {
+	for _, name := range ruleNames {
+		b.e.crossCluster.Add(name)
+	}
+	return b
+}
+
 func (b *Builder) rules(rules ...korrel8r.Rule) {
 	for _, r := range rules {
 		if b.err != nil {
@@ -177,6 +191,19 @@ func (b *Builder) Config(configs config.Configs) *Builder {
 	if b.err != nil {
 		return b
 	}
+	// Add domains first, stores and rules in any configuration may refer to them.
+	for _, c := range configs {
+		for _, dc := range c.Domains {
+			d, err := newDomain(dc)
+			if err != nil {
+				b.err = fmt.Errorf("%v: %w", c.Source, err)
+				return b
+			}
+			if b.Domains(d); b.err != nil {
+				return b
+			}
+		}
+	}
 	for source, c := range configs {
 		b.config(&c)
 		if b.err != nil {
@@ -188,13 +215,41 @@ func (b *Builder) Config(configs config.Configs) *Builder {
 	return b
 }
 
+// newDomain creates a domain from configuration, using the domain type field that is set.
+func newDomain(dc config.Domain) (korrel8r.Domain, error) {
+	switch {
+	case dc.HTTP != nil && dc.Plugin != nil:
+		return nil, fmt.Errorf("domain %q: only one of http or plugin is allowed", dc.Name)
+	case dc.Plugin != nil:
+		return plugin.New(dc)
+	default:
+		return httpjson.New(dc)
+	}
+}
+
 func (b *Builder) Tuning(t *config.Tuning) *Builder {
 	if t != nil {
 		b.e.Tuning = *t
+		if t.Authorization != nil {
+			b.e.authz = authz.New(*t.Authorization, nil)
+		}
+		if t.Redaction != nil {
+			r, err := redact.New(*t.Redaction)
+			if err != nil {
+				b.err = err
+			}
+			b.e.redactor = r
+		}
 	}
 	return b
 }
 
+// Authorize sets the filter used to drop objects the caller is not allowed to see, see [Engine.Get].
+func (b *Builder) Authorize(f *authz.Filter) *Builder {
+	b.e.authz = f
+	return b
+}
+
 func (b *Builder) ConfigFile(file string) *Builder {
 	cfg, err := config.Load(file)
 	if err != nil {
@@ -245,6 +300,9 @@ func (b *Builder) config(c *config.Config) {
 		}
 		b.finally = append(b.finally, func() { b.configStatusRule(lr) })
 	}
+	for _, r := range c.Recipes {
+		b.finally = append(b.finally, func() { b.configRecipe(r) })
+	}
 }
 
 // buildTemplateBase adds named templates from configuration to the base template set.
@@ -280,6 +338,9 @@ func (b *Builder) configRule(r config.Rule) {
 	kr, b.err = rules.NewTemplateRule(start, goal, b.e.NewTemplate(r.Name), r.Result.Query, b.e.domains)
 	if b.err == nil {
 		b.rules(kr)
+		if r.CrossCluster {
+			b.CrossCluster(kr.Name())
+		}
 	}
 }
 
@@ -0,0 +1,399 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package doctor diagnoses store connection and permission problems.
+//
+// Each configured store has these checks:
+//
+//  1. template: expand the store configuration templates.
+//  2. create: create the store from the expanded configuration.
+//     The engine's own stores are used, see [engine.Engine.ConfiguredStoresFor],
+//     so a diagnosis does not create new store clients, and a failed store is not re-created before its retry interval.
+//  3. probe: run a cheap query with a short time range and a limit of 1.
+//  4. rbac: check the permissions the store needs with SelfSubjectAccessReview,
+//     including LokiStack and TempoStack tenants.
+//
+// If the template or create checks fail, the remaining checks are not run.
+// The rbac check runs even if the probe fails, it often explains why.
+//
+// Permissions are checked for the caller of the request, or the current kube config user on the command line.
+// Findings that are not OK have a suggested fix.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/incident"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	logs "github.com/korrel8r/korrel8r/pkg/domains/log"
+	"github.com/korrel8r/korrel8r/pkg/domains/metric"
+	"github.com/korrel8r/korrel8r/pkg/domains/netflow"
+	"github.com/korrel8r/korrel8r/pkg/domains/trace"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var log = logging.Log()
+
+// Level of a finding.
+type Level string
+
+const (
+	OK      Level = "ok"
+	Skipped Level = "skipped"
+	Warning Level = "warning"
+	Error   Level = "error"
+)
+
+// severity orders levels from best to worst.
+func (l Level) severity() int {
+	switch l {
+	case Skipped:
+		return 1
+	case Warning:
+		return 2
+	case Error:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Names of checks.
+const (
+	CheckTemplate = "template"
+	CheckCreate   = "create"
+	CheckProbe    = "probe"
+	CheckRBAC     = "rbac"
+)
+
+// Finding is the result of a check.
+type Finding struct {
+	Check   string
+	Level   Level
+	Message string
+	// Fix suggests how to correct a problem, empty for OK findings.
+	Fix string
+}
+
+// Store is the diagnosis of one store.
+type Store struct {
+	Domain string
+	// Config is the store configuration before template expansion.
+	Config config.Store
+	// Expanded is the configuration after template expansion, nil if expansion failed.
+	Expanded config.Store
+	Findings []Finding
+}
+
+// Level is the worst level of the store findings.
+func (s *Store) Level() Level {
+	level := OK
+	for _, f := range s.Findings {
+		if f.Level.severity() > level.severity() {
+			level = f.Level
+		}
+	}
+	return level
+}
+
+func (s *Store) add(check string, level Level, msg, fix string) {
+	s.Findings = append(s.Findings, Finding{Check: check, Level: level, Message: msg, Fix: fix})
+}
+
+// probeDuration is the time range for probe queries.
+const probeDuration = 5 * time.Minute
+
+// probes are cheap queries for the built-in domains, they don't need to find anything.
+var probes = map[string]string{
+	"alert":    `alert:alert:{"alertname":"Watchdog"}`,
+	"incident": `incident:incident:{"id":"korrel8r-doctor-probe"}`,
+	"k8s":      `k8s:Namespace.v1:{"name":"default"}`,
+	"log":      `log:application:{"namespace":"default"}`,
+	"metric":   `metric:metric:up`,
+	"netflow":  `netflow:network:{SrcK8S_Namespace="default"}`,
+	"trace":    `trace:span:{resource.k8s.namespace.name="default"}`,
+}
+
+// Diagnose the stores for domains, or for all domains if domains is empty.
+// Access reviews are sent with c, if c is nil a client is created from the default kube config.
+func Diagnose(ctx context.Context, e *engine.Engine, domains []korrel8r.Domain, c client.Client) []Store {
+	if len(domains) == 0 {
+		domains = e.Domains()
+	}
+	var reviewer func() (client.Client, error)
+	if c != nil {
+		reviewer = func() (client.Client, error) { return c, nil }
+	} else {
+		reviewer = func() (client.Client, error) { return k8s.NewClient(nil) }
+	}
+	var stores []Store
+	for _, d := range domains {
+		for _, cs := range e.ConfiguredStoresFor(d) {
+			s := Store{Domain: d.Name(), Config: cs.Original}
+			diagnose(ctx, e, cs, &s, reviewer)
+			log.V(2).Info("Doctor: store diagnosed", "domain", s.Domain, "level", s.Level(), "config", cs.Original)
+			stores = append(stores, s)
+		}
+	}
+	return stores
+}
+
+func diagnose(ctx context.Context, e *engine.Engine, cs engine.ConfiguredStore, s *Store, reviewer func() (client.Client, error)) {
+	if cs.Expanded == nil {
+		s.add(CheckTemplate, Error, cs.Err.Error(), fix(cs.Err, s.Domain, s.Config))
+		return
+	}
+	expanded := cs.Expanded
+	s.Expanded = expanded
+	s.add(CheckTemplate, OK, "expanded", "")
+
+	if cs.Store == nil {
+		s.add(CheckCreate, Error, cs.Err.Error(), fix(cs.Err, s.Domain, expanded))
+		return
+	}
+	s.add(CheckCreate, OK, "created", "")
+
+	probe(ctx, e, cs.Store, s)
+	if _, ok := expanded[config.StoreKeyMock]; ok {
+		s.add(CheckRBAC, Skipped, "mock store", "")
+		return
+	}
+	if _, ok := expanded[config.StoreKeyRemote]; ok {
+		s.add(CheckRBAC, Skipped, "permissions are checked by the remote korrel8r", "")
+		return
+	}
+	checkRBAC(ctx, s, reviewer)
+}
+
+// probe runs the probe query for the store domain.
+func probe(ctx context.Context, e *engine.Engine, store korrel8r.Store, s *Store) {
+	qs, ok := probes[s.Domain]
+	if !ok {
+		s.add(CheckProbe, Skipped, fmt.Sprintf("no probe query for domain %v", s.Domain), "")
+		return
+	}
+	q, err := e.Query(qs)
+	if err != nil {
+		s.add(CheckProbe, Error, err.Error(), "")
+		return
+	}
+	end := time.Now()
+	constraint := &korrel8r.Constraint{Limit: new(1), Start: new(end.Add(-probeDuration)), End: &end}
+	ctx, cancel := e.WithTimeout(ctx, 0)
+	defer cancel()
+	n := 0
+	if err := store.Get(ctx, q, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) { n += len(o) })); err != nil {
+		s.add(CheckProbe, Error, fmt.Sprintf("%v: %v", q, err), fix(err, s.Domain, s.Expanded))
+		return
+	}
+	s.add(CheckProbe, OK, fmt.Sprintf("%v: %v objects", q, n), "")
+}
+
+// permission needed by a store.
+type permission struct {
+	authv1.ResourceAttributes
+	// optional permissions give a warning if denied, not an error.
+	optional bool
+	fix      string
+}
+
+func (p permission) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v", p.Verb, p.Resource)
+	if p.Subresource != "" {
+		fmt.Fprintf(&b, "/%v", p.Subresource)
+	}
+	if p.Group != "" {
+		fmt.Fprintf(&b, ".%v", p.Group)
+	}
+	if p.Name != "" {
+		fmt.Fprintf(&b, " name=%v", p.Name)
+	}
+	if p.Namespace != "" {
+		fmt.Fprintf(&b, " namespace=%v", p.Namespace)
+	}
+	return b.String()
+}
+
+func checkRBAC(ctx context.Context, s *Store, reviewer func() (client.Client, error)) {
+	perms := permissions(s.Domain, s.Expanded)
+	if len(perms) == 0 {
+		s.add(CheckRBAC, Skipped, fmt.Sprintf("no known permissions for this %v store", s.Domain), "")
+		return
+	}
+	c, err := reviewer()
+	if err != nil {
+		s.add(CheckRBAC, Warning, fmt.Sprintf("cannot check permissions: %v", err), "")
+		return
+	}
+	for _, p := range perms {
+		sar := &authv1.SelfSubjectAccessReview{Spec: authv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &p.ResourceAttributes}}
+		switch err := c.Create(ctx, sar); {
+		case err != nil:
+			s.add(CheckRBAC, Warning, fmt.Sprintf("%v: access review failed: %v", p, err), "")
+		case sar.Status.Allowed:
+			s.add(CheckRBAC, OK, fmt.Sprintf("%v: allowed", p), "")
+		case p.optional:
+			s.add(CheckRBAC, Warning, fmt.Sprintf("%v: denied, some data will be missing", p), p.fix)
+		default:
+			s.add(CheckRBAC, Error, fmt.Sprintf("%v: denied", p), p.fix)
+		}
+	}
+}
+
+// Tenants of the LokiStack gateway used by each domain.
+var lokiTenants = map[string][]string{
+	"log":     {"application", "infrastructure", "audit"},
+	"netflow": {"network"},
+	"alert":   {"application", "infrastructure", "audit"},
+}
+
+// permissions returns the permissions needed by a store.
+func permissions(domain string, sc config.Store) (perms []permission) {
+	// lokiStack adds tenant permissions, the first tenant is required unless optional is true.
+	lokiStack := func(optional bool) {
+		for i, tenant := range lokiTenants[domain] {
+			perms = append(perms, permission{
+				ResourceAttributes: authv1.ResourceAttributes{Verb: "get", Group: "loki.grafana.com", Resource: tenant, Name: "logs"},
+				optional:           optional || i > 0,
+				fix:                lokiFix(tenant),
+			})
+		}
+	}
+	// monitoring adds permissions for the OpenShift monitoring stack, recognized by service or route name.
+	monitoring := func(u string) {
+		switch {
+		case strings.Contains(u, "thanos-querier"):
+			perms = append(perms, permission{
+				ResourceAttributes: authv1.ResourceAttributes{
+					Verb: "get", Group: "monitoring.coreos.com", Resource: "prometheuses", Subresource: "api",
+					Name: "k8s", Namespace: "openshift-monitoring",
+				},
+				fix: "oc adm policy add-cluster-role-to-user cluster-monitoring-view USER",
+			})
+		case strings.Contains(u, "alertmanager-main"):
+			perms = append(perms, permission{
+				ResourceAttributes: authv1.ResourceAttributes{
+					Verb: "get", Group: "monitoring.coreos.com", Resource: "alertmanagers", Subresource: "api",
+					Name: "main", Namespace: "openshift-monitoring",
+				},
+				optional: true,
+				fix:      "oc adm policy add-role-to-user monitoring-alertmanager-view USER -n openshift-monitoring",
+			})
+		}
+	}
+	switch domain {
+	case "k8s":
+		// Cluster-wide access is optional: a user with access to some namespaces sees the objects in those namespaces.
+		for _, resource := range []string{"pods", "events"} {
+			perms = append(perms, permission{
+				ResourceAttributes: authv1.ResourceAttributes{Verb: "list", Resource: resource},
+				optional:           true,
+				fix: "oc adm policy add-cluster-role-to-user cluster-reader USER" +
+					", or for one namespace: oc adm policy add-role-to-user view USER -n NAMESPACE",
+			})
+		}
+	case "log":
+		if sc[logs.StoreKeyLokiStack] != "" {
+			lokiStack(false)
+		}
+	case "netflow":
+		if sc[netflow.StoreKeyLokiStack] != "" {
+			lokiStack(false)
+		}
+	case "metric":
+		monitoring(sc[metric.StoreKeyMetricURL])
+	case "incident":
+		monitoring(sc[incident.StoreKeyMetrics])
+	case "alert":
+		monitoring(sc[alert.StoreKeyMetrics])
+		monitoring(sc[alert.StoreKeyAlertmanager])
+		if sc[alert.StoreKeyLokiRuler] != "" {
+			lokiStack(true) // Log alerts are optional.
+		}
+	case "trace":
+		if tenant := tempoTenant(sc[trace.StoreKeyTempoStack]); tenant != "" {
+			perms = append(perms, permission{
+				ResourceAttributes: authv1.ResourceAttributes{Verb: "get", Group: "tempo.grafana.com", Resource: tenant, Name: "traces"},
+				fix: fmt.Sprintf("Create a ClusterRole with rules [{apiGroups: [tempo.grafana.com], resources: [%v], resourceNames: [traces], verbs: [get]}]"+
+					" and bind it to the user", tenant),
+			})
+		}
+	}
+	return perms
+}
+
+func lokiFix(tenant string) string {
+	if tenant == "network" {
+		return "oc adm policy add-cluster-role-to-user netobserv-reader USER"
+	}
+	return fmt.Sprintf("oc adm policy add-cluster-role-to-user cluster-logging-%v-view USER", tenant)
+}
+
+// tempoTenant returns the tenant from a TempoStack gateway URL: .../api/traces/v1/TENANT/...
+func tempoTenant(tempoStack string) string {
+	u, err := url.Parse(tempoStack)
+	if err != nil {
+		return ""
+	}
+	_, after, ok := strings.Cut(u.Path, "/api/traces/v1/")
+	if !ok {
+		return ""
+	}
+	tenant, _, _ := strings.Cut(after, "/")
+	return tenant
+}
+
+// fix suggests a fix for a store error.
+func fix(err error, domain string, sc config.Store) string {
+	msg := strings.ToLower(err.Error())
+	has := func(s ...string) bool {
+		for _, x := range s {
+			if strings.Contains(msg, x) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("route/") && has("not found"):
+		return "Check the route namespace and name in the store template, and that the store is installed." +
+			" Inside the cluster, use service URLs instead of routes (see etc/korrel8r/openshift-svc.yaml)."
+	case has("x509:", "certificate"):
+		return fmt.Sprintf("Set store field %q to the CA certificate that signed the server certificate,"+
+			" for example /var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt for in-cluster services.", config.StoreKeyCA)
+	case has("no such host"):
+		return "Check the host in the store URL. Outside the cluster, use route hosts (see etc/korrel8r/openshift-route.yaml)," +
+			" inside the cluster use service hosts (see etc/korrel8r/openshift-svc.yaml)."
+	case has("connection refused"):
+		return "Nothing is listening at the store URL: check that the store is installed and the port is correct."
+	case has("deadline exceeded", "timeout"):
+		return "Check routes and network policies between korrel8r and the store, or increase tuning.requestTimeout."
+	case has("401", "unauthorized"):
+		return fmt.Sprintf("The store rejected the credentials: check the caller's token, or set store field %q to %q to always forward it.",
+			config.StoreKeyAuth, config.StoreAuthToken)
+	case has("403", "forbidden"):
+		return "The caller is not allowed to use the store, see the rbac findings for missing permissions."
+	case has("404", "not found"):
+		switch {
+		case sc[logs.StoreKeyLokiStack] != "":
+			return "Check the lokiStack URL: it should be the LokiStack gateway base URL, without /api/logs/v1/TENANT."
+		case domain == "trace":
+			return "Check the tempoStack URL, it should have the form https://HOST/api/traces/v1/TENANT/tempo/api/search."
+		default:
+			return "Check the path in the store URL."
+		}
+	case has("must set", "can't set", "invalid store"):
+		return fmt.Sprintf("Check the %v store fields, see the domain reference documentation.", domain)
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newReviewer allows access to the listed resources.
+func newReviewer(allow ...string) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			sar := obj.(*authv1.SelfSubjectAccessReview)
+			for _, r := range allow {
+				sar.Status.Allowed = sar.Status.Allowed || r == sar.Spec.ResourceAttributes.Resource
+			}
+			return nil
+		},
+	}).Build()
+}
+
+// findings returns a map of check name to finding.
+func findings(s Store) map[string]Finding {
+	m := map[string]Finding{}
+	for _, f := range s.Findings {
+		if prev, ok := m[f.Check]; !ok || f.Level.severity() > prev.Level.severity() {
+			m[f.Check] = f
+		}
+	}
+	return m
+}
+
+func TestProbes(t *testing.T) {
+	e, err := engine.Build().Domains(domains.All...).Engine()
+	require.NoError(t, err)
+	for domain, probe := range probes {
+		q, err := e.Query(probe)
+		if assert.NoError(t, err) {
+			assert.Equal(t, domain, q.Class().Domain().Name())
+		}
+	}
+}
+
+func TestCheckRBAC_k8s(t *testing.T) {
+	// Users without cluster-wide access can still see their own namespaces.
+	s := &Store{Domain: "k8s"}
+	checkRBAC(context.Background(), s, func() (client.Client, error) { return newReviewer(), nil })
+	f := findings(*s)
+	assert.Equal(t, Warning, f[CheckRBAC].Level)
+	assert.Contains(t, f[CheckRBAC].Message, "list pods: denied, some data will be missing")
+	assert.Contains(t, f[CheckRBAC].Fix, "-n NAMESPACE")
+}
+
+func TestDiagnose(t *testing.T) {
+	status := http.StatusForbidden
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(status), status)
+	}))
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSrv.Close()
+
+	// Stores need a kube config for authentication, it is not used to connect.
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`
+apiVersion: v1
+kind: Config
+clusters: [{name: test, cluster: {server: "`+srv.URL+`"}}]
+users: [{name: test, user: {token: test}}]
+contexts: [{name: test, context: {cluster: test, user: test}}]
+current-context: test
+`), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	mockDir := t.TempDir()
+	e, err := engine.Build().Domains(append(domains.All, mock.NewDomain("mock", "thing"))...).StoreConfigs(
+		config.Store{"domain": "log", "lokiStack": srv.URL},
+		config.Store{"domain": "log", "lokiStack": tlsSrv.URL},
+		config.Store{"domain": "trace", "tempoStack": srv.URL + "/api/traces/v1/platform/tempo/api/search"},
+		config.Store{"domain": "trace"},
+		config.Store{"domain": "metric", "metric": `{{fail "no route"}}`},
+		config.Store{"domain": "mock", "mockData": mockDir},
+	).Engine()
+	require.NoError(t, err)
+	stores := Diagnose(context.Background(), e, nil, newReviewer("application"))
+	byConfig := map[string]Store{}
+	for _, s := range stores {
+		byConfig[s.Config["lokiStack"]+s.Config["tempoStack"]+s.Config["metric"]+s.Config["mockData"]+s.Domain] = s
+	}
+	require.Len(t, byConfig, 6)
+
+	t.Run("forbidden", func(t *testing.T) {
+		s := byConfig[srv.URL+"log"]
+		assert.Equal(t, Error, s.Level())
+		f := findings(s)
+		assert.Equal(t, OK, f[CheckCreate].Level)
+		assert.Equal(t, Error, f[CheckProbe].Level)
+		assert.Contains(t, f[CheckProbe].Fix, "rbac findings")
+		assert.Equal(t, Warning, f[CheckRBAC].Level, "only optional tenants are denied")
+		assert.Contains(t, f[CheckRBAC].Fix, "cluster-logging-")
+	})
+	t.Run("tls", func(t *testing.T) {
+		f := findings(byConfig[tlsSrv.URL+"log"])
+		assert.Equal(t, Error, f[CheckProbe].Level)
+		assert.Contains(t, f[CheckProbe].Fix, config.StoreKeyCA)
+	})
+	t.Run("tempo tenant", func(t *testing.T) {
+		f := findings(byConfig[srv.URL+"/api/traces/v1/platform/tempo/api/search"+"trace"])
+		assert.Equal(t, Error, f[CheckRBAC].Level)
+		assert.Contains(t, f[CheckRBAC].Message, "get platform.tempo.grafana.com name=traces: denied")
+	})
+	t.Run("create", func(t *testing.T) {
+		s := byConfig["trace"]
+		f := findings(s)
+		assert.Equal(t, Error, f[CheckCreate].Level)
+		assert.Contains(t, f[CheckCreate].Fix, "trace store fields")
+		assert.NotContains(t, f, CheckProbe)
+	})
+	t.Run("template", func(t *testing.T) {
+		s := byConfig[`{{fail "no route"}}metric`]
+		f := findings(s)
+		assert.Equal(t, Error, f[CheckTemplate].Level)
+		assert.Contains(t, f[CheckTemplate].Message, "no route")
+		assert.Nil(t, s.Expanded)
+		assert.Len(t, s.Findings, 1)
+	})
+	t.Run("mock", func(t *testing.T) {
+		s := byConfig[mockDir+"mock"]
+		assert.Equal(t, Skipped, s.Level())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		status = http.StatusNotFound
+		d, err := e.Domain("trace")
+		require.NoError(t, err)
+		s := Diagnose(context.Background(), e, []korrel8r.Domain{d}, newReviewer("platform"))
+		require.Len(t, s, 2)
+		f := findings(s[0])
+		assert.Contains(t, f[CheckProbe].Fix, "/api/traces/v1/TENANT/tempo/api/search")
+		assert.Equal(t, OK, f[CheckRBAC].Level)
+	})
+
+	t.Run("engine stores", func(t *testing.T) {
+		// Diagnose uses the engine stores, a failed store is not re-created before the retry interval.
+		d, err := e.Domain("metric")
+		require.NoError(t, err)
+		s := Diagnose(context.Background(), e, []korrel8r.Domain{d}, nil)
+		require.Len(t, s, 1)
+		assert.Equal(t, Error, findings(s[0])[CheckTemplate].Level)
+		assert.Equal(t, "1", e.StoreConfigsFor(d)[0][config.StoreKeyErrorCount])
+	})
+}
+
+func TestFix(t *testing.T) {
+	for _, x := range []struct {
+		err, want string
+	}{
+		{`route/thanos-querier namespace=openshift-monitoring: not found`, "route namespace and name"},
+		{`dial tcp: lookup foo.example: no such host`, "host in the store URL"},
+		{`dial tcp 127.0.0.1:9: connect: connection refused`, "Nothing is listening"},
+		{`context deadline exceeded`, "requestTimeout"},
+		{`401 Unauthorized`, `"auth" to "token"`},
+		{`something else`, ""},
+	} {
+		t.Run(x.err, func(t *testing.T) {
+			got := fix(errors.New(x.err), "metric", config.Store{})
+			if x.want == "" {
+				assert.Empty(t, got)
+			} else {
+				assert.Contains(t, got, x.want)
+			}
+		})
+	}
+}
@@ -6,16 +6,22 @@ package engine
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"sync/atomic"
 	"text/template"
 	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/authz"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/redact"
+	"github.com/korrel8r/korrel8r/pkg/remote"
 	"github.com/korrel8r/korrel8r/pkg/status"
+	"github.com/korrel8r/korrel8r/pkg/unique"
 	"go.opentelemetry.io/otel/metric"
 )
 
@@ -31,18 +37,28 @@ type Engine struct {
 	rulesByName   map[string]korrel8r.Rule
 	rules         []korrel8r.Rule
 	statuses      map[string][]status.Rule // Keyed by class.String()
+	recipes       map[string]*Recipe       // Keyed by recipe name.
+	crossCluster  unique.Set[string]       // Names of rules that cross clusters.
 	data          *graph.Data              // Immutable rule graph data, built once.
+	authz         *authz.Filter            // Drops objects the caller may not see, nil if not enabled.
+	redactor      *redact.Redactor         // Redacts returned objects, nil if not enabled.
 
 	// Tuning parameters
 	Tuning config.Tuning
 
 	// Pre-calculated metric attributes per domain, indexed by [domain][status=="error"]
 	storeMetricAttrs map[string][2]metric.MeasurementOption
+
+	// Error from a failed attempt to replace this engine with a new configuration.
+	reloadErr atomic.Pointer[error]
 }
 
 func (e *Engine) Domain(name string) (korrel8r.Domain, error) { return e.domains.Domain(name) }
 func (e *Engine) Domains() []korrel8r.Domain                  { return e.domains.List() }
 
+// Redactor returns the redactor for objects returned to clients, nil if redaction is not enabled.
+func (e *Engine) Redactor() *redact.Redactor { return e.redactor }
+
 // StoreFor returns the aggregated store for a domain, nil if there is none.
 func (e *Engine) StoreFor(d korrel8r.Domain) korrel8r.Store {
 	s := e.storeHolders[d]
@@ -63,7 +79,52 @@ func (e *Engine) StoresFor(d korrel8r.Domain) []korrel8r.Store {
 // StoreConfigsFor returns the expanded store configurations and status.
 func (e *Engine) StoreConfigsFor(d korrel8r.Domain) []config.Store {
 	if ss, ok := e.storeHolders[d]; ok {
-		return ss.Configs()
+		scs := ss.Configs()
+		if err := e.ReloadError(); err != nil {
+			for _, sc := range scs {
+				sc[config.StoreKeyReloadError] = err.Error()
+			}
+		}
+		return scs
+	}
+	return nil
+}
+
+// StoreTemplatesFor returns the store configurations for a domain before template expansion.
+// Stores added directly as [korrel8r.Store] values are not included.
+func (e *Engine) StoreTemplatesFor(d korrel8r.Domain) []config.Store {
+	if ss, ok := e.storeHolders[d]; ok {
+		return ss.Originals()
+	}
+	return nil
+}
+
+// ConfiguredStoresFor returns the stores created from configurations for a domain.
+// Stores are created if needed and shared with queries, so a store that failed is not re-created
+// before the store retry interval, see [config.Tuning.StoreRetryInterval].
+// Stores added directly as [korrel8r.Store] values are not included.
+func (e *Engine) ConfiguredStoresFor(d korrel8r.Domain) []ConfiguredStore {
+	if ss, ok := e.storeHolders[d]; ok {
+		return ss.Configured()
+	}
+	return nil
+}
+
+// SetReloadError records an error from a failed attempt to replace this engine with a new configuration.
+// The engine continues to run with its original configuration, the error is reported as store status.
+// Setting nil clears the error.
+func (e *Engine) SetReloadError(err error) {
+	if err == nil {
+		e.reloadErr.Store(nil)
+	} else {
+		e.reloadErr.Store(&err)
+	}
+}
+
+// ReloadError returns the error set by [Engine.SetReloadError], or nil.
+func (e *Engine) ReloadError() error {
+	if err := e.reloadErr.Load(); err != nil {
+		return *err
 	}
 	return nil
 }
@@ -126,6 +187,53 @@ func (e *Engine) Rules() []korrel8r.Rule { return slices.Clone(e.rules) }
 
 func (e *Engine) Rule(name string) korrel8r.Rule { return e.rulesByName[name] }
 
+// CrossCluster is true if rule r may find goal objects in any cluster.
+// Other rules only search the cluster of the start object.
+func (e *Engine) CrossCluster(r korrel8r.Rule) bool { return e.crossCluster.Has(r.Name()) }
+
+// Remote returns a client for the remote korrel8r that serves cluster, or nil if there is none.
+// A cluster is served by a remote korrel8r if all stores for the cluster forward to the same remote korrel8r.
+// Stores with no cluster serve every cluster, so a cluster with local stores is never served remotely.
+// Searches restricted to the cluster can be forwarded to the remote korrel8r as a whole.
+// Only the remote store that is returned is created.
+func (e *Engine) Remote(cluster string) *remote.Client {
+	if cluster == "" {
+		return nil
+	}
+	var r *storeHolder
+	for _, ss := range e.storeHolders {
+		s, ok := ss.remote(cluster)
+		if !ok || (r != nil && s != nil && r.Original[config.StoreKeyRemote] != s.Original[config.StoreKeyRemote]) {
+			return nil
+		}
+		if s != nil {
+			r = s
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	ks, err := r.Ensure()
+	if rs, ok := ks.(*remote.Store); ok && err == nil {
+		return rs.Client
+	}
+	return nil
+}
+
+// Clusters returns the sorted, unique cluster names of all configured stores.
+func (e *Engine) Clusters() []string {
+	clusters := unique.NewList[string]()
+	for _, ss := range e.storeHolders {
+		for _, s := range ss.stores {
+			if c := s.Cluster(); c != "" {
+				clusters.Add(c)
+			}
+		}
+	}
+	slices.Sort(clusters.List)
+	return clusters.List
+}
+
 // StatusRulesFor returns the status rules for the given class.
 func (e *Engine) StatusRulesFor(c korrel8r.Class) []status.Rule { return e.statuses[c.String()] }
 
@@ -134,7 +242,42 @@ func (e *Engine) StatusRulesFor(c korrel8r.Class) []status.Rule { return e.statu
 func (e *Engine) Graph() *graph.Graph { return e.data.SharedGraph() }
 
 // Get results for query from all stores for the query domain.
+// If constraint has a cluster, only stores for that cluster and stores with no cluster are used.
+//
+// If authorization is configured, objects the caller is not allowed to see are dropped,
+// and the returned error includes an [authz.DroppedError]. Use [authz.Fatal] to ignore it.
 func (e *Engine) Get(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, result korrel8r.Appender) (err error) {
+	return e.GetClusters(ctx, query, constraint, func(_ string, o ...korrel8r.Object) { result.Append(o...) })
+}
+
+// GetClusters is like [Engine.Get] but passes the cluster of the originating store with each batch of objects.
+// The cluster is "" for stores that are not tagged with a cluster.
+func (e *Engine) GetClusters(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, result func(cluster string, o ...korrel8r.Object)) error {
+	if e.authz == nil {
+		return e.getClusters(ctx, query, constraint, result)
+	}
+	var dropped *authz.DroppedError
+	err := e.getClusters(ctx, query, constraint, func(cluster string, o ...korrel8r.Object) {
+		o, err := e.authz.Filter(ctx, query.Class(), o)
+		if d, ok := err.(*authz.DroppedError); ok {
+			if dropped == nil {
+				dropped = d
+			} else {
+				dropped.Add(d)
+			}
+		}
+		if len(o) > 0 {
+			result(cluster, o...)
+		}
+	})
+	if dropped != nil {
+		return errors.Join(err, dropped)
+	}
+	return err
+}
+
+// getClusters gets results without authorization filtering.
+func (e *Engine) getClusters(ctx context.Context, query korrel8r.Query, constraint *korrel8r.Constraint, result func(cluster string, o ...korrel8r.Object)) (err error) {
 	count := 0
 	constraint = constraint.Default()
 	domain := query.Class().Domain().Name()
@@ -158,10 +301,10 @@ func (e *Engine) Get(ctx context.Context, query korrel8r.Query, constraint *korr
 			log.V(5).Info("Get", "count", count, "query", query, "constraint", constraint, "latency", latency)
 		}
 	}()
-	return ss.Get(ctx, query, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) {
+	return ss.getClusters(ctx, query, constraint, func(cluster string, o ...korrel8r.Object) {
 		count += len(o)
-		result.Append(o...)
-	}))
+		result(cluster, o...)
+	})
 }
 
 // NewTemplate returns a template set up with options, funcs and named templates for this engine.
@@ -5,6 +5,8 @@ package engine_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 	"time"
@@ -156,6 +158,18 @@ func TestEngineStoreFor(t *testing.T) {
 	assert.ElementsMatch(t, []korrel8r.Object{"help", "me"}, r.List())
 }
 
+func TestEngine_ReloadError(t *testing.T) {
+	d := mock.NewDomain("mock")
+	e, err := engine.Build().Domains(d).StoreConfigs(config.Store{"domain": "mock", "x": "y"}).Engine()
+	require.NoError(t, err)
+	assert.Equal(t, []config.Store{{"domain": "mock", "x": "y"}}, e.StoreConfigsFor(d))
+
+	e.SetReloadError(fmt.Errorf("bad config"))
+	assert.Equal(t, []config.Store{{"domain": "mock", "x": "y", config.StoreKeyReloadError: "bad config"}}, e.StoreConfigsFor(d))
+	e.SetReloadError(nil)
+	assert.NoError(t, e.ReloadError())
+}
+
 func TestEngine_LabelersFor(t *testing.T) {
 	d := mock.NewDomain("mock", "a", "b")
 	a := d.Class("a")
@@ -284,3 +298,95 @@ type obj struct {
 	Name string
 	Time time.Time
 }
+
+func TestEngine_Clusters(t *testing.T) {
+	d := mock.NewDomain("mock", "foo")
+	dir := t.TempDir()
+	store := func(cluster, data string) config.Store {
+		file := filepath.Join(dir, cluster+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(data), 0o644))
+		sc := config.Store{config.StoreKeyDomain: "mock", config.StoreKeyMock: file}
+		if cluster != "shared" {
+			sc[config.StoreKeyCluster] = cluster
+		}
+		return sc
+	}
+	e, err := engine.Build().Domains(d).StoreConfigs(
+		store("east", `"mock:foo:x": ["e"]`),
+		store("west", `"mock:foo:x": ["w"]`),
+		store("shared", `"mock:foo:x": ["s"]`),
+	).Config(config.Configs{{Rules: []config.Rule{
+		{Name: "same", Start: config.ClassSpec{Domain: "mock"}, Goal: config.ClassSpec{Domain: "mock"}, Result: config.ResultSpec{Query: "mock:foo:x"}},
+		{Name: "cross", Start: config.ClassSpec{Domain: "mock"}, Goal: config.ClassSpec{Domain: "mock"}, Result: config.ResultSpec{Query: "mock:foo:x"}, CrossCluster: true},
+	}}}).Engine()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"east", "west"}, e.Clusters())
+	assert.False(t, e.CrossCluster(e.Rule("same")))
+	assert.True(t, e.CrossCluster(e.Rule("cross")))
+
+	q := mock.NewQuery(d.Class("foo"), "x")
+	for _, x := range []struct {
+		cluster string
+		want    []korrel8r.Object
+	}{
+		{"", []korrel8r.Object{"e", "w", "s"}},
+		{"east", []korrel8r.Object{"e", "s"}},
+		{"west", []korrel8r.Object{"w", "s"}},
+		{"nonesuch", []korrel8r.Object{"s"}},
+	} {
+		t.Run(x.cluster, func(t *testing.T) {
+			r := &mock.Result{}
+			require.NoError(t, e.Get(context.Background(), q, &korrel8r.Constraint{Cluster: x.cluster}, r))
+			assert.ElementsMatch(t, x.want, r.List())
+		})
+	}
+
+	got := map[korrel8r.Object]string{}
+	require.NoError(t, e.GetClusters(context.Background(), q, nil, func(cluster string, o ...korrel8r.Object) {
+		for _, o := range o {
+			got[o] = cluster
+		}
+	}))
+	assert.Equal(t, map[korrel8r.Object]string{"e": "east", "w": "west", "s": ""}, got)
+
+	e, err = engine.Build().Domains(d).StoreConfigs(store("east", `"mock:foo:x": ["e"]`)).Engine()
+	require.NoError(t, err)
+	assert.EqualError(t, e.Get(context.Background(), q, &korrel8r.Constraint{Cluster: "west"}, &mock.Result{}), "no stores found for cluster west")
+}
+
+func TestEngine_Remote(t *testing.T) {
+	d, d2 := mock.NewDomain("mock", "foo"), mock.NewDomain("other", "bar")
+	remote := func(domain, cluster, url string) config.Store {
+		return config.Store{config.StoreKeyDomain: domain, config.StoreKeyCluster: cluster, config.StoreKeyRemote: url}
+	}
+	local := func(domain, cluster string) config.Store {
+		file := filepath.Join(t.TempDir(), "data.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`{}`), 0o644))
+		sc := config.Store{config.StoreKeyDomain: domain, config.StoreKeyMock: file}
+		if cluster != "" {
+			sc[config.StoreKeyCluster] = cluster
+		}
+		return sc
+	}
+	for _, x := range []struct {
+		name   string
+		stores []config.Store
+		want   string
+	}{
+		{"remote", []config.Store{local("mock", "hub"), remote("mock", "spoke", "https://spoke.example"), remote("other", "spoke", "https://spoke.example")}, "https://spoke.example"},
+		{"no stores", []config.Store{local("mock", "hub")}, ""},
+		{"local", []config.Store{local("mock", "spoke"), remote("other", "spoke", "https://spoke.example")}, ""},
+		{"untagged local", []config.Store{local("mock", ""), remote("mock", "spoke", "https://spoke.example")}, ""},
+		{"different remotes", []config.Store{remote("mock", "spoke", "https://spoke.example"), remote("other", "spoke", "https://other.example")}, ""},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			e, err := engine.Build().Domains(d, d2).StoreConfigs(x.stores...).Engine()
+			require.NoError(t, err)
+			var got string
+			if r := e.Remote("spoke"); r != nil {
+				got = r.URL()
+			}
+			assert.Equal(t, x.want, got)
+		})
+	}
+}
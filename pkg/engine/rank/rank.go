@@ -0,0 +1,154 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package rank scores objects by relevance to the start of a search.
+//
+// The score of an object is between 0 and 1, it is a weighted sum of:
+//
+//   - time: objects close in time to the start objects score higher, if both classes implement [korrel8r.Timer].
+//     The time score halves for every [HalfLife] between the object and the latest start object.
+//   - status: objects with statuses from status rules score higher, error statuses more than warnings.
+//   - class: the score from [korrel8r.Scorer], if the object class implements it.
+//
+// Scores are computed for every node of a ranked search graph by the traverser, see [graph.Node.Scores].
+//
+// Objects are scored before the limit is applied: a ranked search uses the [Fetch] constraint,
+// so stores return more objects than the limit, and the traverser keeps the [Limit] best objects in each node
+// before applying rules to them.
+package rank
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Weights of the score components, they add up to 1.
+const (
+	TimeWeight   = 0.4
+	StatusWeight = 0.4
+	ClassWeight  = 0.2
+)
+
+// HalfLife is the time distance from the start objects that halves the time score.
+const HalfLife = 5 * time.Minute
+
+// FetchFactor is how many times the limit of a constraint are fetched from stores for ranking, see [Fetch].
+const FetchFactor = 10
+
+// MaxFetch caps the number of objects fetched from a store for ranking, see [Fetch].
+const MaxFetch = 1000
+
+// Fetch returns a copy of c to get objects that will be ranked.
+// The limit is FetchFactor times the [Limit] of c, capped at MaxFetch but never less than the [Limit] of c.
+// The limit is 0 (no limit) if c has a 0 limit.
+func Fetch(c *korrel8r.Constraint) *korrel8r.Constraint {
+	var fetch korrel8r.Constraint
+	if c != nil {
+		fetch = *c
+	}
+	limit := Limit(c)
+	fetch.Limit = new(max(min(limit*FetchFactor, MaxFetch), limit))
+	return &fetch
+}
+
+// Limit returns the number of objects per node to keep after ranking: the limit of c,
+// or the default limit if c has none, see [korrel8r.Constraint.Default].
+func Limit(c *korrel8r.Constraint) int {
+	if c != nil && c.Limit != nil {
+		return *c.Limit
+	}
+	return (&korrel8r.Constraint{}).Default().GetLimit()
+}
+
+// Ranker scores objects for a search.
+type Ranker struct {
+	engine *engine.Engine
+	// ref is the reference time for time proximity, zero if the start objects have no time.
+	ref time.Time
+}
+
+// New returns a Ranker for a search that started from objects of class start.
+// The reference time is the latest time of the start objects.
+func New(e *engine.Engine, start korrel8r.Class, objects []korrel8r.Object) *Ranker {
+	r := &Ranker{engine: e}
+	for _, o := range objects {
+		if t := Time(start, o); t.After(r.ref) {
+			r.ref = t
+		}
+	}
+	return r
+}
+
+// Score returns the relevance of o, an object of class c.
+func (r *Ranker) Score(c korrel8r.Class, o korrel8r.Object) float64 {
+	score := StatusWeight * r.status(c, o)
+	if t := Time(c, o); !t.IsZero() && !r.ref.IsZero() {
+		score += TimeWeight * math.Exp2(-math.Abs(float64(t.Sub(r.ref)))/float64(HalfLife))
+	}
+	if s, ok := c.(korrel8r.Scorer); ok {
+		score += ClassWeight * min(max(s.Score(o), 0), 1)
+	}
+	return score
+}
+
+// Scores returns the score of each object, in the same order.
+func (r *Ranker) Scores(c korrel8r.Class, objects []korrel8r.Object) []float64 {
+	scores := make([]float64, len(objects))
+	for i, o := range objects {
+		scores[i] = r.Score(c, o)
+	}
+	return scores
+}
+
+// status returns the highest status level of an object from the status rules for its class.
+func (r *Ranker) status(c korrel8r.Class, o korrel8r.Object) (level float64) {
+	for _, s := range Statuses(r.engine, c, o) {
+		level = max(level, StatusLevel(s))
+	}
+	return level
+}
+
+// Statuses returns the distinct statuses of o, an object of class c, from the status rules for c.
+func Statuses(e *engine.Engine, c korrel8r.Class, o korrel8r.Object) (statuses []string) {
+	for _, sr := range e.StatusRulesFor(c) {
+		found, _ := sr.Apply(o)
+		for _, s := range found {
+			if s != "" && !slices.Contains(statuses, s) {
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	return statuses
+}
+
+// StatusLevel returns 1 for error statuses, 0.5 for warnings and 0.25 for other statuses.
+// Status names are compared without case, so alert severities like "critical" are recognized.
+// Pod failure reasons like "CrashLoopBackOff" or "OOMKilled" from the k8s ContainerStatus rule are errors.
+// Metric anomalies like "Spike" or "Gap" from the metric MetricAnomaly rule are warnings.
+func StatusLevel(status string) float64 {
+	switch strings.ToLower(status) {
+	case "":
+		return 0
+	case "error", "critical", "fatal", "failed",
+		"crashloopbackoff", "imagepullbackoff", "errimagepull", "invalidimagename",
+		"createcontainerconfigerror", "createcontainererror", "runcontainererror",
+		"oomkilled", "containercannotrun", "deadlineexceeded", "evicted":
+		return 1
+	case "warning", "spike", "drop", "flatline", "gap":
+		return 0.5
+	default:
+		return 0.25
+	}
+}
+
+// Time returns the time of o if class c implements [korrel8r.Timer], the zero time otherwise.
+func Time(c korrel8r.Class, o korrel8r.Object) time.Time {
+	if t, ok := c.(korrel8r.Timer); ok {
+		return t.Time(o)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,104 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rank_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rank"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// event is a mock object with a time.
+type event struct {
+	Msg string
+	At  time.Time
+}
+
+func (e event) Timestamp() time.Time { return e.At }
+
+// scored is a class that scores "interesting" objects.
+type scored struct{ korrel8r.Class }
+
+func (scored) Score(o korrel8r.Object) float64 {
+	if o.(event).Msg == "interesting" {
+		return 1
+	}
+	return 0
+}
+
+func newEngine(t *testing.T) (*engine.Engine, *mock.Domain) {
+	t.Helper()
+	d := mock.NewDomain("mock", "alert", "log")
+	e, err := engine.Build().Domains(d).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "severity",
+			Start:  config.ClassSpec{Domain: "mock", Classes: []string{"log"}},
+			Status: `{{if eq .Msg "boom"}}Error{{else if eq .Msg "hmm"}}warning{{end}}`,
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	return e, d
+}
+
+func TestRanker_time(t *testing.T) {
+	e, d := newEngine(t)
+	now := time.Now()
+	r := rank.New(e, d.Class("alert"), []korrel8r.Object{event{At: now.Add(-time.Hour)}, event{At: now}})
+	log := d.Class("log")
+	at := func(dt time.Duration) float64 { return r.Score(log, event{At: now.Add(dt)}) }
+	assert.InDelta(t, rank.TimeWeight, at(0), 1e-9, "latest start time is the reference")
+	assert.InDelta(t, rank.TimeWeight/2, at(rank.HalfLife), 1e-9)
+	assert.InDelta(t, rank.TimeWeight/2, at(-rank.HalfLife), 1e-9)
+	assert.Greater(t, at(time.Minute), at(time.Hour))
+	assert.Zero(t, r.Score(log, "no time"))
+
+	r = rank.New(e, d.Class("alert"), []korrel8r.Object{"no time"})
+	assert.Zero(t, r.Score(log, event{At: now}), "no reference time")
+}
+
+func TestRanker_status(t *testing.T) {
+	e, d := newEngine(t)
+	r := rank.New(e, d.Class("alert"), nil)
+	log := d.Class("log")
+	assert.Equal(t, []float64{rank.StatusWeight, rank.StatusWeight / 2, 0},
+		r.Scores(log, []korrel8r.Object{event{Msg: "boom"}, event{Msg: "hmm"}, event{Msg: "ok"}}))
+	assert.Zero(t, r.Score(d.Class("alert"), event{Msg: "boom"}), "no status rules for class")
+}
+
+func TestRanker_scorer(t *testing.T) {
+	e, d := newEngine(t)
+	r := rank.New(e, d.Class("alert"), nil)
+	c := scored{d.Class("log")}
+	assert.InDelta(t, rank.ClassWeight, r.Score(c, event{Msg: "interesting"}), 1e-9)
+	assert.InDelta(t, rank.StatusWeight, r.Score(c, event{Msg: "boom"}), 1e-9)
+}
+
+func TestStatusLevel(t *testing.T) {
+	for status, want := range map[string]float64{
+		"Error": 1, "critical": 1, "CrashLoopBackOff": 1, "OOMKilled": 1, "Warning": 0.5, "Spike": 0.5, "Gap": 0.5, "Finalizer": 0.25, "": 0,
+	} {
+		assert.Equal(t, want, rank.StatusLevel(status), status)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	start := time.Now()
+	c := &korrel8r.Constraint{Limit: new(5), Start: &start, Cluster: "east"}
+	fetch := rank.Fetch(c)
+	assert.Equal(t, &korrel8r.Constraint{Limit: new(5 * rank.FetchFactor), Start: &start, Cluster: "east"}, fetch)
+	assert.Equal(t, 5, *c.Limit, "original unchanged")
+	assert.Equal(t, 5, rank.Limit(c))
+
+	assert.Equal(t, 100, rank.Limit(nil), "default limit")
+	assert.Equal(t, 100*rank.FetchFactor, rank.Fetch(nil).GetLimit())
+	assert.Equal(t, 0, rank.Fetch(&korrel8r.Constraint{Limit: new(0)}).GetLimit(), "no limit")
+	assert.Equal(t, rank.MaxFetch, rank.Fetch(&korrel8r.Constraint{Limit: new(500)}).GetLimit(), "capped")
+	assert.Equal(t, 2000, rank.Fetch(&korrel8r.Constraint{Limit: new(2000)}).GetLimit(), "at least the limit")
+}
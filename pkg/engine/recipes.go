@@ -0,0 +1,251 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/unique"
+)
+
+// Recipe is a validated recipe from the configuration.
+type Recipe struct {
+	config.Recipe
+	e       *Engine
+	class   korrel8r.Class
+	goals   []korrel8r.Class
+	queries []*template.Template
+}
+
+// Class returns the start class, or nil if the start class is the class of the first query.
+func (r *Recipe) Class() korrel8r.Class { return r.class }
+
+// Goals returns the goal classes, empty for a neighbors search.
+func (r *Recipe) Goals() []korrel8r.Class { return r.goals }
+
+// Queries applies params to the start query templates.
+// Parameters with a default value may be omitted, unknown parameters are an error.
+// All queries must have the same class.
+func (r *Recipe) Queries(params map[string]string) ([]korrel8r.Query, error) {
+	data := map[string]string{}
+	for _, p := range r.Params {
+		v, ok := params[p.Name]
+		switch {
+		case ok:
+			data[p.Name] = v
+		case p.Default != "":
+			data[p.Name] = p.Default
+		default:
+			return nil, fmt.Errorf("recipe %v: missing parameter %q", r.Name, p.Name)
+		}
+	}
+	for name := range params {
+		if _, ok := data[name]; !ok {
+			return nil, fmt.Errorf("recipe %v: unknown parameter %q", r.Name, name)
+		}
+	}
+	queries, err := r.apply(data)
+	if err != nil {
+		return nil, fmt.Errorf("recipe %v: %w", r.Name, err)
+	}
+	return queries, nil
+}
+
+// apply executes the start query templates with data.
+func (r *Recipe) apply(data map[string]string) ([]korrel8r.Query, error) {
+	var queries []korrel8r.Query
+	for _, t := range r.queries {
+		var w strings.Builder
+		if err := t.Execute(&w, data); err != nil {
+			return nil, err
+		}
+		q, err := r.e.Query(strings.TrimSpace(w.String()))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", t.Name(), err)
+		}
+		if len(queries) > 0 && q.Class() != queries[0].Class() || r.class != nil && q.Class() != r.class {
+			return nil, fmt.Errorf("%v: query has wrong class: %v", t.Name(), q)
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+// Constraint returns the recipe constraint relative to now, nil if there is none.
+func (r *Recipe) Constraint(now time.Time) *korrel8r.Constraint {
+	rc := r.Recipe.Constraint
+	if rc == nil {
+		return nil
+	}
+	c := &korrel8r.Constraint{}
+	if rc.Since > 0 {
+		c.Start = new(now.Add(-time.Duration(rc.Since)))
+	}
+	if rc.Limit > 0 {
+		c.Limit = new(rc.Limit)
+	}
+	if rc.QueryLimit > 0 {
+		c.QueryLimit = new(rc.QueryLimit)
+	}
+	return c
+}
+
+// Recipes returns all recipes, sorted by name.
+func (e *Engine) Recipes() []*Recipe {
+	recipes := make([]*Recipe, 0, len(e.recipes))
+	for _, r := range e.recipes {
+		recipes = append(recipes, r)
+	}
+	slices.SortFunc(recipes, func(a, b *Recipe) int { return strings.Compare(a.Name, b.Name) })
+	return recipes
+}
+
+// Recipe returns the named recipe.
+func (e *Engine) Recipe(name string) (*Recipe, error) {
+	if r := e.recipes[name]; r != nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("recipe not found: %v", name)
+}
+
+// checkFields returns an error if a template refers to a field that is not a parameter name.
+// Fields of "$" always refer to parameters. Fields of "." refer to parameters only if dot is true:
+// inside the body of "range" and "with" dot is the range or with value, so its fields are not checked.
+// Named templates invoked with {{template}} are shared with rules and are not checked.
+func checkFields(n parse.Node, params unique.Set[string], dot bool) error {
+	var nodes []parse.Node
+	check := func(name string) error {
+		if !params.Has(name) {
+			return fmt.Errorf("undeclared parameter %q", name)
+		}
+		return nil
+	}
+	switch n := n.(type) {
+	case *parse.FieldNode:
+		if dot {
+			return check(n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			return check(n.Ident[1])
+		}
+	case *parse.ListNode:
+		nodes = n.Nodes
+	case *parse.ActionNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.PipeNode:
+		for _, c := range n.Cmds {
+			nodes = append(nodes, c)
+		}
+	case *parse.CommandNode:
+		nodes = n.Args
+	case *parse.ChainNode:
+		nodes = []parse.Node{n.Node}
+	case *parse.IfNode:
+		nodes = []parse.Node{n.Pipe, n.List}
+		if n.ElseList != nil {
+			nodes = append(nodes, n.ElseList)
+		}
+	case *parse.RangeNode:
+		return checkBranch(&n.BranchNode, params, dot)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode, params, dot)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			nodes = []parse.Node{n.Pipe}
+		}
+	}
+	for _, n := range nodes {
+		if err := checkFields(n, params, dot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBranch checks a "range" or "with" node: the body is checked with dot rebound, the pipe and else list with the outer dot.
+func checkBranch(n *parse.BranchNode, params unique.Set[string], dot bool) error {
+	if err := checkFields(n.Pipe, params, dot); err != nil {
+		return err
+	}
+	if err := checkFields(n.List, params, false); err != nil {
+		return err
+	}
+	if n.ElseList != nil {
+		return checkFields(n.ElseList, params, dot)
+	}
+	return nil
+}
+
+func (b *Builder) configRecipe(cr config.Recipe) {
+	if b.err != nil {
+		return
+	}
+	if cr.Name == "" {
+		b.err = errors.New("recipe has no name")
+		return
+	}
+	defer func() {
+		if b.err != nil {
+			b.err = fmt.Errorf("invalid recipe %v: %w", cr.Name, b.err)
+		}
+	}()
+	r := &Recipe{Recipe: cr, e: b.e}
+	switch {
+	case b.e.recipes[r.Name] != nil:
+		b.err = errors.New("duplicate recipe name")
+	case len(r.Start.Queries) == 0:
+		b.err = errors.New("no start queries")
+	case (len(r.Recipe.Goals) == 0) == (r.Depth <= 0):
+		b.err = errors.New("must have exactly one of goals or depth")
+	}
+	if b.err != nil {
+		return
+	}
+	names := unique.NewSet[string]()
+	for _, p := range r.Params {
+		if p.Name == "" || names.Has(p.Name) {
+			b.err = fmt.Errorf("invalid parameter name: %q", p.Name)
+			return
+		}
+		names.Add(p.Name)
+	}
+	if r.Start.Class != "" {
+		if r.class, b.err = b.e.Class(r.Start.Class); b.err != nil {
+			return
+		}
+	}
+	if r.goals, b.err = b.e.Classes(r.Recipe.Goals); b.err != nil {
+		return
+	}
+	for i, q := range r.Start.Queries {
+		t, err := b.e.NewTemplate(fmt.Sprintf("recipe:%v:%v", r.Name, i)).Parse(q)
+		if err != nil {
+			b.err = err
+			return
+		}
+		if b.err = checkFields(t.Root, names, true); b.err != nil {
+			b.err = fmt.Errorf("%v: %w", t.Name(), b.err)
+			return
+		}
+		r.queries = append(r.queries, t)
+	}
+	// Check that the templates generate valid queries, using the parameter name as the value of required parameters.
+	sample := map[string]string{}
+	for _, p := range r.Params {
+		sample[p.Name] = cmp.Or(p.Default, p.Name)
+	}
+	if _, b.err = r.apply(sample); b.err != nil {
+		return
+	}
+	b.e.recipes[r.Name] = r
+}
@@ -0,0 +1,109 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Recipe(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d, a, b)
+	s.AddQuery(mock.NewQuery(a, "x"), "x")
+	s.AddQuery(mock.NewQuery(b, "x"), "world")
+	e, err := engine.Build().Domains(d).Stores(s).
+		Rules(mock.NewRule("ab", list(a), list(b), mock.NewQuery(b, "x"))).
+		Config(config.Configs{{
+			Recipes: []config.Recipe{{
+				Name:       "ab",
+				Params:     []config.RecipeParam{{Name: "name"}, {Name: "other", Default: "z"}},
+				Start:      config.RecipeStart{Queries: []string{"mock:a:{{.name}}"}},
+				Goals:      []string{"mock:b"},
+				Constraint: &config.RecipeConstraint{Since: config.Duration(time.Hour), Limit: 10},
+			}},
+		}}).Engine()
+	require.NoError(t, err)
+
+	require.Len(t, e.Recipes(), 1)
+	r, err := e.Recipe("ab")
+	require.NoError(t, err)
+	assert.Equal(t, []korrel8r.Class{b}, r.Goals())
+	_, err = e.Recipe("nonesuch")
+	assert.EqualError(t, err, "recipe not found: nonesuch")
+
+	_, err = r.Queries(nil)
+	assert.EqualError(t, err, `recipe ab: missing parameter "name"`)
+	_, err = r.Queries(map[string]string{"name": "x", "bad": "y"})
+	assert.EqualError(t, err, `recipe ab: unknown parameter "bad"`)
+	queries, err := r.Queries(map[string]string{"name": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, []korrel8r.Query{mock.NewQuery(a, "x")}, queries)
+
+	now := time.Now()
+	c := r.Constraint(now)
+	assert.Equal(t, now.Add(-time.Hour), *c.Start)
+	assert.Equal(t, 10, *c.Limit)
+	assert.Nil(t, c.QueryLimit)
+
+	g, err := traverse.Recipe(context.Background(), e, r, traverse.Start{Queries: queries})
+	require.NoError(t, err)
+	if node := g.NodeFor(b); assert.NotNil(t, node) {
+		assert.Equal(t, []korrel8r.Object{"world"}, node.Result.List())
+	}
+}
+
+func TestEngine_Recipe_Dot(t *testing.T) {
+	// Fields of dot inside "with" are not parameters, fields of "$" are.
+	d := mock.NewDomain("mock", "a")
+	e, err := engine.Build().Domains(d).Config(config.Configs{{Recipes: []config.Recipe{{
+		Name: "r", Params: []config.RecipeParam{{Name: "p"}},
+		Start: config.RecipeStart{Queries: []string{`mock:a:{{with .p}}{{.}}-{{$.p}}{{end}}`}}, Depth: 1,
+	}}}}).Engine()
+	require.NoError(t, err)
+	r, err := e.Recipe("r")
+	require.NoError(t, err)
+	queries, err := r.Queries(map[string]string{"p": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, []korrel8r.Query{mock.NewQuery(d.Class("a"), "x-x")}, queries)
+}
+
+func TestEngine_Recipe_Invalid(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	for _, x := range []struct {
+		recipe config.Recipe
+		want   string
+	}{
+		{config.Recipe{Start: config.RecipeStart{Queries: []string{"mock:a:x"}}, Depth: 1}, "recipe has no name"},
+		{config.Recipe{Name: "r", Depth: 1}, "invalid recipe r: no start queries"},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{"mock:a:x"}}}, "invalid recipe r: must have exactly one of goals or depth"},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{"mock:a:x"}}, Goals: []string{"mock:b"}, Depth: 1}, "invalid recipe r: must have exactly one of goals or depth"},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{"mock:a:x"}}, Goals: []string{"mock:nonesuch"}}, "invalid recipe r: class not found: mock: nonesuch"},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{"{{bad"}}, Depth: 1}, "invalid recipe r: template: recipe:r:0:1: function \"bad\" not defined"},
+		{config.Recipe{Name: "r", Params: []config.RecipeParam{{Name: "p"}, {Name: "p"}}, Start: config.RecipeStart{Queries: []string{"mock:a:x"}}, Depth: 1}, `invalid recipe r: invalid parameter name: "p"`},
+		{config.Recipe{Name: "r", Params: []config.RecipeParam{{Name: "p"}}, Start: config.RecipeStart{Queries: []string{"mock:a:{{.p}}{{.q}}"}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "q"`},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{`mock:a:{{if .p}}x{{end}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "p"`},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{`mock:a:{{.p | lower}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "p"`},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{`mock:a:{{$.q}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "q"`},
+		{config.Recipe{Name: "r", Params: []config.RecipeParam{{Name: "p"}}, Start: config.RecipeStart{Queries: []string{`mock:a:{{with .p}}{{$.q}}{{end}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "q"`},
+		{config.Recipe{Name: "r", Params: []config.RecipeParam{{Name: "p"}}, Start: config.RecipeStart{Queries: []string{`mock:a:{{with .p}}x{{else}}{{.q}}{{end}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "q"`},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{`mock:a:{{range .q}}x{{end}}`}}, Depth: 1}, `invalid recipe r: recipe:r:0: undeclared parameter "q"`},
+		{config.Recipe{Name: "r", Params: []config.RecipeParam{{Name: "p"}}, Start: config.RecipeStart{Queries: []string{"nosuch:a:{{.p}}"}}, Depth: 1}, "invalid recipe r: recipe:r:0: domain not found: nosuch"},
+		{config.Recipe{Name: "r", Start: config.RecipeStart{Queries: []string{"mock:a:x", "mock:b:y"}}, Depth: 1}, `invalid recipe r: recipe:r:1: query has wrong class: mock:b:y`},
+	} {
+		t.Run(x.want, func(t *testing.T) {
+			_, err := engine.Build().Domains(d).Config(config.Configs{{Recipes: []config.Recipe{x.recipe}}}).Engine()
+			assert.EqualError(t, err, x.want)
+		})
+	}
+}
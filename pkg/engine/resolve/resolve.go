@@ -0,0 +1,425 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package resolve proposes start queries from free text, such as pasted log lines, alert notifications or URLs.
+//
+// Resolving has three steps:
+//
+//  1. Extract entities from the text: namespaces, k8s object names, alert names, trace IDs, console and Grafana URLs.
+//  2. Generate candidate query strings, and parse them with the domain [korrel8r.Domain.Query] parsers.
+//     Candidates that do not parse, or have no store, are dropped.
+//  3. Check the [MaxCandidates] most confident candidates against the stores, concurrently,
+//     dropping candidates that return no objects.
+//
+// Each candidate has a confidence between 0 and 1 that depends on how it was recognized,
+// and increases if the store check succeeds.
+package resolve
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/authz"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+var log = logging.Log()
+
+// Candidate is a proposed start query.
+type Candidate struct {
+	Query korrel8r.Query
+	// Confidence that the query is what the text refers to, between 0 and 1.
+	Confidence float64
+	// Reason describes how the query was recognized.
+	Reason string
+	// Count of objects found by the store check, nil if the store could not be checked.
+	Count *int
+}
+
+// Limits on the work done by [Resolve].
+const (
+	// MaxText is the maximum length of text to resolve, longer text is truncated.
+	MaxText = 64 * 1024
+	// MaxCandidates is the maximum number of candidates checked against the stores and returned.
+	MaxCandidates = 20
+	// checkWorkers is the maximum number of concurrent store checks.
+	checkWorkers = 8
+)
+
+// Resolve returns candidate start queries for text, sorted by decreasing confidence.
+// Text longer than [MaxText] is truncated, and at most [MaxCandidates] are returned.
+// The constraint, if not nil, is used for store checks.
+func Resolve(ctx context.Context, e *engine.Engine, text string, constraint *korrel8r.Constraint) []Candidate {
+	if len(text) > MaxText {
+		text = text[:MaxText]
+	}
+	var candidates []Candidate
+	seen := map[string]int{} // Index of candidate by query string.
+	for _, g := range guesses(text) {
+		q, err := e.Query(g.query)
+		if err != nil {
+			log.V(4).Info("Resolve: invalid candidate", "query", g.query, "error", err)
+			continue
+		}
+		if len(e.StoresFor(q.Class().Domain())) == 0 {
+			continue
+		}
+		if i, ok := seen[q.String()]; ok {
+			if candidates[i].Confidence < g.confidence {
+				candidates[i].Confidence, candidates[i].Reason = g.confidence, g.reason
+			}
+			continue
+		}
+		seen[q.String()] = len(candidates)
+		candidates = append(candidates, Candidate{Query: q, Confidence: g.confidence, Reason: g.reason})
+	}
+	byConfidence := func(a, b Candidate) int { return cmp.Compare(b.Confidence, a.Confidence) }
+	slices.SortStableFunc(candidates, byConfidence)
+	candidates = candidates[:min(len(candidates), MaxCandidates)]
+	ok := make([]bool, len(candidates))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, checkWorkers)
+	for i := range candidates {
+		workers <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-workers }()
+			ok[i] = check(ctx, e, &candidates[i], constraint)
+		})
+	}
+	wg.Wait()
+	checked := candidates[:0]
+	for i, c := range candidates {
+		if ok[i] {
+			checked = append(checked, c)
+		}
+	}
+	slices.SortStableFunc(checked, byConfidence)
+	return checked
+}
+
+// check gets objects for the candidate, returns false if there are none.
+// If the store returns an error the candidate is kept with a nil Count.
+func check(ctx context.Context, e *engine.Engine, c *Candidate, constraint *korrel8r.Constraint) bool {
+	checkConstraint := &korrel8r.Constraint{Limit: new(1)}
+	if constraint != nil {
+		checkConstraint.Start, checkConstraint.End = constraint.Start, constraint.End
+	}
+	count := 0
+	err := authz.Fatal(e.Get(ctx, c.Query, checkConstraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) { count += len(o) })))
+	if err != nil {
+		log.V(3).Info("Resolve: store check failed", "query", c.Query, "error", err)
+		return true
+	}
+	if count == 0 {
+		return false
+	}
+	c.Count = new(count)
+	c.Confidence += (1 - c.Confidence) / 2
+	return true
+}
+
+// guess is a candidate query string that has not been parsed.
+type guess struct {
+	query      string
+	confidence float64
+	reason     string
+}
+
+// Confidence for different ways of recognizing an entity.
+const (
+	confidenceURL     = 0.8 // Recognized URL path or parameter.
+	confidenceField   = 0.7 // Explicit field, like namespace=x or "alertname":"x".
+	confidenceWord    = 0.5 // Kind word followed by a name, like "pod foo".
+	confidenceBareID  = 0.4 // Hex string that might be a trace ID.
+	confidenceDerived = 0.3 // Derived from another entity, like a deployment from a pod name.
+	confidenceBonus   = 0.1 // Added to object names with a known namespace.
+)
+
+// entity is a value recognized in the text.
+type entity struct {
+	kind, namespace, name string
+	confidence            float64
+	reason                string
+}
+
+var (
+	urlRE = regexp.MustCompile(`https?://[^\s"'<>]+`)
+	// fieldRE matches key=value, key: value and "key":"value".
+	fieldRE = regexp.MustCompile(`"?\b([A-Za-z][A-Za-z0-9_.-]*)"?\s*[:=]\s*"?([A-Za-z0-9][A-Za-z0-9_.:-]*)`)
+	// kindRE matches a kind word followed by a name, like "pod foo", "deployment/foo", "pod ns/foo".
+	kindRE = regexp.MustCompile(`\b(?i:(pod|deployment|statefulset|daemonset|replicaset|job|cronjob|service|node|namespace|project))(?i:e?s)?[\s/:"'=]+(?:([a-z0-9][a-z0-9-]*)/)?([a-z0-9][a-z0-9.-]*[a-z0-9])`)
+	// firingRE matches an AlertManager notification title.
+	firingRE      = regexp.MustCompile(`\[(?:FIRING|RESOLVED)(?::\d+)?\]\s+([A-Za-z_][A-Za-z0-9_]*)`)
+	traceparentRE = regexp.MustCompile(`\b00-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}\b`)
+	traceIDRE     = regexp.MustCompile(`\b[0-9a-f]{32}\b`)
+	// traceIDValueRE is a valid trace ID field value.
+	traceIDValueRE = regexp.MustCompile(`^[0-9a-fA-F]{16,32}$`)
+	// dnsNameRE is a valid k8s object name.
+	dnsNameRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+	// replicaSetPodRE matches the name of a pod owned by a Deployment ReplicaSet.
+	replicaSetPodRE = regexp.MustCompile(`^(.+)-[a-z0-9]{6,10}-[a-z0-9]{5}$`)
+)
+
+// fieldKinds maps lower-case field names to entity kinds.
+var fieldKinds = map[string]string{
+	"namespace": "namespace", "ns": "namespace", "exported_namespace": "namespace", "project": "namespace",
+	"kubernetes_namespace_name": "namespace", "k8s_namespace_name": "namespace", "k8s.namespace.name": "namespace",
+	"pod": "pod", "pod_name": "pod", "exported_pod": "pod",
+	"kubernetes_pod_name": "pod", "k8s_pod_name": "pod", "k8s.pod.name": "pod",
+	"deployment": "deployment", "k8s_deployment_name": "deployment", "k8s.deployment.name": "deployment",
+	"statefulset": "statefulset", "k8s_statefulset_name": "statefulset", "k8s.statefulset.name": "statefulset",
+	"daemonset": "daemonset", "k8s_daemonset_name": "daemonset", "k8s.daemonset.name": "daemonset",
+	"node": "node", "nodename": "node", "kubernetes_host": "node", "k8s_node_name": "node", "k8s.node.name": "node",
+	"alertname": "alert", "alert": "alert",
+	"trace_id": "trace", "traceid": "trace", "trace-id": "trace", "trace": "trace",
+}
+
+// k8sClasses maps entity kinds and lower-case resource names to k8s class names.
+var k8sClasses = map[string]string{
+	"pod": "Pod", "pods": "Pod",
+	"deployment": "Deployment.apps", "deployments": "Deployment.apps",
+	"statefulset": "StatefulSet.apps", "statefulsets": "StatefulSet.apps",
+	"daemonset": "DaemonSet.apps", "daemonsets": "DaemonSet.apps",
+	"replicaset": "ReplicaSet.apps", "replicasets": "ReplicaSet.apps",
+	"job": "Job.batch", "jobs": "Job.batch",
+	"cronjob": "CronJob.batch", "cronjobs": "CronJob.batch",
+	"service": "Service", "services": "Service",
+	"node": "Node", "nodes": "Node",
+	"namespace": "Namespace", "namespaces": "Namespace", "project": "Namespace", "projects": "Namespace",
+}
+
+// guesses returns unique candidate query strings for text, they may be invalid.
+func guesses(text string) []guess {
+	var (
+		entities []entity
+		gs       []guess
+	)
+	for _, u := range urlRE.FindAllString(text, -1) {
+		es, ugs := fromURL(trimURL(u))
+		entities = append(entities, es...)
+		gs = append(gs, ugs...)
+	}
+	text = urlRE.ReplaceAllString(text, " ")
+	for _, m := range fieldRE.FindAllStringSubmatch(text, -1) {
+		if kind := fieldKinds[strings.ToLower(m[1])]; kind != "" {
+			entities = append(entities, entity{kind: kind, name: strings.TrimRight(m[2], ".:"), confidence: confidenceField, reason: m[0]})
+		}
+	}
+	for _, m := range kindRE.FindAllStringSubmatch(text, -1) {
+		kind := strings.ToLower(m[1])
+		if kind == "project" {
+			kind = "namespace"
+		}
+		entities = append(entities, entity{kind: kind, namespace: m[2], name: m[3], confidence: confidenceWord, reason: m[0]})
+	}
+	for _, m := range firingRE.FindAllStringSubmatch(text, -1) {
+		entities = append(entities, entity{kind: "alert", name: m[1], confidence: confidenceURL, reason: m[0]})
+	}
+	for _, m := range traceparentRE.FindAllStringSubmatch(text, -1) {
+		entities = append(entities, entity{kind: "trace", name: m[1], confidence: confidenceURL, reason: m[0]})
+	}
+	for _, m := range traceIDRE.FindAllString(text, -1) {
+		entities = append(entities, entity{kind: "trace", name: m, confidence: confidenceBareID, reason: m})
+	}
+	gs = append(gs, entityGuesses(entities)...)
+	// Remove duplicates, keep the highest confidence.
+	unique := gs[:0]
+	index := map[string]int{}
+	for _, g := range gs {
+		if i, ok := index[g.query]; ok {
+			if unique[i].confidence < g.confidence {
+				unique[i] = g
+			}
+			continue
+		}
+		index[g.query] = len(unique)
+		unique = append(unique, g)
+	}
+	return unique
+}
+
+// trimURL removes trailing punctuation that is probably not part of a URL found in text.
+func trimURL(u string) string {
+	for {
+		n := len(u)
+		u = strings.TrimRight(u, ".,;:!?'\"")
+		for _, p := range []string{"()", "[]", "{}"} {
+			if strings.HasSuffix(u, p[1:]) && strings.Count(u, p[1:]) > strings.Count(u, p[:1]) {
+				u = u[:len(u)-1]
+			}
+		}
+		if len(u) == n {
+			return u
+		}
+	}
+}
+
+// entityGuesses generates queries for entities.
+// A namespace is applied to names without a namespace if it is the only namespace found.
+func entityGuesses(entities []entity) []guess {
+	var namespaces []string
+	for _, e := range entities {
+		ns := e.namespace
+		if e.kind == "namespace" {
+			ns = e.name
+		}
+		if ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+		if e.kind != "namespace" && e.namespace != "" { // Namespace of a namespaced object.
+			entities = append(entities, entity{kind: "namespace", name: e.namespace, confidence: e.confidence, reason: e.reason})
+		}
+	}
+	var gs []guess
+	for _, e := range entities {
+		switch e.kind {
+		case "alert":
+			selector := map[string]string{"alertname": e.name}
+			if len(namespaces) == 1 {
+				selector["namespace"] = namespaces[0]
+			}
+			gs = append(gs, guess{query: "alert:alert:" + jsonString(selector), confidence: e.confidence, reason: e.reason})
+		case "trace":
+			if !traceIDValueRE.MatchString(e.name) {
+				continue
+			}
+			gs = append(gs, guess{query: "trace:span:" + strings.ToLower(e.name), confidence: e.confidence, reason: e.reason})
+		case "namespace":
+			if dnsNameRE.MatchString(e.name) {
+				gs = append(gs, k8sGuess("Namespace", "", e.name, e.confidence, e.reason))
+			}
+		default:
+			class := k8sClasses[e.kind]
+			if class == "" || !dnsNameRE.MatchString(e.name) {
+				continue
+			}
+			if e.namespace == "" && len(namespaces) == 1 && class != "Node" {
+				e.namespace = namespaces[0]
+			}
+			confidence := e.confidence
+			if e.namespace != "" {
+				confidence += confidenceBonus
+			}
+			gs = append(gs, k8sGuess(class, e.namespace, e.name, confidence, e.reason))
+			if e.kind == "pod" {
+				if m := replicaSetPodRE.FindStringSubmatch(e.name); m != nil {
+					gs = append(gs, k8sGuess("Deployment.apps", e.namespace, m[1], confidenceDerived, e.reason))
+				}
+			}
+		}
+	}
+	return gs
+}
+
+func k8sGuess(class, namespace, name string, confidence float64, reason string) guess {
+	selector := map[string]string{"name": name}
+	if namespace != "" {
+		selector["namespace"] = namespace
+	}
+	return guess{query: fmt.Sprintf("k8s:%v:%v", class, jsonString(selector)), confidence: confidence, reason: reason}
+}
+
+// fromURL recognizes OpenShift console and Grafana URLs.
+// Query parameters with known field names are returned as entities.
+func fromURL(s string) (entities []entity, gs []guess) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, nil
+	}
+	add := func(kind, namespace, name string) {
+		entities = append(entities, entity{kind: kind, namespace: namespace, name: name, confidence: confidenceURL, reason: s})
+	}
+	// Console resource paths: /k8s/ns/NAMESPACE/RESOURCE/NAME or /k8s/cluster/RESOURCE/NAME
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := range path {
+		rest := path[i:]
+		switch {
+		case len(rest) >= 3 && rest[0] == "k8s" && rest[1] == "ns":
+			add("namespace", "", rest[2])
+			if len(rest) >= 5 {
+				if class := consoleClass(rest[3]); class != "" {
+					gs = append(gs, k8sGuess(class, rest[2], rest[4], confidenceURL+confidenceBonus, s))
+				}
+			}
+		case len(rest) >= 4 && rest[0] == "k8s" && rest[1] == "cluster":
+			if class := consoleClass(rest[2]); class != "" {
+				gs = append(gs, k8sGuess(class, "", rest[3], confidenceURL, s))
+			}
+		case len(rest) >= 2 && (rest[0] == "trace" || rest[0] == "traces") && traceIDRE.MatchString(rest[1]):
+			add("trace", "", rest[1])
+		}
+	}
+	params := u.Query()
+	for k, vs := range params {
+		if kind := fieldKinds[strings.ToLower(strings.TrimPrefix(k, "var-"))]; kind != "" {
+			for _, v := range vs {
+				if v != "" && !strings.HasPrefix(v, "$") {
+					add(kind, "", v)
+				}
+			}
+		}
+	}
+	// Console metrics and logs pages.
+	for _, expr := range params["query0"] {
+		gs = append(gs, guess{query: "metric:metric:" + expr, confidence: confidenceURL, reason: s})
+	}
+	if q := params.Get("q"); q != "" && strings.Contains(u.Path, "logs") {
+		tenant := cmp.Or(params.Get("tenant"), "application")
+		gs = append(gs, guess{query: fmt.Sprintf("log:%v:%v", tenant, q), confidence: confidenceURL, reason: s})
+	}
+	// Grafana explore: JSON parameters containing "expr" fields, from Prometheus or Loki.
+	for _, k := range []string{"left", "right", "panes"} {
+		var v any
+		if json.Unmarshal([]byte(params.Get(k)), &v) != nil {
+			continue
+		}
+		for _, expr := range exprs(v) {
+			gs = append(gs, guess{query: "metric:metric:" + expr, confidence: confidenceField, reason: s})
+			if strings.HasPrefix(strings.TrimSpace(expr), "{") {
+				gs = append(gs, guess{query: "log:application:" + expr, confidence: confidenceWord, reason: s})
+			}
+		}
+	}
+	return entities, gs
+}
+
+// consoleClass returns the k8s class for a console resource name,
+// which is either a plural resource like "pods" or a reference like "apps~v1~Deployment".
+func consoleClass(resource string) string {
+	if parts := strings.Split(resource, "~"); len(parts) == 3 {
+		group, version, kind := parts[0], parts[1], parts[2]
+		if group == "core" {
+			return kind + "." + version
+		}
+		return kind + "." + version + "." + group
+	}
+	return k8sClasses[resource]
+}
+
+// exprs returns all string values of "expr" fields in a decoded JSON value.
+func exprs(v any) (found []string) {
+	switch v := v.(type) {
+	case map[string]any:
+		if s, ok := v["expr"].(string); ok && s != "" {
+			found = append(found, s)
+		}
+		for _, x := range v {
+			found = append(found, exprs(x)...)
+		}
+	case []any:
+		for _, x := range v {
+			found = append(found, exprs(x)...)
+		}
+	}
+	return found
+}
+
+func jsonString(v any) string { b, _ := json.Marshal(v); return string(b) }
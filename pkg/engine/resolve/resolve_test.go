@@ -0,0 +1,159 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/domains/trace"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func queries(gs []guess) []string {
+	var qs []string
+	for _, g := range gs {
+		qs = append(qs, g.query)
+	}
+	return qs
+}
+
+func TestGuesses(t *testing.T) {
+	for _, x := range []struct {
+		text string
+		want []string
+	}{
+		{
+			text: `level=error namespace=shop pod=cart-7d9c6b5f4-x2x9z msg="connection refused"`,
+			want: []string{
+				`k8s:Namespace:{"name":"shop"}`,
+				`k8s:Pod:{"name":"cart-7d9c6b5f4-x2x9z","namespace":"shop"}`,
+				`k8s:Deployment.apps:{"name":"cart","namespace":"shop"}`,
+			},
+		},
+		{
+			text: `{"kubernetes_namespace_name":"shop","kubernetes_pod_name":"db-0","message":"oops"}`,
+			want: []string{
+				`k8s:Namespace:{"name":"shop"}`,
+				`k8s:Pod:{"name":"db-0","namespace":"shop"}`,
+			},
+		},
+		{
+			text: `Back-off restarting failed container in pod shop/cart-0`,
+			want: []string{
+				`k8s:Pod:{"name":"cart-0","namespace":"shop"}`,
+				`k8s:Namespace:{"name":"shop"}`,
+			},
+		},
+		{
+			text: `[FIRING:2] KubePodCrashLooping (namespace: shop)`,
+			want: []string{
+				`k8s:Namespace:{"name":"shop"}`,
+				`alert:alert:{"alertname":"KubePodCrashLooping","namespace":"shop"}`,
+			},
+		},
+		{
+			text: `traceparent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`,
+			want: []string{`trace:span:4bf92f3577b34da6a3ce929d0e0e4736`},
+		},
+		{
+			text: `see https://console.example.com/k8s/ns/shop/apps~v1~Deployment/cart.`,
+			want: []string{
+				`k8s:Deployment.v1.apps:{"name":"cart","namespace":"shop"}`,
+				`k8s:Namespace:{"name":"shop"}`,
+			},
+		},
+		{
+			text: `(see https://console.example.com/k8s/ns/shop/pods/cart-0).`,
+			want: []string{
+				`k8s:Pod:{"name":"cart-0","namespace":"shop"}`,
+				`k8s:Namespace:{"name":"shop"}`,
+			},
+		},
+		{
+			text: `https://console.example.com/k8s/cluster/nodes/worker-1`,
+			want: []string{`k8s:Node:{"name":"worker-1"}`},
+		},
+		{
+			text: `https://console.example.com/monitoring/alerts/123?alertname=Watchdog&namespace=openshift-monitoring`,
+			want: []string{
+				`k8s:Namespace:{"name":"openshift-monitoring"}`,
+				`alert:alert:{"alertname":"Watchdog","namespace":"openshift-monitoring"}`,
+			},
+		},
+		{
+			text: `https://console.example.com/monitoring/query-browser?query0=rate(http_requests_total[5m])`,
+			want: []string{`metric:metric:rate(http_requests_total[5m])`},
+		},
+		{
+			text: `https://grafana.example.com/d/abc/pods?var-namespace=shop&var-pod=$__all`,
+			want: []string{`k8s:Namespace:{"name":"shop"}`},
+		},
+	} {
+		t.Run(x.text, func(t *testing.T) {
+			assert.ElementsMatch(t, x.want, queries(guesses(x.text)))
+		})
+	}
+}
+
+func TestGuesses_GrafanaExplore(t *testing.T) {
+	got := queries(guesses(`https://grafana.example.com/explore?left=%7B%22queries%22%3A%5B%7B%22expr%22%3A%22up%22%7D%5D%7D`))
+	assert.Equal(t, []string{`metric:metric:up`}, got)
+}
+
+func TestResolve(t *testing.T) {
+	c := fake.NewClientBuilder().
+		WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cart-0", Namespace: "shop"}},
+		).Build()
+	s, err := k8s.Domain.NewStore(c, &rest.Config{})
+	require.NoError(t, err)
+	e, err := engine.Build().Domains(k8s.Domain, alert.Domain, trace.Domain).Stores(s).Engine()
+	require.NoError(t, err)
+
+	got := Resolve(context.Background(), e,
+		`[FIRING:1] KubePodCrashLooping: pod shop/cart-0 and pod shop/missing-0, trace_id=4bf92f3577b34da6a3ce929d0e0e4736`, nil)
+	// The alert and trace candidates have no store, the missing pod is not found.
+	require.Len(t, got, 2)
+	assert.Equal(t, `k8s:Pod.v1:{"namespace":"shop","name":"cart-0"}`, got[0].Query.String())
+	assert.Equal(t, 1, *got[0].Count)
+	assert.InDelta(t, 0.8, got[0].Confidence, 0.001)
+	assert.Equal(t, "pod shop/cart-0", got[0].Reason)
+	assert.Equal(t, `k8s:Namespace.v1:{"name":"shop"}`, got[1].Query.String())
+	assert.InDelta(t, 0.75, got[1].Confidence, 0.001)
+}
+
+func TestResolve_Limits(t *testing.T) {
+	var objects []client.Object
+	var text strings.Builder
+	for i := range 2 * MaxCandidates {
+		name := fmt.Sprintf("ns%v", i)
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		fmt.Fprintf(&text, "namespace=%v ", name)
+	}
+	c := fake.NewClientBuilder().WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)).WithObjects(objects...).Build()
+	s, err := k8s.Domain.NewStore(c, &rest.Config{})
+	require.NoError(t, err)
+	e, err := engine.Build().Domains(k8s.Domain).Stores(s).Engine()
+	require.NoError(t, err)
+
+	assert.Len(t, Resolve(context.Background(), e, text.String(), nil), MaxCandidates)
+	// Text after MaxText is ignored.
+	long := strings.Repeat(" ", MaxText) + "namespace=ns0"
+	assert.Empty(t, Resolve(context.Background(), e, long, nil))
+}
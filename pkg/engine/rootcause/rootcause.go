@@ -0,0 +1,187 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package rootcause ranks the objects in a correlation graph as candidate root causes of a problem with the start objects.
+//
+// The score of a candidate is between 0 and 1, it is a weighted sum of:
+//
+//   - status: objects with error statuses score higher than warnings, see [rank.StatusLevel].
+//   - precedence: objects that happened shortly before the earliest start object score higher.
+//     The precedence score halves for every [HalfLife] before the start, objects after the start score 0.
+//     Both classes must implement [korrel8r.Timer].
+//   - ownership: k8s objects owned by a start object, directly or via other objects in the graph, score 1.
+//     Owners of a start object score 0.5. Ownership is taken from metadata.ownerReferences.
+//   - distance: objects found by following fewer rules from the start score higher.
+//
+// Objects with no evidence other than distance are not candidates.
+package rootcause
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rank"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultDepth is the depth of a neighbors search for candidates, if no depth is given.
+const DefaultDepth = 3
+
+// DefaultLimit is the maximum number of candidates returned, if no limit is given.
+const DefaultLimit = 10
+
+// Weights of the score components, they add up to 1.
+const (
+	StatusWeight     = 0.4
+	PrecedenceWeight = 0.25
+	OwnerWeight      = 0.2
+	DistanceWeight   = 0.15
+)
+
+// HalfLife is the time before the start objects that halves the precedence score.
+const HalfLife = 15 * time.Minute
+
+// Candidate is an object that may be the root cause of a problem with the start objects.
+type Candidate struct {
+	Class  korrel8r.Class
+	Object korrel8r.Object
+	// Score between 0 and 1, higher scores are more likely causes.
+	Score float64
+	// Time of the object, zero if the class does not implement [korrel8r.Timer].
+	Time time.Time
+	// Cluster of the object, "" if it does not belong to a cluster.
+	Cluster string
+	// Statuses of the object from status rules.
+	Statuses []string
+	// Rules followed from the start class to the object class: the evidence path.
+	Rules []korrel8r.Rule
+	// Reasons are human-readable descriptions of the evidence that contributed to the score.
+	Reasons []string
+}
+
+// Analyze returns at most limit candidates from g, sorted by decreasing score. If limit <= 0 all candidates are returned.
+// Objects of the start class are the start objects, they are not candidates.
+func Analyze(e *engine.Engine, g *graph.Graph, start korrel8r.Class, limit int) []Candidate {
+	if g == nil || g.NodeFor(start) == nil || g.NodeFor(start).Result == nil {
+		return nil
+	}
+	starts := g.NodeFor(start).Result.List()
+	a := &analyzer{engine: e, paths: g.RulePaths(start), owners: map[types.UID][]types.UID{}}
+	for _, o := range starts {
+		if t := rank.Time(start, o); !t.IsZero() && (a.ref.IsZero() || t.Before(a.ref)) {
+			a.ref = t
+		}
+	}
+	g.EachNode(func(n *graph.Node) {
+		if n.Result == nil {
+			return
+		}
+		for _, o := range n.Result.List() {
+			if ko, ok := o.(k8s.Object); ok {
+				u := k8s.ToUnstructured(ko)
+				for _, ref := range u.GetOwnerReferences() {
+					a.owners[u.GetUID()] = append(a.owners[u.GetUID()], ref.UID)
+				}
+			}
+		}
+	})
+	for _, o := range starts {
+		if ko, ok := o.(k8s.Object); ok {
+			if uid := k8s.ToUnstructured(ko).GetUID(); uid != "" {
+				a.starts = append(a.starts, uid)
+			}
+		}
+	}
+	var candidates []Candidate
+	g.EachNode(func(n *graph.Node) {
+		if n.Class == start || n.Result == nil {
+			return
+		}
+		for i, o := range n.Result.List() {
+			if x, ok := a.candidate(n.Class, o); ok {
+				if n.Clusters != nil {
+					x.Cluster = n.Clusters[i]
+				}
+				candidates = append(candidates, x)
+			}
+		}
+	})
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(len(a.Rules), len(b.Rules)), cmp.Compare(a.Class.String(), b.Class.String()))
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+type analyzer struct {
+	engine *engine.Engine
+	paths  map[korrel8r.Class][]korrel8r.Rule
+	// ref is the earliest start time, zero if the start objects have no time.
+	ref time.Time
+	// starts are the UIDs of k8s start objects.
+	starts []types.UID
+	// owners maps the UID of each k8s object in the graph to the UIDs of its owners.
+	owners map[types.UID][]types.UID
+}
+
+func (a *analyzer) candidate(c korrel8r.Class, o korrel8r.Object) (x Candidate, ok bool) {
+	x = Candidate{Class: c, Object: o, Time: rank.Time(c, o), Rules: a.paths[c], Statuses: rank.Statuses(a.engine, c, o)}
+	var level float64
+	for _, s := range x.Statuses {
+		level = max(level, rank.StatusLevel(s))
+	}
+	if level > 0 {
+		x.Score += StatusWeight * level
+		x.Reasons = append(x.Reasons, "status: "+strings.Join(x.Statuses, ", "))
+	}
+	if !x.Time.IsZero() && !a.ref.IsZero() && !x.Time.After(a.ref) {
+		d := a.ref.Sub(x.Time)
+		x.Score += PrecedenceWeight * math.Exp2(-float64(d)/float64(HalfLife))
+		x.Reasons = append(x.Reasons, fmt.Sprintf("%v before start", d.Round(time.Second)))
+	}
+	if ko, isK8s := o.(k8s.Object); isK8s {
+		uid := k8s.ToUnstructured(ko).GetUID()
+		switch {
+		case a.ownedBy(uid, a.starts):
+			x.Score += OwnerWeight
+			x.Reasons = append(x.Reasons, "owned by start object")
+		case slices.ContainsFunc(a.starts, func(s types.UID) bool { return a.ownedBy(s, []types.UID{uid}) }):
+			x.Score += OwnerWeight / 2
+			x.Reasons = append(x.Reasons, "owner of start object")
+		}
+	}
+	if len(x.Reasons) == 0 {
+		return x, false
+	}
+	x.Score += DistanceWeight / float64(max(len(x.Rules), 1))
+	return x, true
+}
+
+// ownedBy returns true if uid is owned, directly or indirectly, by one of owners.
+func (a *analyzer) ownedBy(uid types.UID, owners []types.UID) bool {
+	if uid == "" {
+		return false
+	}
+	seen := map[types.UID]bool{}
+	for queue := []types.UID{uid}; len(queue) > 0; queue = queue[1:] {
+		for _, owner := range a.owners[queue[0]] {
+			if slices.Contains(owners, owner) {
+				return true
+			}
+			if !seen[owner] {
+				seen[owner] = true
+				queue = append(queue, owner)
+			}
+		}
+	}
+	return false
+}
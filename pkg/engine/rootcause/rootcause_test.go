@@ -0,0 +1,107 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rootcause_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// event is a mock object with a time.
+type event struct {
+	Msg string
+	At  time.Time
+}
+
+func (e event) Timestamp() time.Time { return e.At }
+
+func TestAnalyze(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(msg string, d time.Duration) event { return event{Msg: msg, At: t0.Add(d)} }
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab", at("boom", -rootcause.HalfLife), at("later", time.Minute), "no time")),
+		b.Rule("bc", "d:b", "d:c", b.Query("d:c", "bc", at("c1", 0))),
+	).Stores(b.Store("d", nil)).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "boom",
+			Start:  config.ClassSpec{Domain: "d", Classes: []string{"b"}},
+			Status: `{{if eq .Msg "boom"}}CrashLoopBackOff{{end}}`,
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	a := b.Class("d:a")
+	g, err := traverse.Neighbors(context.Background(), e, traverse.Start{Class: a, Objects: []korrel8r.Object{at("a", 0), at("a2", time.Hour)}}, 3)
+	require.NoError(t, err)
+
+	type candidate struct {
+		Class   string
+		Msg     string
+		Rules   []string
+		Reasons []string
+	}
+	var got []candidate
+	var scores []float64
+	for _, x := range rootcause.Analyze(e, g, a, 0) {
+		var rules []string
+		for _, r := range x.Rules {
+			rules = append(rules, r.Name())
+		}
+		got = append(got, candidate{x.Class.String(), x.Object.(event).Msg, rules, x.Reasons})
+		scores = append(scores, x.Score)
+	}
+	assert.Equal(t, []candidate{
+		{"d:b", "boom", []string{"ab"}, []string{"status: CrashLoopBackOff", "15m0s before start"}},
+		{"d:c", "c1", []string{"ab", "bc"}, []string{"0s before start"}},
+	}, got)
+	assert.InDeltaSlice(t, []float64{
+		rootcause.StatusWeight + rootcause.PrecedenceWeight/2 + rootcause.DistanceWeight,
+		rootcause.PrecedenceWeight + rootcause.DistanceWeight/2,
+	}, scores, 1e-9)
+
+	assert.Len(t, rootcause.Analyze(e, g, a, 1), 1)
+	assert.Empty(t, rootcause.Analyze(e, nil, a, 0))
+}
+
+func TestAnalyze_owners(t *testing.T) {
+	object := func(kind, uid string, owners ...string) k8s.Object {
+		var refs []any
+		for _, o := range owners {
+			refs = append(refs, map[string]any{"apiVersion": "v1", "kind": "Owner", "name": o, "uid": o})
+		}
+		return k8s.Object{"apiVersion": "v1", "kind": kind, "metadata": map[string]any{"name": uid, "uid": uid, "ownerReferences": refs}}
+	}
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab",
+			object("Deployment", "deployment"),
+			object("ReplicaSet", "replicaset", "start"),
+			object("Unrelated", "unrelated"))),
+		b.Rule("bc", "d:b", "d:c", b.Query("d:c", "bc", object("Pod", "pod", "replicaset"))),
+	).Stores(b.Store("d", nil)).Engine()
+	require.NoError(t, err)
+	a := b.Class("d:a")
+	g, err := traverse.Neighbors(context.Background(), e, traverse.Start{Class: a, Objects: []korrel8r.Object{object("Deployment", "start", "deployment")}}, 3)
+	require.NoError(t, err)
+
+	got := map[string][]string{}
+	for _, x := range rootcause.Analyze(e, g, a, 0) {
+		got[k8s.ToUnstructured(x.Object.(k8s.Object)).GetName()] = x.Reasons
+	}
+	assert.Equal(t, map[string][]string{
+		"deployment": {"owner of start object"},
+		"replicaset": {"owned by start object"},
+		"pod":        {"owned by start object"},
+	}, got)
+}
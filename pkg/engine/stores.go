@@ -18,6 +18,7 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/remote"
 	"github.com/korrel8r/korrel8r/pkg/unique"
 )
 
@@ -61,6 +62,9 @@ func wrap(e *Engine, sc config.Store, s korrel8r.Store) (*storeHolder, error) {
 
 func (s *storeHolder) Domain() korrel8r.Domain { return s.domain }
 
+// Cluster returns the cluster name from the store configuration, "" if there is none.
+func (s *storeHolder) Cluster() string { return s.Original[config.StoreKeyCluster] }
+
 // recordErrorLH records a store error and resets the store for re-creation.
 // Must be called with the lock held.
 func (s *storeHolder) recordErrorLH(err error) {
@@ -125,10 +129,25 @@ func (s *storeHolder) ensureLH() (_ korrel8r.Store, err error) {
 	}()
 
 	// Expand the store config each time - the results may change.
-	s.Expanded = config.Store{}
-	for k, original := range s.Original {
-		var expanded string
-		expanded, err = s.Engine.execTemplate(s.domain.Name()+"-store", original, nil)
+	s.Expanded, err = s.Engine.ExpandStoreConfig(s.domain, s.Original)
+	if err != nil {
+		return nil, err
+	}
+	s.Store, err = s.Engine.NewStore(s.domain, s.Expanded)
+	if err != nil {
+		s.Store = nil
+	}
+	if err == nil {
+		log.V(2).Info("Engine: Store connected", "domain", s.Domain().Name(), "config", s.Original)
+	}
+	return s.Store, err
+}
+
+// ExpandStoreConfig expands the templates in a store configuration for domain d.
+func (e *Engine) ExpandStoreConfig(d korrel8r.Domain, sc config.Store) (config.Store, error) {
+	expanded := config.Store{}
+	for k, original := range sc {
+		v, err := e.execTemplate(d.Name()+"-store", original, nil)
 		if err != nil {
 			var execErr template.ExecError
 			if errors.As(err, &execErr) {
@@ -136,23 +155,22 @@ func (s *storeHolder) ensureLH() (_ korrel8r.Store, err error) {
 					err = err2
 				}
 			}
-			return nil, err
+			return expanded, err
 		}
-		s.Expanded[k] = expanded
+		expanded[k] = v
 	}
-	// Create the store
-	if _, ok := s.Expanded[config.StoreKeyMock]; ok {
-		s.Store, err = mock.NewStoreConfig(s.domain, s.Expanded)
-	} else {
-		s.Store, err = s.domain.Store(s.Expanded)
-	}
-	if err != nil {
-		s.Store = nil
-	}
-	if err == nil {
-		log.V(2).Info("Engine: Store connected", "domain", s.Domain().Name(), "config", s.Original)
+	return expanded, nil
+}
+
+// NewStore creates a store for domain d from an expanded store configuration.
+// Mock and remote store configurations are handled by the engine, others by [korrel8r.Domain.Store].
+func (e *Engine) NewStore(d korrel8r.Domain, expanded config.Store) (korrel8r.Store, error) {
+	if _, ok := expanded[config.StoreKeyMock]; ok {
+		return mock.NewStoreConfig(d, expanded)
+	} else if _, ok := expanded[config.StoreKeyRemote]; ok {
+		return remote.NewStore(d, expanded)
 	}
-	return s.Store, err
+	return d.Store(expanded)
 }
 
 // storeHolders contains multiple store wrappers storeHolders and iterates over them in Get.
@@ -180,11 +198,21 @@ func (ss *storeHolders) Add(newStore *storeHolder) {
 }
 
 func (ss *storeHolders) Get(ctx context.Context, q korrel8r.Query, constraint *korrel8r.Constraint, result korrel8r.Appender) error {
+	return ss.getClusters(ctx, q, constraint, func(_ string, o ...korrel8r.Object) { result.Append(o...) })
+}
+
+// getClusters calls Get on each store and accumulates the results, passing the store cluster with each batch of objects.
+// If constraint has a cluster, stores for other clusters are skipped.
+func (ss *storeHolders) getClusters(ctx context.Context, q korrel8r.Query, constraint *korrel8r.Constraint, result func(cluster string, o ...korrel8r.Object)) error {
 	errs := unique.NewList[string]()
 	ok := false
 	for _, s := range ss.stores {
+		cluster := s.Cluster()
+		if want := constraint.GetCluster(); want != "" && cluster != "" && cluster != want {
+			continue
+		}
 		// Iterate over stores and accumulate all results.
-		err := s.Get(ctx, q, constraint, result)
+		err := s.Get(ctx, q, constraint, korrel8r.AppenderFunc(func(o ...korrel8r.Object) { result(cluster, o...) }))
 		if err != nil {
 			errs.Add(err.Error())
 		}
@@ -196,9 +224,63 @@ func (ss *storeHolders) Get(ctx context.Context, q korrel8r.Query, constraint *k
 		}
 		return nil
 	}
+	if len(errs.List) == 0 {
+		return ss.notFound(constraint)
+	}
 	return fmt.Errorf("Get failed: %v", errs.List)
 }
 
+// notFound returns the error when no store was called, the cluster is only mentioned if constraint has one.
+func (ss *storeHolders) notFound(constraint *korrel8r.Constraint) error {
+	if cluster := constraint.GetCluster(); cluster != "" {
+		return fmt.Errorf("no stores found for cluster %v", cluster)
+	}
+	return fmt.Errorf("no stores found for domain %v", ss.domain.Name())
+}
+
+// Originals returns the template configurations for each store.
+func (ss *storeHolders) Originals() (ret []config.Store) {
+	for _, s := range ss.stores {
+		if s.Original != nil {
+			ret = append(ret, maps.Clone(s.Original))
+		}
+	}
+	return ret
+}
+
+// ConfiguredStore is a store created from a configuration, see [Engine.ConfiguredStoresFor].
+type ConfiguredStore struct {
+	Original config.Store   // Original template configuration.
+	Expanded config.Store   // Expanded configuration, nil if template expansion failed.
+	Store    korrel8r.Store // Store created from Expanded, nil if expansion or creation failed.
+	Err      error          // Error expanding the configuration or creating the store.
+}
+
+// Configured returns the stores created from configurations, creating them if needed.
+func (ss *storeHolders) Configured() (ret []ConfiguredStore) {
+	for _, s := range ss.stores {
+		if s.Original == nil {
+			continue
+		}
+		cs := ConfiguredStore{Original: maps.Clone(s.Original)}
+		cs.Store, cs.Err = s.Ensure()
+		if cs.Store == nil {
+			// Expanding again distinguishes template errors from store creation errors.
+			if expanded, err := s.Engine.ExpandStoreConfig(s.domain, s.Original); err != nil {
+				cs.Err = err
+			} else {
+				cs.Expanded = expanded
+			}
+		} else {
+			s.lock.Lock()
+			cs.Expanded = maps.Clone(s.Expanded)
+			s.lock.Unlock()
+		}
+		ret = append(ret, cs)
+	}
+	return ret
+}
+
 // Configs returns the expanded configurations for each store.
 func (ss *storeHolders) Configs() (ret []config.Store) {
 	for _, s := range ss.stores {
@@ -214,6 +296,24 @@ func (ss *storeHolders) Configs() (ret []config.Store) {
 	return ret
 }
 
+// remote returns a store for cluster that forwards to a remote korrel8r, nil if no store serves cluster.
+// Returns ok false if the stores that serve cluster do not all forward to the same remote korrel8r.
+// Stores with no cluster serve every cluster, as in [storeHolders.getClusters].
+// Only the store configuration is checked, stores are not created.
+func (ss *storeHolders) remote(cluster string) (r *storeHolder, ok bool) {
+	for _, s := range ss.stores {
+		if c := s.Cluster(); c != "" && c != cluster {
+			continue
+		}
+		u := s.Original[config.StoreKeyRemote]
+		if u == "" || (r != nil && r.Original[config.StoreKeyRemote] != u) {
+			return nil, false
+		}
+		r = s
+	}
+	return r, true
+}
+
 // Ensure calls [configuredStore.Ensure] on all configured stores.
 func (ss *storeHolders) Ensure() (ks []korrel8r.Store) {
 	for _, s := range ss.stores {
@@ -56,7 +56,8 @@ func (e *Engine) query(query string) ([]korrel8r.Object, error) {
 		return nil, err
 	}
 	results := result.New(q.Class())
-	err = e.Get(context.Background(), q, nil, results)
+	// Template queries are used to build other queries, not returned to the caller, so they are not filtered.
+	err = e.getClusters(context.Background(), q, nil, func(_ string, o ...korrel8r.Object) { results.Append(o...) })
 	return results.List(), err
 }
 
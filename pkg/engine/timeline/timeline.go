@@ -0,0 +1,65 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package timeline flattens a correlation graph into a single list of objects sorted by time.
+//
+// Only objects with a time are included: the object class must implement [korrel8r.Timer].
+// Each entry records the rules followed from the start of the search to find the object.
+package timeline
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rank"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// DefaultDepth is the depth of a neighbors search for a timeline, if no depth is given.
+const DefaultDepth = 2
+
+// Entry is an object in a timeline.
+type Entry struct {
+	Class  korrel8r.Class
+	Object korrel8r.Object
+	Time   time.Time
+	// Cluster of the object, "" if it does not belong to a cluster.
+	Cluster string
+	// Statuses of the object from status rules.
+	Statuses []string
+	// Rules followed from the start class to the object class, empty for start objects.
+	Rules []korrel8r.Rule
+}
+
+// New returns the objects in g that have a time, sorted by time.
+// Objects with the same time are ordered by rule path length then class name.
+// Rule paths are the shortest paths from the start class, see [graph.Graph.RulePaths].
+func New(e *engine.Engine, g *graph.Graph, start korrel8r.Class) []Entry {
+	if g == nil {
+		return nil
+	}
+	paths := g.RulePaths(start)
+	var entries []Entry
+	g.EachNode(func(n *graph.Node) {
+		if n.Result == nil {
+			return
+		}
+		for i, o := range n.Result.List() {
+			t := rank.Time(n.Class, o)
+			if t.IsZero() {
+				continue
+			}
+			x := Entry{Class: n.Class, Object: o, Time: t, Rules: paths[n.Class], Statuses: rank.Statuses(e, n.Class, o)}
+			if n.Clusters != nil {
+				x.Cluster = n.Clusters[i]
+			}
+			entries = append(entries, x)
+		}
+	})
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(len(a.Rules), len(b.Rules)), cmp.Compare(a.Class.String(), b.Class.String()))
+	})
+	return entries
+}
@@ -0,0 +1,70 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/timeline"
+	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// event is a mock object with a time.
+type event struct {
+	Msg string
+	At  time.Time
+}
+
+func (e event) Timestamp() time.Time { return e.At }
+
+func TestNew(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(msg string, d time.Duration) event { return event{Msg: msg, At: t0.Add(d)} }
+	b := mock.NewBuilder("d")
+	e, err := engine.Build().Rules(
+		b.Rule("ab", "d:a", "d:b", b.Query("d:b", "ab", at("b1", -time.Minute), at("boom", 2*time.Minute))),
+		b.Rule("bc", "d:b", "d:c", b.Query("d:c", "bc", at("c1", time.Minute), "no time")),
+	).Stores(b.Store("d", nil)).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "boom",
+			Start:  config.ClassSpec{Domain: "d", Classes: []string{"b"}},
+			Status: `{{if eq .Msg "boom"}}Error{{end}}`,
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	a := b.Class("d:a")
+	g, err := traverse.Neighbors(context.Background(), e, traverse.Start{Class: a, Objects: []korrel8r.Object{at("a", 0)}}, 3)
+	require.NoError(t, err)
+
+	type entry struct {
+		Class    string
+		Msg      string
+		Rules    []string
+		Statuses []string
+	}
+	var got []entry
+	for _, x := range timeline.New(e, g, a) {
+		var rules []string
+		for _, r := range x.Rules {
+			rules = append(rules, r.Name())
+		}
+		assert.Equal(t, x.Object.(event).At, x.Time)
+		got = append(got, entry{x.Class.String(), x.Object.(event).Msg, rules, x.Statuses})
+	}
+	assert.Equal(t, []entry{
+		{"d:b", "b1", []string{"ab"}, nil},
+		{"d:a", "a", nil, nil},
+		{"d:c", "c1", []string{"ab", "bc"}, nil},
+		{"d:b", "boom", []string{"ab"}, []string{"Error"}},
+	}, got)
+
+	assert.Empty(t, timeline.New(e, nil, a))
+}
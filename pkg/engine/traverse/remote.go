@@ -0,0 +1,134 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package traverse
+
+import (
+	"context"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
+	"github.com/korrel8r/korrel8r/pkg/remote"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+// forwardOptions request everything needed to rebuild the graph locally.
+// Rank order is requested to get relevance scores, the remote scores objects relative to the same start.
+var forwardOptions = api.GraphOptions{Errors: new(true), Results: new(true), Rules: new(true), Order: new(api.OrderRank)}
+
+// forward runs a complete search on a remote korrel8r using the search function,
+// and converts the result to a local graph.
+func forward(ctx context.Context, e *engine.Engine, r *remote.Client, start Start, search func(context.Context, api.Start) (*api.Graph, error)) (*graph.Graph, error) {
+	cluster := start.Constraint.GetCluster()
+	log.V(2).Info("Forward search", "remote", r.URL(), "cluster", cluster)
+	as := api.Start{Class: start.Class.String(), Constraint: remote.Constraint(start.Constraint)}
+	if as.Constraint != nil {
+		as.Constraint.Cluster = "" // Cluster names are local to this korrel8r.
+	}
+	for _, q := range start.Queries {
+		as.Queries = append(as.Queries, q.String())
+	}
+	for _, o := range start.Objects {
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, err
+		}
+		as.Objects = append(as.Objects, b)
+	}
+	ag, err := search(ctx, as)
+	if err != nil {
+		return nil, err
+	}
+	return fromRemote(e, ag, cluster)
+}
+
+// fromRemote converts a graph returned by a remote korrel8r to a local graph.
+// All objects are annotated with cluster.
+// Classes and rules that are not known locally are omitted.
+func fromRemote(e *engine.Engine, ag *api.Graph, cluster string) (*graph.Graph, error) {
+	data := e.Graph().Data
+	g := graph.New(data)
+	g.Errors = ag.Errors
+	nodes := map[string]*graph.Node{}
+	for _, an := range ag.Nodes {
+		c, err := e.Class(an.Class)
+		if err != nil {
+			log.V(3).Info("Skipping unknown remote class", "class", an.Class, "error", err)
+			continue
+		}
+		dn := data.NodeFor(c)
+		if dn == nil {
+			continue
+		}
+		n := &graph.Node{Node: dn.Node, Class: c, Attrs: graph.Attrs{}, Result: result.New(c), Queries: graph.Queries{}}
+		for _, raw := range an.Result {
+			o, err := c.Unmarshal(raw)
+			if err != nil {
+				return nil, err
+			}
+			n.Result.Append(o)
+		}
+		if n.Empty() {
+			continue
+		}
+		for range n.Result.List() {
+			n.Clusters = append(n.Clusters, cluster)
+		}
+		if len(an.ResultScores) == len(n.Result.List()) {
+			n.Scores = an.ResultScores
+		}
+		queries(e, an.Queries, n.Queries)
+		g.AddNode(n)
+		nodes[an.Class] = n
+	}
+	for _, ae := range ag.Edges {
+		from, to := nodes[ae.Start], nodes[ae.Goal]
+		if from == nil || to == nil {
+			continue
+		}
+		for _, ar := range ae.Rules {
+			r := e.Rule(ar.Name)
+			if r == nil {
+				continue
+			}
+			dl := e.Graph().FindLine(from.Class, to.Class, r)
+			if dl == nil {
+				continue
+			}
+			l := &graph.Line{Line: multi.Line{F: from, T: to, UID: dl.UID}, Rule: r, Attrs: graph.Attrs{}, Queries: graph.Queries{}}
+			queries(e, ar.Queries, l.Queries)
+			g.AddLine(l)
+		}
+	}
+	return g, nil
+}
+
+// queries adds remote query counts to qs, skipping queries that can't be parsed locally.
+func queries(e *engine.Engine, aqs []api.QueryCount, qs graph.Queries) {
+	for _, aq := range aqs {
+		q, err := e.Query(aq.Query)
+		if err != nil {
+			continue
+		}
+		qs.Add(q, ptr.Deref(aq.Count))
+		if len(aq.Statuses) > 0 {
+			statuses := map[string]int{}
+			for _, s := range aq.Statuses {
+				statuses[s.Status] += ptr.Deref(s.Count)
+			}
+			qs.AddStatuses(q, statuses)
+		}
+	}
+}
+
+func classNames(classes []korrel8r.Class) []string {
+	names := make([]string, len(classes))
+	for i, c := range classes {
+		names[i] = c.String()
+	}
+	return names
+}
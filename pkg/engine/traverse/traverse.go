@@ -12,17 +12,30 @@
 //     c. Resulting queries are deduplicated and sent back to the channel.
 //  4. Traversal completes when all in-flight work is done (tracked by sync.WaitGroup).
 //  5. A result graph is built from only the nodes and lines that produced results.
+//
+// If [Start.Rank] is set, rules are applied one depth at a time instead of immediately.
+// When all queries for a depth are done, each node is ranked and limited,
+// and rules are applied only to the new objects that are kept.
+// Objects are scored for relevance only when ranking, see package [rank].
 package traverse
 
 import (
+	"cmp"
 	"context"
-
+	"errors"
+	"fmt"
 	"math"
 	"runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/authz"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rank"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/result"
@@ -37,14 +50,22 @@ import (
 )
 
 // Goals traverses all paths from start objects to all goal classes.
+//
+// If the constraint cluster is served by a remote korrel8r (see [engine.Engine.Remote])
+// the search is forwarded to the remote korrel8r.
 func Goals(ctx context.Context, e *engine.Engine, start Start, goals []korrel8r.Class) (*graph.Graph, error) {
 	log.V(2).Info("Goal directed search", "start", start, "goals", goals, "constraint", start.Constraint)
+	if r := e.Remote(start.Constraint.GetCluster()); r != nil {
+		return forward(ctx, e, r, start, func(ctx context.Context, as api.Start) (*api.Graph, error) {
+			return r.Goals(ctx, api.Goals{Start: as, Goals: classNames(goals)}, forwardOptions)
+		})
+	}
 	shared := e.Graph()
 	scope, err := goalScope(shared, start.Class, goals)
 	if err != nil {
 		return nil, err
 	}
-	g, err := newTraverser(e, shared.Data, scope, start.Constraint, -1).run(ctx, start)
+	g, err := newTraverser(e, shared.Data, scope, start, -1).run(ctx, start)
 	if err != nil {
 		return nil, err
 	}
@@ -54,14 +75,38 @@ func Goals(ctx context.Context, e *engine.Engine, start Start, goals []korrel8r.
 }
 
 // Neighbors traverses to all neighbors of the start objects, traversing links up to the given depth.
+//
+// If the constraint cluster is served by a remote korrel8r (see [engine.Engine.Remote])
+// the search is forwarded to the remote korrel8r.
 func Neighbors(ctx context.Context, e *engine.Engine, start Start, depth int) (*graph.Graph, error) {
 	log.V(2).Info("Neighbourhood search", "start", start, "depth", depth, "constraint", start.Constraint)
+	if r := e.Remote(start.Constraint.GetCluster()); r != nil {
+		return forward(ctx, e, r, start, func(ctx context.Context, as api.Start) (*api.Graph, error) {
+			return r.Neighbors(ctx, api.Neighbors{Start: as, Depth: depth}, forwardOptions)
+		})
+	}
 	shared := e.Graph()
 	scope, err := neighborScope(shared, start.Class, depth)
 	if err != nil {
 		return nil, err
 	}
-	return newTraverser(e, shared.Data, scope, start.Constraint, depth).run(ctx, start)
+	return newTraverser(e, shared.Data, scope, start, depth).run(ctx, start)
+}
+
+// Recipe runs a recipe search from start.Queries, returned by [engine.Recipe.Queries].
+// The start class is the class of the queries. If start.Constraint is nil, the recipe constraint is used.
+func Recipe(ctx context.Context, e *engine.Engine, r *engine.Recipe, start Start) (*graph.Graph, error) {
+	if len(start.Queries) == 0 {
+		return nil, fmt.Errorf("recipe %v: no start queries", r.Name)
+	}
+	if start.Constraint == nil {
+		start.Constraint = r.Constraint(time.Now())
+	}
+	start.Class = start.Queries[0].Class()
+	if len(r.Goals()) > 0 {
+		return Goals(ctx, e, start, r.Goals())
+	}
+	return Neighbors(ctx, e, start, r.Depth)
 }
 
 // neighborScope returns the lines reachable within maxDepth BFS hops from start.
@@ -116,16 +161,26 @@ type Start struct {
 	Objects    []korrel8r.Object    // Start objects, must be of Start class.
 	Queries    []korrel8r.Query     // Queries for start objects, must be of Start class.
 	Constraint *korrel8r.Constraint // Constraint to apply during the traversal.
+	// Rank objects in each node by relevance before rules are applied, and keep the [rank.Limit] best.
+	// Stores are queried with the [rank.Fetch] constraint, so more objects than the limit are ranked.
+	Rank bool
 }
 
 var log = logging.Log()
 
 // queryLine is a query, the graph line that generated it, and its traversal depth.
 type queryLine struct {
-	Query korrel8r.Query
-	Line  *graph.Line // immutable line (for Rule, String, metric attrs)
-	key   lineKey     // overlay state key
-	depth int
+	Query   korrel8r.Query
+	Line    *graph.Line // immutable line (for Rule, String, metric attrs)
+	key     lineKey     // overlay state key
+	depth   int
+	cluster string // cluster to search, "" for all clusters
+}
+
+// seenKey identifies a query evaluated in a cluster.
+type seenKey struct {
+	query   korrel8r.Query
+	cluster string
 }
 
 type lineKey struct {
@@ -138,8 +193,32 @@ type node struct {
 	mu        sync.Mutex
 	class     korrel8r.Class
 	result    result.Result
+	clusters  []string                 // cluster of each object in result
+	origins   []queryLine              // query that added each object in result, zero for start objects
+	applied   []bool                   // true if rules were applied to each object in result
+	scores    []float64                // score of each object in result, only for ranked traversals
+	unique    map[string]result.Result // de-duplicate objects per cluster
 	queries   graph.Queries
-	processed int // count of result objects already rule-applied
+	processed int // objects before this index in result have had rules applied
+}
+
+// addLH adds o from cluster, found by query ql, to the node if it is new, returns true if added.
+// Objects with the same identity in different clusters are distinct.
+// Must be called with the lock held.
+func (n *node) addLH(cluster string, o korrel8r.Object, ql queryLine) bool {
+	u := n.unique[cluster]
+	if u == nil {
+		u = result.New(n.class)
+		n.unique[cluster] = u
+	}
+	if !u.Add(o) {
+		return false
+	}
+	n.result.Append(o)
+	n.clusters = append(n.clusters, cluster)
+	n.origins = append(n.origins, ql)
+	n.applied = append(n.applied, false)
+	return true
 }
 
 // workQueue is an unbounded, mutex-protected FIFO queue.
@@ -189,7 +268,11 @@ type traverser struct {
 	engine     *engine.Engine
 	data       *graph.Data
 	constraint *korrel8r.Constraint
-	maxDepth   int // -1 for unlimited
+	fetch      *korrel8r.Constraint // Constraint for store queries, see [Start.Rank].
+	maxDepth   int                  // -1 for unlimited
+	rank       bool                 // Rank nodes before applying rules, see [Start.Rank].
+	limit      int                  // Objects kept in each node when ranking.
+	ranker     *rank.Ranker
 
 	// Read-only after init
 	nodes      map[korrel8r.Class]*node
@@ -202,17 +285,22 @@ type traverser struct {
 	lineQueries map[lineKey]graph.Queries // overlay: mutable line queries
 	work        *workQueue
 	wg          sync.WaitGroup
+	queued      atomic.Int64 // Number of queries added to work.
 	seenMu      sync.Mutex
-	seen        map[korrel8r.Query]struct{}
+	seen        map[seenKey]struct{}
 	lineMu      sync.Mutex
+	errMu       sync.Mutex
+	errs        *unique.List[string] // Objects dropped by authorization, see [graph.Graph.Errors].
 }
 
-func newTraverser(e *engine.Engine, data *graph.Data, scopeLines []*graph.Line, c *korrel8r.Constraint, maxDepth int) *traverser {
+func newTraverser(e *engine.Engine, data *graph.Data, scopeLines []*graph.Line, start Start, maxDepth int) *traverser {
 	t := &traverser{
 		engine:      e,
 		data:        data,
-		constraint:  c,
+		constraint:  start.Constraint,
+		fetch:       start.Constraint,
 		maxDepth:    maxDepth,
+		rank:        start.Rank,
 		nodes:       map[korrel8r.Class]*node{},
 		rules:       map[korrel8r.Class]unique.Set[korrel8r.Rule]{},
 		lines:       map[lineKey]*graph.Line{},
@@ -220,7 +308,11 @@ func newTraverser(e *engine.Engine, data *graph.Data, scopeLines []*graph.Line,
 		lineMetric:  map[*graph.Line]metric.MeasurementOption{},
 		ruleMetric:  map[korrel8r.Rule]metric.MeasurementOption{},
 		work:        newWorkQueue(),
-		seen:        map[korrel8r.Query]struct{}{},
+		seen:        map[seenKey]struct{}{},
+		errs:        unique.NewList[string](),
+	}
+	if t.rank {
+		t.fetch, t.limit = rank.Fetch(start.Constraint), rank.Limit(start.Constraint)
 	}
 
 	for _, l := range scopeLines {
@@ -256,7 +348,8 @@ func (t *traverser) getOrCreateNode(class korrel8r.Class) *node {
 	if n == nil {
 		n = &node{
 			class:   class,
-			result:  result.New(class),
+			result:  result.NewList(),
+			unique:  map[string]result.Result{},
 			queries: graph.Queries{},
 		}
 		t.nodes[class] = n
@@ -285,24 +378,122 @@ func (t *traverser) run(ctx context.Context, start Start) (*graph.Graph, error)
 	// Sentinel prevents premature WaitGroup completion during priming.
 	t.wg.Add(1)
 
+	cluster := t.constraint.GetCluster()
 	startNode.mu.Lock()
-	startNode.result.Append(start.Objects...)
+	for _, o := range start.Objects {
+		startNode.addLH(cluster, o, queryLine{})
+	}
 	startNode.mu.Unlock()
 
 	for _, q := range start.Queries {
-		t.dedupAndSend(ctx, queryLine{Query: q, depth: 0})
+		t.dedupAndSend(ctx, queryLine{Query: q, depth: 0, cluster: cluster})
 	}
 
-	t.applyRules(ctx, startNode, 1)
+	if !t.rank {
+		t.applyRules(ctx, startNode, 1)
+	}
 
 	t.wg.Done() // Release sentinel.
 	t.wg.Wait()
+	if t.rank {
+		t.runRanked(ctx, startNode)
+	}
 	t.work.close()
 	workerWg.Wait()
 
 	return t.buildGraph(), ctx.Err()
 }
 
+// runRanked applies rules one depth at a time, called when the start queries are done.
+// Before each depth all nodes are ranked, rules are applied to the new objects that are kept.
+func (t *traverser) runRanked(ctx context.Context, start *node) {
+	t.ranker = rank.New(t.engine, start.class, start.result.List())
+	for depth := 1; ; depth++ {
+		t.rankNodes()
+		if ctx.Err() != nil {
+			return
+		}
+		queued := t.queued.Load()
+		t.wg.Add(1) // Sentinel
+		for _, n := range t.nodes {
+			t.applyRules(ctx, n, depth)
+		}
+		t.wg.Done()
+		if t.queued.Load() == queued {
+			return // No new queries, all nodes are ranked.
+		}
+		t.wg.Wait()
+	}
+}
+
+// rankNodes sorts the objects in each node by decreasing score, and keeps the best t.limit objects.
+// Query counts and statuses of nodes and lines are re-counted for the objects that are kept.
+// Must not be called while queries are in progress.
+func (t *traverser) rankNodes() {
+	t.lineMu.Lock()
+	defer t.lineMu.Unlock()
+	for _, qs := range t.lineQueries {
+		for q := range qs {
+			qs.Set(q, 0)
+		}
+	}
+	for _, n := range t.nodes {
+		t.rankNode(n)
+	}
+}
+
+// rankNode ranks and limits one node, see [traverser.rankNodes]. Must be called with t.lineMu held.
+func (t *traverser) rankNode(n *node) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	objects := n.result.List()
+	n.scores = append(n.scores, t.ranker.Scores(n.class, objects[len(n.scores):])...)
+	order := make([]int, len(objects))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp.Compare(n.scores[j], n.scores[i]) })
+	if t.limit > 0 && len(order) > t.limit {
+		order = order[:t.limit]
+	}
+	// Dropped objects stay in n.unique, so they are not added again.
+	r := result.NewList()
+	clusters, origins := make([]string, 0, len(order)), make([]queryLine, 0, len(order))
+	applied, scores := make([]bool, 0, len(order)), make([]float64, 0, len(order))
+	for _, i := range order {
+		r.Append(objects[i])
+		clusters, origins = append(clusters, n.clusters[i]), append(origins, n.origins[i])
+		applied, scores = append(applied, n.applied[i]), append(scores, n.scores[i])
+	}
+	n.result, n.clusters, n.origins, n.applied, n.scores = r, clusters, origins, applied, scores
+	n.processed = 0 // Objects are re-ordered, applied objects are not a prefix.
+
+	for q := range n.queries {
+		n.queries.Set(q, 0)
+	}
+	statusRules := t.engine.StatusRulesFor(n.class)
+	for i, o := range r.List() {
+		ql := n.origins[i]
+		if ql.Query == nil {
+			continue // Start object
+		}
+		n.queries.Add(ql.Query, 1)
+		if ql.Line != nil {
+			t.lineQueries[ql.key].Add(ql.Query, 1)
+		}
+		statusCounts := map[string]int{}
+		for _, sr := range statusRules {
+			statuses, _ := sr.Apply(o)
+			for _, s := range statuses {
+				statusCounts[s]++
+			}
+		}
+		if len(statusCounts) > 0 {
+			n.queries.AddStatuses(ql.Query, statusCounts)
+		}
+	}
+}
+
 // buildGraph creates a result graph containing only nodes and lines that produced results.
 func (t *traverser) buildGraph() *graph.Graph {
 	g := graph.New(t.data)
@@ -321,6 +512,10 @@ func (t *traverser) buildGraph() *graph.Graph {
 			Attrs:   graph.Attrs{},
 			Result:  n.result,
 			Queries: n.queries,
+			Scores:  n.scores,
+		}
+		if slices.ContainsFunc(n.clusters, func(c string) bool { return c != "" }) {
+			gn.Clusters = n.clusters
 		}
 		g.AddNode(gn)
 		nodeMap[n.class] = gn
@@ -342,6 +537,7 @@ func (t *traverser) buildGraph() *graph.Graph {
 		}
 		g.AddLine(l)
 	}
+	g.Errors = t.errs.List
 	return g
 }
 
@@ -357,13 +553,15 @@ func (t *traverser) dedupAndSend(ctx context.Context, ql queryLine) {
 		return
 	}
 	t.wg.Add(1)
+	t.queued.Add(1)
 	t.work.put(ql)
 }
 
 func (t *traverser) isDuplicate(ctx context.Context, ql queryLine) bool {
 	t.seenMu.Lock()
 	defer t.seenMu.Unlock()
-	if _, exists := t.seen[ql.Query]; exists {
+	key := seenKey{query: ql.Query, cluster: ql.cluster}
+	if _, exists := t.seen[key]; exists {
 		if ql.Line != nil {
 			metricDuplicateQueries.Add(ctx, 1, t.lineMetric[ql.Line])
 		} else {
@@ -371,7 +569,7 @@ func (t *traverser) isDuplicate(ctx context.Context, ql queryLine) bool {
 		}
 		return true
 	}
-	t.seen[ql.Query] = struct{}{}
+	t.seen[key] = struct{}{}
 	return false
 }
 
@@ -391,11 +589,25 @@ func (t *traverser) handleQuery(ctx context.Context, ql queryLine) {
 		return
 	}
 
-	// Execute query into a local slice.
+	// Execute query into local slices.
+	constraint := t.fetch
+	if ql.cluster != constraint.GetCluster() {
+		constraint = constraint.WithCluster(ql.cluster)
+	}
 	var results []korrel8r.Object
-	_ = t.engine.Get(ctx, ql.Query, t.constraint, korrel8r.AppenderFunc(func(objects ...korrel8r.Object) {
+	var clusters []string
+	err := t.engine.GetClusters(ctx, ql.Query, constraint, func(cluster string, objects ...korrel8r.Object) {
 		results = append(results, objects...)
-	}))
+		for range objects {
+			clusters = append(clusters, cluster)
+		}
+	})
+	var dropped *authz.DroppedError
+	if errors.As(err, &dropped) { // Other store errors are not reported to the caller.
+		t.errMu.Lock()
+		t.errs.Add(dropped.Error())
+		t.errMu.Unlock()
+	}
 	if ql.Line != nil {
 		metricQueries.Add(ctx, 1, t.lineMetric[ql.Line])
 	} else {
@@ -409,19 +621,22 @@ func (t *traverser) handleQuery(ctx context.Context, ql queryLine) {
 	// 3. We only read indices < our captured len, so concurrent writes at higher indices don't matter.
 	n.mu.Lock()
 	before := len(n.result.List())
-	for _, o := range results {
-		n.result.Add(o)
+	for i, o := range results {
+		n.addLH(clusters[i], o, ql)
 	}
 	resultList := n.result.List()
 	resultCount := len(resultList) - before
-	n.queries.Set(ql.Query, resultCount)
+	n.queries.Add(ql.Query, resultCount)
 	n.mu.Unlock()
 
 	if ql.Line != nil {
 		t.lineMu.Lock()
-		t.lineQueries[ql.key].Set(ql.Query, resultCount)
+		t.lineQueries[ql.key].Add(ql.Query, resultCount)
 		t.lineMu.Unlock()
 	}
+	if t.rank {
+		return // Statuses are counted and rules applied after ranking, see [traverser.runRanked].
+	}
 
 	// Apply status rules to unique new objects.
 	statusRules := t.engine.StatusRulesFor(goalClass)
@@ -456,24 +671,34 @@ func (n *node) overLimit(limit int) bool {
 }
 
 // applyRules applies outgoing correlation rules to unprocessed objects in a node.
-// The processed counter ensures each object is rule-applied exactly once,
+// The applied flags ensure each object is rule-applied exactly once,
 // even when multiple goroutines call this concurrently for the same node.
+//
+// Resulting queries search the cluster of the start object, unless the rule crosses clusters.
 func (t *traverser) applyRules(ctx context.Context, n *node, nextDepth int) {
-	// Snapshot the objects, update processed, release the lock
+	// Snapshot the unprocessed objects, mark them applied, release the lock
 	n.mu.Lock()
 	objects := n.result.List()
-	start := n.processed
+	clusters := n.clusters
+	var todo []int
+	for i := n.processed; i < len(objects); i++ {
+		if !n.applied[i] {
+			n.applied[i] = true
+			todo = append(todo, i)
+		}
+	}
 	n.processed = len(objects)
 	class := n.class
 	n.mu.Unlock()
 
-	if start >= len(objects) {
-		return
-	}
-
 	rules := t.rules[class]
-	for _, o := range objects[start:] {
+	for _, i := range todo {
+		o, from := objects[i], clusters[i]
 		for r := range rules {
+			cluster := from
+			if cluster == "" || t.engine.CrossCluster(r) {
+				cluster = t.constraint.GetCluster()
+			}
 			if ctx.Err() != nil {
 				return
 			}
@@ -483,7 +708,7 @@ func (t *traverser) applyRules(ctx context.Context, n *node, nextDepth int) {
 			for _, q := range queries {
 				key := lineKey{start: class, rule: r, goal: q.Class()}
 				if line := t.lines[key]; line != nil {
-					t.dedupAndSend(ctx, queryLine{Query: q, Line: line, key: key, depth: nextDepth})
+					t.dedupAndSend(ctx, queryLine{Query: q, Line: line, key: key, depth: nextDepth, cluster: cluster})
 				}
 			}
 		}
@@ -4,17 +4,27 @@ package traverse
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/authz"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestTraverserGoals(t *testing.T) {
@@ -411,3 +421,138 @@ func TestGoalScope(t *testing.T) {
 	})
 }
 
+func TestTraverserClusters(t *testing.T) {
+	d := mock.NewDomain("d", "a", "b", "c")
+	a, b, c := d.Class("a"), d.Class("b"), d.Class("c")
+	dir := t.TempDir()
+	store := func(cluster, data string) config.Store {
+		file := filepath.Join(dir, cluster+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(data), 0o644))
+		return config.Store{config.StoreKeyDomain: "d", config.StoreKeyCluster: cluster, config.StoreKeyMock: file}
+	}
+	stores := []config.Store{
+		// Both clusters have c objects for both b objects, only same-cluster results should be found.
+		store("east", `{"d:b:x": ["p1"], "d:c:p1": ["east-c1"], "d:c:p2": ["east-c2"]}`),
+		store("west", `{"d:b:x": ["p2"], "d:c:p1": ["west-c1"], "d:c:p2": ["west-c2"]}`),
+	}
+	rules := []korrel8r.Rule{
+		mock.NewRule("ab", []korrel8r.Class{a}, []korrel8r.Class{b}, mock.NewQuery(b, "x")),
+		mock.NewRule("bc", []korrel8r.Class{b}, []korrel8r.Class{c}, func(o korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{mock.NewQuery(c, o.(string))}, nil
+		}),
+	}
+	// clustered returns "cluster/object" strings for a node.
+	clustered := func(g *graph.Graph, class korrel8r.Class) (s []string) {
+		n := g.NodeFor(class)
+		require.NotNil(t, n)
+		require.Len(t, n.Clusters, len(n.Result.List()))
+		for i, o := range n.Result.List() {
+			s = append(s, fmt.Sprintf("%v/%v", n.Clusters[i], o))
+		}
+		return s
+	}
+	start := Start{Class: a, Objects: []korrel8r.Object{"start"}}
+
+	t.Run("same cluster", func(t *testing.T) {
+		e, err := engine.Build().Domains(d).StoreConfigs(stores...).Rules(rules...).Engine()
+		require.NoError(t, err)
+		g, err := Goals(context.Background(), e, start, []korrel8r.Class{c})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"east/p1", "west/p2"}, clustered(g, b))
+		assert.ElementsMatch(t, []string{"east/east-c1", "west/west-c2"}, clustered(g, c))
+		assert.Nil(t, g.NodeFor(a).Clusters, "start objects have no cluster")
+	})
+
+	t.Run("cross cluster", func(t *testing.T) {
+		e, err := engine.Build().Domains(d).StoreConfigs(stores...).Rules(rules...).CrossCluster("bc").Engine()
+		require.NoError(t, err)
+		g, err := Goals(context.Background(), e, start, []korrel8r.Class{c})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"east/east-c1", "east/east-c2", "west/west-c1", "west/west-c2"}, clustered(g, c))
+	})
+
+	t.Run("constraint cluster", func(t *testing.T) {
+		e, err := engine.Build().Domains(d).StoreConfigs(stores...).Rules(rules...).CrossCluster("bc").Engine()
+		require.NoError(t, err)
+		start := start
+		start.Constraint = &korrel8r.Constraint{Cluster: "west"}
+		g, err := Goals(context.Background(), e, start, []korrel8r.Class{c})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"west/p2"}, clustered(g, b))
+		assert.ElementsMatch(t, []string{"west/west-c2"}, clustered(g, c))
+	})
+}
+
+func TestTraverserRank(t *testing.T) {
+	d := mock.NewDomain("d", "a", "b", "c")
+	a, b, c := d.Class("a"), d.Class("b"), d.Class("c")
+	s := mock.NewStore(d)
+	s.AddQuery("d:b:x", []korrel8r.Object{"ok", "fine", "boom"})
+	for _, o := range []string{"ok", "fine", "boom"} {
+		s.AddQuery("d:c:"+o, []korrel8r.Object{"c-" + o})
+	}
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("ab", []korrel8r.Class{a}, []korrel8r.Class{b}, mock.NewQuery(b, "x")),
+		mock.NewRule("bc", []korrel8r.Class{b}, []korrel8r.Class{c}, func(o korrel8r.Object) ([]korrel8r.Query, error) {
+			return []korrel8r.Query{mock.NewQuery(c, o.(string))}, nil
+		}),
+	).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "boom",
+			Start:  config.ClassSpec{Domain: "d", Classes: []string{"b"}},
+			Status: `{{if eq . "boom"}}Error{{end}}`,
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	start := Start{Class: a, Objects: []korrel8r.Object{"start"}, Constraint: &korrel8r.Constraint{Limit: new(1)}}
+
+	t.Run("unranked", func(t *testing.T) {
+		g, err := Neighbors(context.Background(), e, start, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []korrel8r.Object{"ok"}, g.NodeFor(b).Result.List())
+		assert.Equal(t, []korrel8r.Object{"c-ok"}, g.NodeFor(c).Result.List())
+		assert.Nil(t, g.NodeFor(b).Scores, "not scored")
+	})
+
+	t.Run("ranked", func(t *testing.T) {
+		start := start
+		start.Rank = true
+		g, err := Neighbors(context.Background(), e, start, 2)
+		require.NoError(t, err)
+		nb := g.NodeFor(b)
+		assert.Equal(t, []korrel8r.Object{"boom"}, nb.Result.List())
+		assert.Len(t, nb.Scores, 1)
+		assert.Equal(t, 1, nb.Queries.Total(), "only objects that are kept are counted")
+		// Rules are applied only to the objects that are kept.
+		nc := g.NodeFor(c)
+		assert.Equal(t, []korrel8r.Object{"c-boom"}, nc.Result.List())
+		assert.Len(t, nc.Queries, 1)
+	})
+}
+
+// nsClass is a mock class where each object is the name of its namespace.
+type nsClass struct{ korrel8r.Class }
+
+func (nsClass) Namespace(o korrel8r.Object) string { return o.(string) }
+
+func TestTraverserAuthorization(t *testing.T) {
+	d := mock.NewDomain("d", "a", "b")
+	a, b := nsClass{d.Class("a")}, nsClass{d.Class("b")}
+	rule := mock.NewRule("ab", []korrel8r.Class{a}, []korrel8r.Class{b}, mock.NewQuery(b, "x", "allowed", "denied", "denied"))
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			sar := obj.(*authv1.SelfSubjectAccessReview)
+			sar.Status.Allowed = sar.Spec.ResourceAttributes.Namespace == "allowed"
+			return nil
+		},
+	}).Build()
+	// Store errors are not reported in graph errors, only dropped objects.
+	failed := mock.NewRule("ab-failed", []korrel8r.Class{a}, []korrel8r.Class{b}, mock.NewQueryError(b, "failed", errors.New("store failed")))
+	e, err := engine.Build().Domains(d).Stores(mock.NewStore(d)).Rules(rule, failed).
+		Authorize(authz.New(config.Authorization{}, c)).Engine()
+	require.NoError(t, err)
+	g, err := Neighbors(auth.WithToken(context.Background(), "token"), e, Start{Class: a, Objects: []korrel8r.Object{"start"}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []korrel8r.Object{"allowed"}, g.NodeFor(b).Result.List())
+	assert.Equal(t, []string{"d:b: 2 objects dropped, not authorized"}, g.Errors)
+}
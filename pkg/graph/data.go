@@ -134,6 +134,16 @@ type Node struct {
 	Class   korrel8r.Class
 	Result  result.Result // Accumulate incoming query results.
 	Queries Queries       // All queries leading to this node.
+
+	// Clusters has the cluster of each object in Result, in the same order, "" for objects with no cluster.
+	// Nil if no objects have a cluster.
+	Clusters []string
+
+	// Scores has the relevance score of each object in Result, in the same order, see package [rank].
+	// Nil if the objects were not scored.
+	//
+	// [rank]: https://pkg.go.dev/github.com/korrel8r/korrel8r/pkg/engine/rank
+	Scores []float64
 }
 
 // Copy returns a new Node with the same identity but fresh mutable state.
@@ -179,6 +189,16 @@ func (qs Queries) Has(q korrel8r.Query) bool { _, ok := qs[q]; return ok }
 func (qs Queries) Set(q korrel8r.Query, n int) {
 	qs[q] = QueryCount{Query: q, Count: n}
 }
+
+// Add adds n to the count for q, keeping any status counts.
+// Use Add for a query that is evaluated more than once, for example in different clusters.
+func (qs Queries) Add(q korrel8r.Query, n int) {
+	qc := qs[q]
+	qc.Query = q
+	qc.Count += n
+	qs[q] = qc
+}
+
 func (qs Queries) Get(q korrel8r.Query) int {
 	if qc, ok := qs[q]; ok {
 		return qc.Count
@@ -7,6 +7,7 @@
 package graph
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"slices"
@@ -28,7 +29,8 @@ type Graph struct {
 	*multi.DirectedGraph
 	GraphAttrs, NodeAttrs, EdgeAttrs Attrs
 	Data                             *Data
-	allLines                         []*Line // Cached lines; nil = use gonum iterators.
+	Errors                           []string // Objects dropped by authorization in the search that built the graph.
+	allLines                         []*Line  // Cached lines; nil = use gonum iterators.
 }
 
 // New empty graph based on Data
@@ -161,6 +163,32 @@ func (g *Graph) EachLineTo(goal *Node, visit func(*Line)) {
 	}
 }
 
+// RulePaths returns the shortest path of rules from start to each class in g, by breadth-first search.
+// The path to start is empty, classes that can't be reached from start are omitted.
+// Lines are followed in order of rule name, so paths do not depend on graph iteration order.
+func (g *Graph) RulePaths(start korrel8r.Class) map[korrel8r.Class][]korrel8r.Rule {
+	paths := map[korrel8r.Class][]korrel8r.Rule{}
+	n := g.NodeFor(start)
+	if n == nil {
+		return paths
+	}
+	paths[start] = nil
+	for queue := []*Node{n}; len(queue) > 0; queue = queue[1:] {
+		from := queue[0]
+		var lines []*Line
+		g.EachLineFrom(from, func(l *Line) { lines = append(lines, l) })
+		slices.SortFunc(lines, func(a, b *Line) int { return cmp.Compare(a.Rule.Name(), b.Rule.Name()) })
+		for _, l := range lines {
+			to := l.Goal()
+			if _, seen := paths[to.Class]; !seen {
+				paths[to.Class] = append(slices.Clip(paths[from.Class]), l.Rule)
+				queue = append(queue, to)
+			}
+		}
+	}
+	return paths
+}
+
 // Select creates a mutable sub-graph of all lines where keep(line) is true.
 func (g *Graph) Select(keep func(*Line) bool) *Graph {
 	sub := g.Data.EmptyGraph()
@@ -180,6 +180,33 @@ func TestEachLineTo(t *testing.T) {
 	assert.Equal(t, []string{"ab", "cb"}, lines)
 }
 
+func TestRulePaths(t *testing.T) {
+	b := mock.NewBuilder("d")
+	r := b.Rule
+	g := NewData(
+		r("ab", "d:a", "d:b", nil),
+		r("ac", "d:a", "d:c", nil),
+		r("cb", "d:c", "d:b", nil),
+		r("bd", "d:b", "d:d", nil),
+		r("xa", "d:x", "d:a", nil),
+	).FullGraph()
+
+	paths := map[string][]string{}
+	for c, rules := range g.RulePaths(b.Class("d:a")) {
+		paths[c.String()] = []string{}
+		for _, r := range rules {
+			paths[c.String()] = append(paths[c.String()], r.Name())
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"d:a": {},
+		"d:b": {"ab"},
+		"d:c": {"ac"},
+		"d:d": {"ab", "bd"},
+	}, paths)
+	assert.Empty(t, g.RulePaths(b.Class("d:nope")))
+}
+
 func TestSelect(t *testing.T) {
 	b := mock.NewBuilder("d")
 	r := b.Rule
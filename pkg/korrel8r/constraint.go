@@ -18,6 +18,8 @@ type Constraint struct {
 	Start *time.Time `json:"start,omitempty"`
 	// End ignore data after this time (RFC 3339)
 	End *time.Time `json:"end,omitempty"`
+	// Cluster only use stores for this cluster, and stores with no cluster.
+	Cluster string `json:"cluster,omitempty"`
 }
 
 func (c *Constraint) String() string {
@@ -84,6 +86,24 @@ func (c *Constraint) GetQueryLimit() int {
 	return 0
 }
 
+// GetCluster returns the cluster or "", safe to call with c == nil
+func (c *Constraint) GetCluster() string {
+	if c != nil {
+		return c.Cluster
+	}
+	return ""
+}
+
+// WithCluster returns a copy of c with the cluster set. Safe to call with c == nil.
+func (c *Constraint) WithCluster(cluster string) *Constraint {
+	var c2 Constraint
+	if c != nil {
+		c2 = *c
+	}
+	c2.Cluster = cluster
+	return &c2
+}
+
 func (c *Constraint) GetStart() time.Time {
 	if c != nil && c.Start != nil {
 		return *c.Start
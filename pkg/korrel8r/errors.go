@@ -2,7 +2,10 @@
 
 package korrel8r
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type ClassNotFoundError struct {
 	Domain, Name string
@@ -27,3 +30,6 @@ func (e *DomainNotFoundError) Error() string {
 func NewDomainNotFoundError(domain string) error {
 	return &DomainNotFoundError{Domain: domain}
 }
+
+// ErrNotAggregated is returned by [Aggregator] stores that cannot count a query in the backend.
+var ErrNotAggregated = errors.New("aggregation not supported by store")
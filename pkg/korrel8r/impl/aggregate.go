@@ -0,0 +1,143 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package impl
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Field returns the value of a field of an object as a string, "" if there is no such field.
+//
+// The name is a path of keys separated by ".", for example "status.phase".
+// Keys may contain "." characters, the longest matching key is used at each level,
+// so "metadata.labels.app.kubernetes.io/name" finds the label "app.kubernetes.io/name".
+// Objects that are not maps are converted to JSON form first.
+// String values are returned unchanged, other values are formatted as JSON.
+func Field(o korrel8r.Object, name string) string {
+	var v any
+	switch o := o.(type) {
+	case map[string]string:
+		return o[name]
+	case map[string]any:
+		v = o
+	default:
+		b, err := json.Marshal(o)
+		if err != nil || json.Unmarshal(b, &v) != nil {
+			return ""
+		}
+	}
+	v, _ = lookup(v, name)
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+func lookup(v any, path string) (any, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if x, ok := m[path]; ok {
+		return x, true
+	}
+	for i := strings.LastIndexByte(path, '.'); i > 0; i = strings.LastIndexByte(path[:i], '.') {
+		if x, ok := m[path[:i]]; ok {
+			if x, ok := lookup(x, path[i+1:]); ok {
+				return x, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Groups counts objects grouped by the values of fields, see [Field].
+// It is a [korrel8r.Appender], not safe for concurrent use.
+type Groups struct {
+	fields []string
+	groups map[string]*korrel8r.Group
+}
+
+var _ korrel8r.Appender = &Groups{}
+
+// NewGroups returns empty groups for fields.
+func NewGroups(fields []string) *Groups {
+	return &Groups{fields: fields, groups: map[string]*korrel8r.Group{}}
+}
+
+// Fields returns the grouping fields.
+func (g *Groups) Fields() []string { return g.fields }
+
+// Append counts objects in their groups.
+func (g *Groups) Append(objects ...korrel8r.Object) {
+	for _, o := range objects {
+		values := make([]string, len(g.fields))
+		for i, f := range g.fields {
+			values[i] = Field(o, f)
+		}
+		g.add(values, 1)
+	}
+}
+
+// AddGroups adds the counts of groups for the same fields, for example from a [korrel8r.Aggregator].
+func (g *Groups) AddGroups(groups ...korrel8r.Group) {
+	for _, x := range groups {
+		values := make([]string, len(g.fields))
+		for i, f := range g.fields {
+			values[i] = x.Fields[f]
+		}
+		g.add(values, x.Count)
+	}
+}
+
+func (g *Groups) add(values []string, count int) {
+	key := strings.Join(values, "\x00")
+	x := g.groups[key]
+	if x == nil {
+		x = &korrel8r.Group{Fields: make(map[string]string, len(g.fields))}
+		for i, f := range g.fields {
+			x.Fields[f] = values[i]
+		}
+		g.groups[key] = x
+	}
+	x.Count += count
+}
+
+// List returns the groups, largest first. Groups with equal counts are sorted by field values.
+func (g *Groups) List() []korrel8r.Group {
+	list := make([]korrel8r.Group, 0, len(g.groups))
+	for _, x := range g.groups {
+		list = append(list, *x)
+	}
+	values := func(x korrel8r.Group) []string {
+		v := make([]string, len(g.fields))
+		for i, f := range g.fields {
+			v[i] = x.Fields[f]
+		}
+		return v
+	}
+	slices.SortFunc(list, func(a, b korrel8r.Group) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), slices.Compare(values(a), values(b)))
+	})
+	return list
+}
+
+// Aggregate returns the groups of objects for fields, largest first.
+func Aggregate(objects []korrel8r.Object, fields []string) []korrel8r.Group {
+	g := NewGroups(fields)
+	g.Append(objects...)
+	return g.List()
+}
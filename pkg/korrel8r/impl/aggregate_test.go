@@ -0,0 +1,106 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestField(t *testing.T) {
+	type status struct {
+		Code string `json:"code"`
+	}
+	type span struct {
+		Name       string         `json:"name"`
+		Status     status         `json:"status"`
+		Attributes map[string]any `json:"attributes"`
+	}
+	s := span{Name: "GET", Status: status{Code: "Error"}, Attributes: map[string]any{"k8s.namespace.name": "demo", "port": 8080, "ok": true}}
+	for _, x := range []struct {
+		o     korrel8r.Object
+		field string
+		want  string
+	}{
+		{map[string]string{"level": "error", "a.b": "x"}, "level", "error"},
+		{map[string]string{"level": "error", "a.b": "x"}, "a.b", "x"},
+		{map[string]string{"level": "error"}, "missing", ""},
+		{map[string]any{"a": map[string]any{"b": "nested"}}, "a.b", "nested"},
+		{s, "name", "GET"},
+		{s, "status.code", "Error"},
+		{s, "attributes.k8s.namespace.name", "demo"},
+		{s, "attributes.port", "8080"},
+		{s, "attributes.ok", "true"},
+		{s, "status", `{"code":"Error"}`},
+		{s, "attributes.nonesuch", ""},
+		{"a string", "x", ""},
+	} {
+		t.Run(x.field, func(t *testing.T) { assert.Equal(t, x.want, Field(x.o, x.field)) })
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	objects := []korrel8r.Object{
+		map[string]string{"level": "info", "container": "a"},
+		map[string]string{"level": "error", "container": "a"},
+		map[string]string{"level": "info", "container": "b"},
+		map[string]string{"level": "info", "container": "a"},
+		map[string]string{"container": "b"},
+	}
+	assert.Equal(t, []korrel8r.Group{
+		{Fields: map[string]string{"level": "info", "container": "a"}, Count: 2},
+		{Fields: map[string]string{"level": "", "container": "b"}, Count: 1},
+		{Fields: map[string]string{"level": "error", "container": "a"}, Count: 1},
+		{Fields: map[string]string{"level": "info", "container": "b"}, Count: 1},
+	}, Aggregate(objects, []string{"level", "container"}))
+	assert.Equal(t, []korrel8r.Group{{Fields: map[string]string{}, Count: 5}}, Aggregate(objects, nil))
+	assert.Empty(t, Aggregate(nil, []string{"level"}))
+
+	g := NewGroups([]string{"level"})
+	g.Append(objects...)
+	g.AddGroups(korrel8r.Group{Fields: map[string]string{"level": "error", "ignored": "x"}, Count: 10})
+	assert.Equal(t, []korrel8r.Group{
+		{Fields: map[string]string{"level": "error"}, Count: 11},
+		{Fields: map[string]string{"level": "info"}, Count: 3},
+		{Fields: map[string]string{"level": ""}, Count: 1},
+	}, g.List())
+}
+
+// aggregatorStore is a mock store that counts all objects as one group, or fails with err.
+type aggregatorStore struct {
+	*mock.Store
+	count int
+	err   error
+}
+
+func (s aggregatorStore) Aggregate(context.Context, korrel8r.Query, *korrel8r.Constraint, []string) ([]korrel8r.Group, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []korrel8r.Group{{Fields: map[string]string{}, Count: s.count}}, nil
+}
+
+func TestTryStores_Aggregate(t *testing.T) {
+	domain, class, query := tryStoresFixture(t)
+	store := mock.NewStore(domain, class)
+	ok := aggregatorStore{Store: store, count: 3}
+	failed := aggregatorStore{Store: store, err: errors.New("failed")}
+
+	groups, err := TryStores{failed, ok}.Aggregate(context.Background(), query, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, groups[0].Count)
+
+	_, err = TryStores{failed, store, ok}.Aggregate(context.Background(), query, nil, nil)
+	assert.ErrorIs(t, err, korrel8r.ErrNotAggregated)
+	assert.ErrorContains(t, err, "failed")
+
+	_, err = TryStores{failed}.Aggregate(context.Background(), query, nil, nil)
+	assert.ErrorContains(t, err, "failed")
+	assert.NotErrorIs(t, err, korrel8r.ErrNotAggregated)
+}
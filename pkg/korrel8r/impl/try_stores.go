@@ -10,7 +10,10 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 )
 
-var _ korrel8r.Store = TryStores{}
+var (
+	_ korrel8r.Store      = TryStores{}
+	_ korrel8r.Aggregator = TryStores{}
+)
 
 // TryStores Get tries each store in turn. Uses the first store to satisfy other Store methods.
 type TryStores []korrel8r.Store
@@ -31,3 +34,22 @@ func (ts TryStores) Get(ctx context.Context, q korrel8r.Query, c *korrel8r.Const
 }
 
 var log = logging.Log()
+
+// Aggregate tries each store in turn, like Get.
+// Returns [korrel8r.ErrNotAggregated] if it reaches a store that is not a [korrel8r.Aggregator].
+func (ts TryStores) Aggregate(ctx context.Context, q korrel8r.Query, c *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	var errs error
+	for i, s := range ts {
+		a, ok := s.(korrel8r.Aggregator)
+		if !ok {
+			return nil, errors.Join(errs, korrel8r.ErrNotAggregated)
+		}
+		groups, err := a.Aggregate(ctx, q, c, fields)
+		if err == nil {
+			return groups, nil
+		}
+		log.V(5).Info("try-stores aggregate error", "store", s.Domain(), "remaining", len(ts)-i, "error", err)
+		errs = errors.Join(errs, err)
+	}
+	return nil, errs
+}
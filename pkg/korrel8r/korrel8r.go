@@ -16,6 +16,7 @@ package korrel8r
 
 import (
 	"context"
+	"time"
 )
 
 // Domain is the entry-point to a package implementing a korrel8r domain.
@@ -81,6 +82,26 @@ type Store interface {
 	Get(context.Context, Query, *Constraint, Appender) error
 }
 
+// Aggregator is optionally implemented by Store implementations that can count objects in the backend,
+// grouped by the values of some fields, without returning the objects.
+//
+// Field names are the same as for in-memory grouping, see [github.com/korrel8r/korrel8r/pkg/korrel8r/impl.Field].
+// A store that cannot count some query or fields in the backend should return [ErrNotAggregated],
+// the caller will count the objects returned by Get instead.
+type Aggregator interface {
+	// Aggregate counts the objects selected by the Query, grouped by the values of fields.
+	// The Constraint time range applies, the limit does not: all matching objects are counted.
+	Aggregate(ctx context.Context, q Query, c *Constraint, fields []string) ([]Group, error)
+}
+
+// Group is the number of objects that have the same values for a set of fields.
+type Group struct {
+	// Fields maps each field name to its value, "" if the object has no such field.
+	Fields map[string]string `json:"fields"`
+	// Count of objects in the group.
+	Count int `json:"count"`
+}
+
 // Query is a request that selects some subset of Objects from a Store.
 // Query types must be comparable.
 //
@@ -115,6 +136,51 @@ type Previewer interface {
 	Preview(Object) string
 }
 
+// Namespacer is optionally implemented by Class implementations for objects that belong to a Kubernetes namespace.
+//
+// It is used to check that the caller of a request is allowed to see an object.
+type Namespacer interface {
+	// Namespace returns the namespace of the object, or "" if it does not belong to a namespace.
+	Namespace(Object) string
+}
+
+// Timer is optionally implemented by Class implementations for objects that have a time.
+//
+// It is used to build timelines, and to rank objects by how close they are in time to the start of a search.
+type Timer interface {
+	// Time returns the time of the object, or the zero time if it has none.
+	Time(Object) time.Time
+}
+
+// Scorer is optionally implemented by Class implementations to rank objects by relevance.
+//
+// The score is a property of the object alone, for example an error log is more interesting than a debug log.
+type Scorer interface {
+	// Score returns the relevance of the object, from 0 (not interesting) to 1 (very interesting).
+	Score(Object) float64
+}
+
+// Compacter is optionally implemented by Class implementations that have a compact form for large objects.
+//
+// The compact form keeps the fields that identify an object and describe its state,
+// and drops bulky fields such as Kubernetes managedFields.
+// It is used by the "compact" projection profile, see [github.com/korrel8r/korrel8r/pkg/projection].
+type Compacter interface {
+	// CompactFields returns the projection fields for the compact form.
+	CompactFields() []string
+}
+
+// Summarizer is optionally implemented by Class implementations to summarize objects.
+//
+// A summary is a small structured form of an object, for an assistant or a human to assess many objects at once.
+// It has the fields that identify the object, health indicators such as status, errors or restart counts,
+// and relevant times. Unlike a compact form, summary values may be derived from several fields of the object.
+// It is used by the "summary" projection profile, see [github.com/korrel8r/korrel8r/pkg/projection].
+type Summarizer interface {
+	// Summary returns the summary of an object as a JSON object, or nil if the object has no summary.
+	Summary(Object) map[string]any
+}
+
 // Appender gathers results from Store.Get calls.
 //
 // Not required for a domain implementations: implemented by [Result]
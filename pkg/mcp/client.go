@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
@@ -77,9 +79,12 @@ func (c *Client) Help(ctx context.Context, domain string) (string, error) {
 	return h.Documentation, nil
 }
 
+// graphOptions for graph requests: log nodes include pattern summaries, which are much smaller than full results.
+const graphOptions = "?patterns=true"
+
 func (c *Client) GraphNeighbors(ctx context.Context, params api.Neighbors) (*api.Graph, error) {
 	var g api.Graph
-	if err := c.post(ctx, "/graphs/neighbors", params, &g); err != nil {
+	if err := c.post(ctx, "/graphs/neighbors"+graphOptions, params, &g); err != nil {
 		return nil, err
 	}
 	return &g, nil
@@ -87,13 +92,36 @@ func (c *Client) GraphNeighbors(ctx context.Context, params api.Neighbors) (*api
 
 func (c *Client) GraphGoals(ctx context.Context, params api.Goals) (*api.Graph, error) {
 	var g api.Graph
-	if err := c.post(ctx, "/graphs/goals", params, &g); err != nil {
+	if err := c.post(ctx, "/graphs/goals"+graphOptions, params, &g); err != nil {
 		return nil, err
 	}
 	return &g, nil
 }
 
-func (c *Client) GetObjects(ctx context.Context, query string, constraint *api.Constraint) ([]json.RawMessage, error) {
+// GetObjects returns the objects for query, with the projection applied if it is not empty.
+func (c *Client) GetObjects(ctx context.Context, query string, constraint *api.Constraint, project string) ([]json.RawMessage, error) {
+	var objects []json.RawMessage
+	u := objectsURL(query, constraint)
+	if project != "" {
+		u += "&project=" + url.QueryEscape(project)
+	}
+	if err := c.get(ctx, u, &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// GetGroups returns counts of the objects for query, grouped by the values of fields.
+func (c *Client) GetGroups(ctx context.Context, query string, constraint *api.Constraint, fields []string) ([]api.GroupCount, error) {
+	var groups []api.GroupCount
+	u := objectsURL(query, constraint) + "&groupBy=" + url.QueryEscape(strings.Join(fields, ","))
+	if err := c.get(ctx, u, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func objectsURL(query string, constraint *api.Constraint) string {
 	u := "/objects?query=" + url.QueryEscape(query)
 	if constraint != nil {
 		if constraint.Limit != nil {
@@ -108,12 +136,35 @@ func (c *Client) GetObjects(ctx context.Context, query string, constraint *api.C
 		if constraint.End != nil {
 			u += "&end=" + url.QueryEscape(constraint.End.Format("2006-01-02T15:04:05Z07:00"))
 		}
+		if constraint.Cluster != "" {
+			u += "&cluster=" + url.QueryEscape(constraint.Cluster)
+		}
 	}
-	var objects []json.RawMessage
-	if err := c.get(ctx, u, &objects); err != nil {
+	return u
+}
+
+func (c *Client) Resolve(ctx context.Context, params api.Resolve) ([]api.Candidate, error) {
+	var candidates []api.Candidate
+	if err := c.post(ctx, "/resolve", params, &candidates); err != nil {
 		return nil, err
 	}
-	return objects, nil
+	return candidates, nil
+}
+
+func (c *Client) Timeline(ctx context.Context, params api.TimelineSearch) (*api.Timeline, error) {
+	var tl api.Timeline
+	if err := c.post(ctx, "/timeline", params, &tl); err != nil {
+		return nil, err
+	}
+	return &tl, nil
+}
+
+func (c *Client) RootCauses(ctx context.Context, params api.RootCauseSearch) (*api.RootCauses, error) {
+	var rc api.RootCauses
+	if err := c.post(ctx, "/rootcauses", params, &rc); err != nil {
+		return nil, err
+	}
+	return &rc, nil
 }
 
 func (c *Client) GetConsole(ctx context.Context) (*api.Console, error) {
@@ -128,6 +179,42 @@ func (c *Client) ShowInConsole(ctx context.Context, update *api.Console) error {
 	return c.put(ctx, "/console/events", update, nil)
 }
 
+func (c *Client) GetOverlay(ctx context.Context) (*api.Overlay, error) {
+	var overlay api.Overlay
+	if err := c.get(ctx, "/config/overlay", &overlay); err != nil {
+		return nil, err
+	}
+	return &overlay, nil
+}
+
+func (c *Client) SetOverlay(ctx context.Context, overlay *api.Overlay) error {
+	return c.put(ctx, "/config/overlay", overlay, nil)
+}
+
+func (c *Client) DeleteOverlay(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+api.BasePath+"/config/overlay", nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+func (c *Client) ListRecipes(ctx context.Context) ([]api.Recipe, error) {
+	var recipes []api.Recipe
+	if err := c.get(ctx, "/recipes", &recipes); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+func (c *Client) RunRecipe(ctx context.Context, name string, run api.RecipeRun) (*api.Graph, error) {
+	var g api.Graph
+	if err := c.post(ctx, "/recipes/"+url.PathEscape(name)+graphOptions, run, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
 func (c *Client) get(ctx context.Context, path string, result any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+api.BasePath+path, nil)
 	if err != nil {
@@ -157,12 +244,43 @@ func (c *Client) send(ctx context.Context, method, path string, body, result any
 	return c.do(req, result)
 }
 
+// redactedKey is the context key for counts of values redacted from responses, see [api.HeaderRedacted].
+type redactedKey struct{}
+
+func withRedacted(ctx context.Context, counts map[string]int) context.Context {
+	return context.WithValue(ctx, redactedKey{}, counts)
+}
+
+// contextRedacted returns the redaction counts collected for ctx, nil if there are none.
+func contextRedacted(ctx context.Context) map[string]int {
+	counts, _ := ctx.Value(redactedKey{}).(map[string]int)
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// addRedacted adds the counts from a [api.HeaderRedacted] value to counts.
+func addRedacted(counts map[string]int, header string) {
+	for e := range strings.SplitSeq(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(e), "=")
+		if n, err := strconv.Atoi(value); err == nil && name != "" {
+			counts[name] += n
+		}
+	}
+}
+
 func (c *Client) do(req *http.Request, result any) error {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	if counts, _ := req.Context().Value(redactedKey{}).(map[string]int); counts != nil {
+		if h := resp.Header.Get(api.HeaderRedacted); h != "" {
+			addRedacted(counts, h)
+		}
+	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
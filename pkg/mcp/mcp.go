@@ -42,16 +42,42 @@ type ListDomainClassesResult struct {
 type NeighborParams = api.Neighbors
 type GoalParams = api.Goals
 type ShowInConsoleParams = api.Console
+type SetOverlayParams = api.Overlay
 
 type ObjectsParams struct {
 	Query      string          `json:"query" jsonschema:"Query string in the form 'domain:class:selector'. Use 'help' to learn query syntax for each domain."`
 	Constraint *api.Constraint `json:"constraint,omitempty" jsonschema:"Optional constraint to limit results by time range and/or count."`
+	GroupBy    []string        `json:"groupBy,omitempty" jsonschema:"Optional object fields. If present return counts of objects grouped by the values of these fields instead of the objects."`
+	Project    string          `json:"project,omitempty" jsonschema:"Optional projection to return only some fields of each object: 'summary' for a short summary of each object with identity, health indicators and times, 'compact' for a compact form of each class, or a comma-separated list of field paths like 'metadata.name,status.phase'."`
+}
+
+type ResolveParams = api.Resolve
+type TimelineParams = api.TimelineSearch
+type RootCausesParams = api.RootCauseSearch
+
+type ResolveResult struct {
+	Candidates []api.Candidate `json:"candidates" jsonschema:"Candidate start queries, sorted by decreasing confidence"`
+}
+
+type ListRecipesResult struct {
+	Recipes []api.Recipe `json:"recipes" jsonschema:"List of recipes"`
+}
+
+type RunRecipeParams struct {
+	Name       string            `json:"name" jsonschema:"Name of the recipe to run"`
+	Params     map[string]string `json:"params,omitempty" jsonschema:"Recipe parameter values by name. Parameters without a default are required."`
+	Constraint *api.Constraint   `json:"constraint,omitempty" jsonschema:"Optional constraint to use instead of the recipe constraint."`
 }
 
 type ObjectsResult struct {
-	Objects []any `json:"objects" jsonschema:"List of objects matching the query"`
+	Objects  []any            `json:"objects,omitzero" jsonschema:"List of objects matching the query"`
+	Groups   []api.GroupCount `json:"groups,omitzero" jsonschema:"Counts of objects grouped by field values, largest first, if groupBy is present"`
+	Redacted map[string]int   `json:"redacted,omitempty" jsonschema:"Number of values replaced by [REDACTED] for each redaction rule, if any"`
 }
 
+// MetaRedacted is the key in the _meta field of a tool result for counts of redacted values, if any.
+const MetaRedacted = "korrel8r/redacted"
+
 const Instructions = `Korrel8r finds correlations between observability signals and resources in a Kubernetes cluster.
 It connects data from different domains (logs, metrics, alerts, traces, Kubernetes resources, etc.)
 by following correlation rules to build a graph of related objects.
@@ -60,8 +86,18 @@ If the user refers to a console, use get_console and show_in_console to read and
 If the user asks "what am I looking at?", "what is this?" or says "show me ..." or "display ..." they may be referring to the console.
 
 To search: use list_domains to discover domains, then 'help' to learn query syntax.
+If the user pastes a log line, alert notification or URL, use resolve to find start queries.
 Use create_goals_graph for targeted queries ("find logs for this pod")
 and create_neighbors_graph for open-ended exploration ("what is related to this pod?").
+Use create_timeline to see what happened, in time order, across logs, alerts, events, traces and incidents.
+Log nodes in graphs include log patterns: templates of similar lines with a count, first and last time and an example.
+Use the patterns to summarize large log results instead of retrieving every line with get_objects.
+Use get_objects with project "summary" to assess many objects at once, for example "what's wrong with these pods?".
+Use project "compact" unless you need fields that the compact form omits.
+For "how many" questions use get_objects with groupBy to get counts grouped by fields, for example log level or span status, instead of the objects.
+Use find_root_causes when the user asks "why is this failing?" or "what caused this?".
+Use list_recipes to find pre-defined searches, and run_recipe to run one with parameters.
+Secrets and personal data may be replaced by [REDACTED] in results. Redacted values are not available, do not try to recover them.
 `
 
 const (
@@ -71,9 +107,19 @@ const (
 	CreateGoalsGraph     = "create_goals_graph"
 	CreateNeighborsGraph = "create_neighbors_graph"
 	GetObjects           = "get_objects"
+	Resolve              = "resolve"
+	CreateTimeline       = "create_timeline"
+	FindRootCauses       = "find_root_causes"
 	// Console tools, only work in sessions with a connected console.
 	GetConsole    = "get_console"
 	ShowInConsole = "show_in_console"
+	// Configuration overlay tools, change the configuration of this session only.
+	GetConfigOverlay    = "get_config_overlay"
+	SetConfigOverlay    = "set_config_overlay"
+	DeleteConfigOverlay = "delete_config_overlay"
+	// Recipe tools, run pre-defined searches.
+	ListRecipes = "list_recipes"
+	RunRecipe   = "run_recipe"
 )
 
 type Server struct {
@@ -109,7 +155,17 @@ func addTool[In, Out any](tools *[]*mcp.Tool, server *mcp.Server, t *mcp.Tool, h
 	}
 	*tools = append(*tools, t)
 	if server != nil {
-		mcp.AddTool(server, t, h)
+		mcp.AddTool(server, t, func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+			ctx = withRedacted(api.WithMCPTool(ctx, t.Name), map[string]int{})
+			res, out, err := h(ctx, req, in)
+			if redacted := contextRedacted(ctx); redacted != nil && err == nil {
+				if res == nil {
+					res = &mcp.CallToolResult{}
+				}
+				res.Meta = mcp.Meta{MetaRedacted: redacted}
+			}
+			return res, out, err
+		})
 	}
 }
 
@@ -156,7 +212,7 @@ func AddTools(server *mcp.Server, client *Client) []*mcp.Tool {
 
 	addTool(&tools, server, &mcp.Tool{
 		Name:        CreateNeighborsGraph,
-		Description: `Follow correlation rules outward from start objects up to a given depth. Returns a graph of correlated classes with queries and result counts. Use for open-ended exploration like "what is related to this pod?" Depth 1 = direct correlations; depth 2-3 typically reaches logs, metrics, and alerts. Start queries use "domain:class:selector" format; see 'help' for syntax.`,
+		Description: `Follow correlation rules outward from start objects up to a given depth. Returns a graph of correlated classes with queries and result counts, log nodes include a summary of log patterns. Use for open-ended exploration like "what is related to this pod?" Depth 1 = direct correlations; depth 2-3 typically reaches logs, metrics, and alerts. Start queries use "domain:class:selector" format; see 'help' for syntax.`,
 	},
 		func(ctx context.Context, req *mcp.CallToolRequest, input NeighborParams) (*mcp.CallToolResult, *api.Graph, error) {
 			g, err := client.GraphNeighbors(ctx, input)
@@ -168,7 +224,7 @@ func AddTools(server *mcp.Server, client *Client) []*mcp.Tool {
 
 	addTool(&tools, server, &mcp.Tool{
 		Name:        CreateGoalsGraph,
-		Description: `Follow correlation paths from start objects to specific goal classes. Returns a graph of correlated classes with queries and result counts. Use for targeted queries like "find logs for this pod" or "what alerts fired for this deployment?" Start queries use "domain:class:selector" format; goals are class names like ["log:application"]. See 'help' for syntax.`,
+		Description: `Follow correlation paths from start objects to specific goal classes. Returns a graph of correlated classes with queries and result counts, log nodes include a summary of log patterns. Use for targeted queries like "find logs for this pod" or "what alerts fired for this deployment?" Start queries use "domain:class:selector" format; goals are class names like ["log:application"]. See 'help' for syntax.`,
 	},
 		func(ctx context.Context, req *mcp.CallToolRequest, input GoalParams) (*mcp.CallToolResult, *api.Graph, error) {
 			g, err := client.GraphGoals(ctx, input)
@@ -178,12 +234,43 @@ func AddTools(server *mcp.Server, client *Client) []*mcp.Tool {
 			return nil, g, nil
 		})
 
+	addTool(&tools, server, &mcp.Tool{
+		Name:        CreateTimeline,
+		Description: `Run a correlation search and return the objects found as one list sorted by time: "what happened, in order". Each entry has the class, time, a short preview, statuses such as Error or Warning, and the rules followed to find it. Only objects with a time are included: logs, alerts, Kubernetes events, trace spans, incidents, changes and metric anomalies. Give goals for a targeted search like 'create_goals_graph', otherwise a neighbors search to depth (default 2) is done like 'create_neighbors_graph'.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input TimelineParams) (*mcp.CallToolResult, *api.Timeline, error) {
+			tl, err := client.Timeline(ctx, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, tl, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        FindRootCauses,
+		Description: `Find candidate root causes of a problem with the start objects. Runs a neighbors search to depth (default 3) and ranks the objects found, most likely cause first. The score combines statuses (Error, CrashLoopBackOff, OOMKilled, critical alerts), how shortly before the start objects an object happened, Kubernetes ownership (objects owned by or owning a start object), and the number of rules followed. Each candidate has its class, score, preview, statuses, the rules followed to find it and the reasons for its score. Use 'get_objects' with a query from 'create_neighbors_graph' to see full candidate objects.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input RootCausesParams) (*mcp.CallToolResult, *api.RootCauses, error) {
+			rc, err := client.RootCauses(ctx, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, rc, nil
+		})
+
 	addTool(&tools, server, &mcp.Tool{
 		Name:        GetObjects,
-		Description: `Execute a query and return matching objects as self-contained JSON (all labels/fields included per object). Query format is "domain:class:selector"; see 'help' for syntax. Use the constraint parameter (limit number of objects, start/end time as RFC 3339) to control result size, especially for high-volume domains like logs, metrics, and traces.`,
+		Description: `Execute a query and return matching objects as self-contained JSON (all labels/fields included per object). Query format is "domain:class:selector"; see 'help' for syntax. Use the constraint parameter (limit number of objects, start/end time as RFC 3339) to control result size, especially for high-volume domains like logs, metrics, and traces. Use project "summary" to get a short summary of each object: identity, health indicators such as status, conditions, restarts or errors, and relevant times. Use project "compact" to drop bulky fields such as Kubernetes managedFields and full specs, or a list of fields to return only those fields. Use groupBy with a list of fields (for example "level", "k8s_container_name", "DstK8S_OwnerName", "status.statusCode") to get counts of objects grouped by field values instead of the objects; logs, network flows and traces are counted in the backend where possible.`,
 	},
 		func(ctx context.Context, req *mcp.CallToolRequest, input ObjectsParams) (*mcp.CallToolResult, *ObjectsResult, error) {
-			raw, err := client.GetObjects(ctx, input.Query, input.Constraint)
+			if len(input.GroupBy) > 0 {
+				groups, err := client.GetGroups(ctx, input.Query, input.Constraint, input.GroupBy)
+				if err != nil {
+					return nil, nil, err
+				}
+				return nil, &ObjectsResult{Groups: groups, Redacted: contextRedacted(ctx)}, nil
+			}
+			raw, err := client.GetObjects(ctx, input.Query, input.Constraint, input.Project)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -195,7 +282,19 @@ func AddTools(server *mcp.Server, client *Client) []*mcp.Tool {
 				}
 				objects[i] = v
 			}
-			return nil, &ObjectsResult{Objects: objects}, nil
+			return nil, &ObjectsResult{Objects: objects, Redacted: contextRedacted(ctx)}, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        Resolve,
+		Description: `Propose start queries from free text such as a pasted log line, alert notification, error message, or console or Grafana URL. Recognizes namespaces, pod and workload names, alert names and trace IDs. Returns candidate queries that exist in the stores, with a confidence between 0 and 1. Use the best candidates as start queries for 'create_goals_graph' or 'create_neighbors_graph'. Only the first 64KiB of text are used, and at most 20 candidates are returned.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input ResolveParams) (*mcp.CallToolResult, *ResolveResult, error) {
+			candidates, err := client.Resolve(ctx, input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, &ResolveResult{Candidates: candidates}, nil
 		})
 
 	addTool(&tools, server, &mcp.Tool{
@@ -221,6 +320,64 @@ func AddTools(server *mcp.Server, client *Client) []*mcp.Tool {
 			return nil, nil, nil
 		})
 
+	addTool(&tools, server, &mcp.Tool{
+		Name:        GetConfigOverlay,
+		Description: `Get the configuration overlay for this session: rules, aliases, templates and stores added by 'set_config_overlay'. Returns an error if there is no overlay.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, *api.Overlay, error) {
+			overlay, err := client.GetOverlay(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, overlay, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        SetConfigOverlay,
+		Description: `Add rules, aliases, templates and stores to the configuration of this session only, replacing any previous overlay. Items use the same format as a korrel8r configuration file. Use to try a new correlation rule or connect to an additional store without changing the shared configuration. The overlay is dropped when the session expires.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, overlay SetOverlayParams) (*mcp.CallToolResult, any, error) {
+			if err := client.SetOverlay(ctx, &overlay); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        DeleteConfigOverlay,
+		Description: `Remove the configuration overlay for this session, restoring the shared configuration.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, any, error) {
+			if err := client.DeleteOverlay(ctx); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        ListRecipes,
+		Description: `List recipes: named, pre-defined correlation searches with parameters. Prefer a recipe that matches the user's question over building a search from scratch.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, *ListRecipesResult, error) {
+			recipes, err := client.ListRecipes(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, &ListRecipesResult{Recipes: recipes}, nil
+		})
+
+	addTool(&tools, server, &mcp.Tool{
+		Name:        RunRecipe,
+		Description: `Run a recipe from 'list_recipes' with parameter values. Returns a graph of correlated classes with queries and result counts, like 'create_goals_graph' or 'create_neighbors_graph'.`,
+	},
+		func(ctx context.Context, req *mcp.CallToolRequest, input RunRecipeParams) (*mcp.CallToolResult, *api.Graph, error) {
+			g, err := client.RunRecipe(ctx, input.Name, api.RecipeRun{Params: input.Params, Constraint: input.Constraint})
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, g, nil
+		})
+
 	return tools
 }
 
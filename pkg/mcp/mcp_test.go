@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,13 +35,31 @@ func mockAPI() http.Handler {
 		writeJSON(w, api.Help{Documentation: "k8s domain help"})
 	})
 	mux.HandleFunc("GET "+prefix+"/objects", func(w http.ResponseWriter, r *http.Request) {
+		if groupBy := r.URL.Query().Get("groupBy"); groupBy != "" {
+			writeJSON(w, []api.GroupCount{{Fields: map[string]string{groupBy: "Running"}, Count: 3}})
+			return
+		}
+		if r.URL.Query().Get("project") == "compact" {
+			writeJSON(w, []json.RawMessage{json.RawMessage(`{"name":"compact"}`)})
+			return
+		}
 		writeJSON(w, []json.RawMessage{json.RawMessage(`{"name":"pod1"}`)})
 	})
 	mux.HandleFunc("POST "+prefix+"/graphs/neighbors", func(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, api.Graph{Nodes: []api.Node{{Class: "k8s:Pod.v1", Count: intPtr(1)}}})
 	})
 	mux.HandleFunc("POST "+prefix+"/graphs/goals", func(w http.ResponseWriter, r *http.Request) {
-		writeJSON(w, api.Graph{Nodes: []api.Node{{Class: "log:application", Count: intPtr(5)}}})
+		n := api.Node{Class: "log:application", Count: intPtr(5)}
+		if r.URL.Query().Get("patterns") == "true" {
+			n.Patterns = []api.LogPattern{{Template: "took <*>", Count: 5, Example: "took 1ms"}}
+		}
+		writeJSON(w, api.Graph{Nodes: []api.Node{n}})
+	})
+	mux.HandleFunc("POST "+prefix+"/timeline", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, api.Timeline{Entries: []api.TimelineEntry{{Class: "log:application", Time: time.Unix(1, 0).UTC(), Preview: "hello"}}})
+	})
+	mux.HandleFunc("POST "+prefix+"/rootcauses", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, api.RootCauses{Candidates: []api.RootCause{{Class: "k8s:Pod.v1", Score: 0.5, Reasons: []string{"status: CrashLoopBackOff"}}}})
 	})
 	mux.HandleFunc("GET "+prefix+"/console", func(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, api.Console{View: "k8s:Pod.v1:{}"})
@@ -48,6 +67,24 @@ func mockAPI() http.Handler {
 	mux.HandleFunc("PUT "+prefix+"/console/events", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 	})
+	mux.HandleFunc("GET "+prefix+"/config/overlay", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, api.Overlay{Stores: []api.Store{{"domain": "log"}}})
+	})
+	mux.HandleFunc("PUT "+prefix+"/config/overlay", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, struct{}{})
+	})
+	mux.HandleFunc("DELETE "+prefix+"/config/overlay", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, struct{}{})
+	})
+	mux.HandleFunc("POST "+prefix+"/resolve", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []api.Candidate{{Query: "k8s:Pod.v1:{}", Confidence: 0.9}})
+	})
+	mux.HandleFunc("GET "+prefix+"/recipes", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []api.Recipe{{Name: "pod-logs", Goals: []api.Class{"log:application"}}})
+	})
+	mux.HandleFunc("POST "+prefix+"/recipes/pod-logs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, api.Graph{Nodes: []api.Node{{Class: "log:application", Count: intPtr(3)}}})
+	})
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not found: "+r.URL.Path, http.StatusNotFound)
 	})
@@ -97,7 +134,7 @@ func TestClient_Help(t *testing.T) {
 
 func TestClient_GetObjects(t *testing.T) {
 	c, _ := testClient(t)
-	objs, err := c.GetObjects(context.Background(), "k8s:Pod:{}", nil)
+	objs, err := c.GetObjects(context.Background(), "k8s:Pod:{}", nil, "")
 	require.NoError(t, err)
 	require.Len(t, objs, 1)
 	assert.Contains(t, string(objs[0]), "pod1")
@@ -114,11 +151,26 @@ func TestClient_GetObjects_WithConstraint(t *testing.T) {
 		QueryLimit: &queryLimit,
 		Start:      &start,
 		End:        &end,
-	})
+	}, "")
 	require.NoError(t, err)
 	assert.NotEmpty(t, objs)
 }
 
+func TestClient_GetObjects_project(t *testing.T) {
+	c, _ := testClient(t)
+	objs, err := c.GetObjects(context.Background(), "k8s:Pod:{}", nil, "compact")
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.JSONEq(t, `{"name":"compact"}`, string(objs[0]))
+}
+
+func TestClient_GetGroups(t *testing.T) {
+	c, _ := testClient(t)
+	groups, err := c.GetGroups(context.Background(), "k8s:Pod:{}", nil, []string{"status.phase"})
+	require.NoError(t, err)
+	assert.Equal(t, []api.GroupCount{{Fields: map[string]string{"status.phase": "Running"}, Count: 3}}, groups)
+}
+
 func TestClient_GraphNeighbors(t *testing.T) {
 	c, _ := testClient(t)
 	g, err := c.GraphNeighbors(context.Background(), api.Neighbors{
@@ -139,6 +191,21 @@ func TestClient_GraphGoals(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, g.Nodes, 1)
 	assert.Equal(t, "log:application", g.Nodes[0].Class)
+	assert.Equal(t, []api.LogPattern{{Template: "took <*>", Count: 5, Example: "took 1ms"}}, g.Nodes[0].Patterns)
+}
+
+func TestClient_Timeline(t *testing.T) {
+	c, _ := testClient(t)
+	tl, err := c.Timeline(context.Background(), api.TimelineSearch{Start: api.Start{Queries: []string{"k8s:Pod:{}"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []api.TimelineEntry{{Class: "log:application", Time: time.Unix(1, 0).UTC(), Preview: "hello"}}, tl.Entries)
+}
+
+func TestClient_RootCauses(t *testing.T) {
+	c, _ := testClient(t)
+	rc, err := c.RootCauses(context.Background(), api.RootCauseSearch{Start: api.Start{Queries: []string{"alert:alert:{}"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []api.RootCause{{Class: "k8s:Pod.v1", Score: 0.5, Reasons: []string{"status: CrashLoopBackOff"}}}, rc.Candidates)
 }
 
 func TestClient_Console(t *testing.T) {
@@ -151,6 +218,34 @@ func TestClient_Console(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_Overlay(t *testing.T) {
+	c, _ := testClient(t)
+	overlay, err := c.GetOverlay(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []api.Store{{"domain": "log"}}, overlay.Stores)
+	assert.NoError(t, c.SetOverlay(context.Background(), overlay))
+	assert.NoError(t, c.DeleteOverlay(context.Background()))
+}
+
+func TestClient_Resolve(t *testing.T) {
+	c, _ := testClient(t)
+	candidates, err := c.Resolve(context.Background(), api.Resolve{Text: "pod foo"})
+	require.NoError(t, err)
+	assert.Equal(t, []api.Candidate{{Query: "k8s:Pod.v1:{}", Confidence: 0.9}}, candidates)
+}
+
+func TestClient_Recipes(t *testing.T) {
+	c, _ := testClient(t)
+	recipes, err := c.ListRecipes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []api.Recipe{{Name: "pod-logs", Goals: []api.Class{"log:application"}}}, recipes)
+	g, err := c.RunRecipe(context.Background(), "pod-logs", api.RecipeRun{Params: map[string]string{"name": "x"}})
+	require.NoError(t, err)
+	assert.Equal(t, "log:application", g.Nodes[0].Class)
+	_, err = c.RunRecipe(context.Background(), "nonexistent", api.RecipeRun{})
+	assert.Error(t, err)
+}
+
 func TestClient_HTTPError(t *testing.T) {
 	c, _ := testClient(t)
 	_, err := c.ListDomainClasses(context.Background(), "nonexistent")
@@ -180,6 +275,8 @@ func TestAddTools(t *testing.T) {
 		ListDomains, ListDomainClasses, Help,
 		CreateNeighborsGraph, CreateGoalsGraph, GetObjects,
 		GetConsole, ShowInConsole,
+		GetConfigOverlay, SetConfigOverlay, DeleteConfigOverlay,
+		ListRecipes, RunRecipe, Resolve, CreateTimeline, FindRootCauses,
 	}, names)
 }
 
@@ -187,7 +284,7 @@ func TestNewServer(t *testing.T) {
 	c, _ := testClient(t)
 	s := NewServer(c, "test-version", logr.Discard())
 	assert.NotNil(t, s.Server)
-	assert.Len(t, s.AllTools(), 8)
+	assert.Len(t, s.AllTools(), 16)
 }
 
 func TestJsonValue_MarshalLog(t *testing.T) {
@@ -204,3 +301,55 @@ func TestJsonValue_MarshalLog_Unmarshable(t *testing.T) {
 	_, ok := result.(string)
 	assert.True(t, ok)
 }
+
+func TestServer_ToolContext(t *testing.T) {
+	tools := make(chan string, 1)
+	mock := mockAPI()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tools <- api.ContextMCPTool(r.Context())
+		mock.ServeHTTP(w, r)
+	})
+	s := NewServer(NewClientForHandler(handler), "test-version", logr.Discard())
+	ctx := context.Background()
+	st, ct := mcp.NewInMemoryTransports()
+	_, err := s.Connect(ctx, st, nil)
+	require.NoError(t, err)
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer func() { _ = cs.Close() }()
+	_, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: ListDomains, Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.Equal(t, ListDomains, <-tools)
+}
+
+func TestServer_Redacted(t *testing.T) {
+	mock := mockAPI()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "log:application:{}" {
+			w.Header().Set(api.HeaderRedacted, "email=2,secret-data=1")
+		}
+		mock.ServeHTTP(w, r)
+	})
+	s := NewServer(NewClientForHandler(handler), "test-version", logr.Discard())
+	ctx := context.Background()
+	st, ct := mcp.NewInMemoryTransports()
+	_, err := s.Connect(ctx, st, nil)
+	require.NoError(t, err)
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer func() { _ = cs.Close() }()
+
+	res, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: GetObjects, Arguments: map[string]any{"query": "log:application:{}"}})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	assert.Equal(t, map[string]any{"email": float64(2), "secret-data": float64(1)}, res.Meta[MetaRedacted])
+	var out ObjectsResult
+	b, err := json.Marshal(res.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, map[string]int{"email": 2, "secret-data": 1}, out.Redacted)
+
+	res, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: GetObjects, Arguments: map[string]any{"query": "k8s:Pod:{}"}})
+	require.NoError(t, err)
+	assert.NotContains(t, res.Meta, MetaRedacted)
+}
@@ -0,0 +1,332 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package oidc authenticates bearer tokens that are JSON Web Tokens (JWT) signed by an OpenID Connect provider.
+//
+// It does not need access to a Kubernetes API server, unlike a TokenReview.
+// Signatures are verified with the provider's JSON Web Key Set (JWKS), read from a local file or URL.
+package oidc
+
+import (
+	"cmp"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/korrel8r/korrel8r/internal/pkg/cache"
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+)
+
+var log = logging.Log()
+
+const (
+	cacheExpiry = 5 * time.Minute
+	// refreshInterval is the minimum time between reloads of the key set, when a token has an unknown key ID.
+	refreshInterval = 10 * time.Second
+	// leeway allows for clock skew when checking token times.
+	leeway = 30 * time.Second
+)
+
+// Signing methods that use public keys. Symmetric (HMAC) and "none" methods are not accepted.
+var validMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Authenticator verifies JWT bearer tokens and maps a claim to the user name.
+// Verified tokens are cached until they expire, or for 5 minutes.
+type Authenticator struct {
+	userClaim, groupsClaim string
+	usernameClaim, prefix  string
+	parser                 *jwt.Parser
+	source                 string                                    // File or URL of the key set, for messages.
+	load                   func() ([]byte, error)                    // Load the key set.
+	tokens                 *cache.TTL[[sha256.Size]byte, cacheEntry] // Keyed by token hash, tokens are not kept in memory.
+
+	m      sync.Mutex
+	keys   map[string]any // Public keys by key ID.
+	loaded time.Time
+}
+
+type cacheEntry struct {
+	user    *auth.User
+	expires time.Time
+}
+
+// New returns an authenticator for cfg. The key set is loaded immediately to report configuration errors.
+func New(cfg config.OIDC) (*Authenticator, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("oidc: issuer and audience are required")
+	}
+	if cfg.UsernameClaim != "" && cfg.UsernamePrefix == "" {
+		return nil, errors.New("oidc: usernamePrefix is required with usernameClaim")
+	}
+	a := &Authenticator{
+		userClaim:     cmp.Or(cfg.UserClaim, "sub"),
+		groupsClaim:   cmp.Or(cfg.GroupsClaim, "groups"),
+		usernameClaim: cfg.UsernameClaim,
+		prefix:        cfg.UsernamePrefix,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(validMethods),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(leeway)),
+		tokens: cache.NewTTL[[sha256.Size]byte, cacheEntry](cacheExpiry),
+	}
+	switch {
+	case cfg.JWKSFile != "":
+		a.source = cfg.JWKSFile
+		a.load = func() ([]byte, error) { return os.ReadFile(cfg.JWKSFile) }
+	case cfg.JWKSURL != "":
+		a.source = cfg.JWKSURL
+		a.load = func() ([]byte, error) { return get(cfg.JWKSURL) }
+	default:
+		u, err := discover(cfg.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		a.source = u
+		a.load = func() ([]byte, error) { return get(u) }
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authenticate verifies token and returns the user named by the user claim.
+// The user has a Kubernetes username only if a username claim is configured,
+// the username and groups are prefixed with the configured prefix.
+func (a *Authenticator) Authenticate(token string) (*auth.User, error) {
+	key := sha256.Sum256([]byte(token))
+	if e, ok := a.tokens.Get(key); ok && time.Now().Before(e.expires) {
+		return e.user, nil
+	}
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.key); err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	name, _ := claims[a.userClaim].(string)
+	if name == "" {
+		return nil, fmt.Errorf("oidc: token has no %q claim", a.userClaim)
+	}
+	u := &auth.User{Name: name}
+	if a.usernameClaim != "" {
+		username, _ := claims[a.usernameClaim].(string)
+		if username == "" {
+			return nil, fmt.Errorf("oidc: token has no %q claim", a.usernameClaim)
+		}
+		u.Username = a.prefix + username
+		for _, g := range groups(claims[a.groupsClaim]) {
+			u.Groups = append(u.Groups, a.prefix+g)
+		}
+	}
+	expires := time.Now().Add(cacheExpiry)
+	if exp, _ := claims.GetExpirationTime(); exp != nil && exp.Before(expires) {
+		expires = exp.Time
+	}
+	a.tokens.Put(key, cacheEntry{user: u, expires: expires})
+	return u, nil
+}
+
+// groups converts a claim that is a string or a list of strings.
+func groups(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var groups []string
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+// key is a [jwt.Keyfunc] that finds the verification key for a token by key ID.
+// If the key ID is unknown the key set is reloaded, at most once per refreshInterval, in case keys were rotated.
+func (a *Authenticator) key(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	a.m.Lock()
+	defer a.m.Unlock()
+	if kid == "" { // Try all keys.
+		set := jwt.VerificationKeySet{}
+		for _, k := range a.keys {
+			set.Keys = append(set.Keys, k)
+		}
+		return set, nil
+	}
+	if k, ok := a.keys[kid]; ok {
+		return k, nil
+	}
+	if time.Since(a.loaded) > refreshInterval {
+		if err := a.reload(); err != nil {
+			return nil, err
+		}
+		if k, ok := a.keys[kid]; ok {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key ID %q", kid)
+}
+
+// reload the key set, must be called with a.m locked.
+func (a *Authenticator) reload() error {
+	a.loaded = time.Now()
+	b, err := a.load()
+	if err != nil {
+		return fmt.Errorf("oidc: loading keys: %w", err)
+	}
+	keys, err := parseJWKS(b)
+	if err != nil {
+		return fmt.Errorf("oidc: %v: %w", a.source, err)
+	}
+	log.V(1).Info("Loaded OIDC keys", "source", a.source, "count", len(keys))
+	a.keys = keys
+	return nil
+}
+
+// jwk is a JSON Web Key, see RFC 7517. Only public key fields are used.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the signature keys in a JWKS document by key ID.
+// Keys that are for encryption, or of unsupported types, are skipped.
+func parseJWKS(b []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %w", err)
+	}
+	keys := map[string]any{}
+	for _, k := range set.Keys {
+		if k.Use == "enc" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signature keys found")
+	}
+	return keys, nil
+}
+
+// publicKey returns the key, or nil if the key type or curve is not supported.
+func (k *jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err1 := decode(k.N)
+		e, err2 := decode(k.E)
+		if err := errors.Join(err1, err2); err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		x, err1 := decode(k.X)
+		y, err2 := decode(k.Y)
+		if err := errors.Join(err1, err2); err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		point := make([]byte, 1+2*size)
+		point[0] = 4 // Uncompressed point.
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+func get(url string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v: %v", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// discover returns the jwks_uri from the issuer's OpenID Connect discovery document.
+func discover(issuer string) (string, error) {
+	b, err := get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", fmt.Errorf("oidc discovery: %w", err)
+	}
+	var doc struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return "", fmt.Errorf("oidc discovery: %w", err)
+	}
+	if doc.JWKSURI == "" {
+		return "", errors.New("oidc discovery: no jwks_uri")
+	}
+	return doc.JWKSURI, nil
+}
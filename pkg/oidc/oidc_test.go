@@ -0,0 +1,232 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	issuer   = "https://issuer.example"
+	audience = "korrel8r"
+)
+
+// signer is a private key with its key ID and signing method.
+type signer struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func newRSA(t *testing.T, kid string) signer {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return signer{kid: kid, method: jwt.SigningMethodRS256, key: k}
+}
+
+func newEC(t *testing.T, kid string) signer {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return signer{kid: kid, method: jwt.SigningMethodES256, key: k}
+}
+
+func newEd25519(t *testing.T, kid string) signer {
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return signer{kid: kid, method: jwt.SigningMethodEdDSA, key: k}
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func (s signer) jwk() map[string]string {
+	switch k := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": s.kid, "n": b64(k.N.Bytes()), "e": b64(big.NewInt(int64(k.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		b, _ := k.Bytes()
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "x": b64(b[1:33]), "y": b64(b[33:])}
+	case ed25519.PublicKey:
+		return map[string]string{"kty": "OKP", "kid": s.kid, "crv": "Ed25519", "x": b64(k)}
+	}
+	panic("unknown key type")
+}
+
+func jwks(t *testing.T, signers ...signer) []byte {
+	keys := []map[string]string{{"kty": "RSA", "kid": "encryption", "use": "enc", "n": "AQAB", "e": "AQAB"}}
+	for _, s := range signers {
+		keys = append(keys, s.jwk())
+	}
+	b, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return b
+}
+
+func writeJWKS(t *testing.T, signers ...signer) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwks(t, signers...), 0o600))
+	return path
+}
+
+func (s signer) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.kid != "" {
+		token.Header["kid"] = s.kid
+	}
+	signed, err := token.SignedString(s.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    issuer,
+		"aud":    []string{"other", audience},
+		"sub":    "alice",
+		"email":  "alice@example.com",
+		"groups": []string{"dev", "ops"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	rsaKey, ecKey, edKey := newRSA(t, "rsa"), newEC(t, "ec"), newEd25519(t, "ed")
+	a, err := New(config.OIDC{Issuer: issuer, Audience: audience, JWKSFile: writeJWKS(t, rsaKey, ecKey, edKey)})
+	require.NoError(t, err)
+
+	for _, s := range []signer{rsaKey, ecKey, edKey} {
+		t.Run(s.kid, func(t *testing.T) {
+			u, err := a.Authenticate(s.sign(t, validClaims()))
+			require.NoError(t, err)
+			assert.Equal(t, &auth.User{Name: "alice"}, u, "no username without a username claim")
+		})
+	}
+
+	t.Run("no key ID", func(t *testing.T) {
+		s := ecKey
+		s.kid = ""
+		u, err := a.Authenticate(s.sign(t, validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", u.Name)
+	})
+
+	for _, x := range []struct {
+		name   string
+		signer signer
+		claims func(jwt.MapClaims)
+		err    string
+	}{
+		{"issuer", rsaKey, func(c jwt.MapClaims) { c["iss"] = "https://other.example" }, "invalid issuer"},
+		{"audience", rsaKey, func(c jwt.MapClaims) { c["aud"] = "other" }, "invalid audience"},
+		{"expired", rsaKey, func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, "token is expired"},
+		{"no expiry", rsaKey, func(c jwt.MapClaims) { delete(c, "exp") }, "exp claim is required"},
+		{"no user claim", rsaKey, func(c jwt.MapClaims) { delete(c, "sub") }, `token has no "sub" claim`},
+		{"unknown key", newRSA(t, "unknown"), func(jwt.MapClaims) {}, `unknown key ID "unknown"`},
+		{"wrong key", signer{kid: "rsa", method: jwt.SigningMethodRS256, key: newRSA(t, "").key}, func(jwt.MapClaims) {}, "verification error"},
+	} {
+		t.Run(x.name, func(t *testing.T) {
+			c := validClaims()
+			x.claims(c)
+			_, err := a.Authenticate(x.signer.sign(t, c))
+			assert.ErrorContains(t, err, x.err)
+		})
+	}
+
+	t.Run("HMAC rejected", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		_, err = a.Authenticate(token)
+		assert.ErrorContains(t, err, "signing method HS256 is invalid")
+	})
+}
+
+func TestAuthenticator_Claims(t *testing.T) {
+	s := newRSA(t, "rsa")
+	a, err := New(config.OIDC{Issuer: issuer, Audience: audience, JWKSFile: writeJWKS(t, s),
+		UserClaim: "email", GroupsClaim: "team", UsernameClaim: "preferred_username", UsernamePrefix: "oidc:"})
+	require.NoError(t, err)
+	c := validClaims()
+	c["team"] = "sre"
+	c["preferred_username"] = "system:admin"
+	u, err := a.Authenticate(s.sign(t, c))
+	require.NoError(t, err)
+	assert.Equal(t, &auth.User{Name: "alice@example.com", Username: "oidc:system:admin", Groups: []string{"oidc:sre"}}, u)
+
+	delete(c, "preferred_username")
+	_, err = a.Authenticate(s.sign(t, c))
+	assert.ErrorContains(t, err, `token has no "preferred_username" claim`)
+
+	_, err = New(config.OIDC{Issuer: issuer, Audience: audience, JWKSFile: writeJWKS(t, s), UsernameClaim: "preferred_username"})
+	assert.ErrorContains(t, err, "usernamePrefix is required")
+}
+
+func TestAuthenticator_URL(t *testing.T) {
+	oldKey, newKey := newRSA(t, "old"), newEC(t, "new")
+	keys := jwks(t, oldKey)
+	var fetches int
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"issuer": "` + srv.URL + `", "jwks_uri": "` + srv.URL + `/keys"}`))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		fetches++
+		_, _ = w.Write(keys)
+	})
+
+	for _, cfg := range []config.OIDC{
+		{Issuer: srv.URL, Audience: audience, JWKSURL: srv.URL + "/keys"},
+		{Issuer: srv.URL, Audience: audience}, // Discovery
+	} {
+		fetches = 0
+		keys = jwks(t, oldKey)
+		a, err := New(cfg)
+		require.NoError(t, err)
+		c := validClaims()
+		c["iss"] = srv.URL
+		_, err = a.Authenticate(oldKey.sign(t, c))
+		require.NoError(t, err)
+		assert.Equal(t, 1, fetches)
+
+		// Rotated keys are fetched on first use, at most once per refresh interval.
+		keys = jwks(t, newKey)
+		_, err = a.Authenticate(newKey.sign(t, c))
+		assert.ErrorContains(t, err, `unknown key ID "new"`)
+		assert.Equal(t, 1, fetches)
+		a.loaded = time.Time{}
+		_, err = a.Authenticate(newKey.sign(t, c))
+		require.NoError(t, err)
+		assert.Equal(t, 2, fetches)
+	}
+}
+
+func TestNew_Error(t *testing.T) {
+	_, err := New(config.OIDC{Issuer: issuer})
+	assert.ErrorContains(t, err, "issuer and audience are required")
+	_, err = New(config.OIDC{Issuer: issuer, Audience: audience, JWKSFile: "/nonesuch"})
+	assert.ErrorContains(t, err, "loading keys")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": []}`), 0o600))
+	_, err = New(config.OIDC{Issuer: issuer, Audience: audience, JWKSFile: path})
+	assert.ErrorContains(t, err, "no signature keys found")
+}
@@ -9,4 +9,6 @@ package otel
 const (
 	AttrServiceName = "service.name"
 	AttrTraceName   = "trace.name"
+
+	AttrK8sNamespaceName = "k8s.namespace.name"
 )
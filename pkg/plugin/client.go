@@ -0,0 +1,351 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/cache"
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/jsonpath"
+	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/internal/pkg/storeclient"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+)
+
+var log = logging.Log()
+
+var _ = impl.AssertDomainTypes(&Domain{}, map[string]any{}, &Class{}, Query{}, &Store{})
+
+const (
+	// startTimeout is the time allowed for a subprocess to print its URL.
+	startTimeout = 10 * time.Second
+	// queryCacheExpiry is how long validated queries are cached.
+	queryCacheExpiry = 5 * time.Minute
+	// requestTimeout is the time allowed for requests that don't depend on a store.
+	requestTimeout = 10 * time.Second
+)
+
+// httpClient is used for requests that don't depend on a store.
+var httpClient = &http.Client{Timeout: requestTimeout}
+
+// Domain is a domain implemented by a plugin.
+type Domain struct {
+	*impl.Domain
+	plugin  *plugin
+	queries *cache.TTL[string, Query]
+}
+
+// New creates a domain for a plugin.
+// The plugin subprocess is started and the domain information read on first use,
+// then shared by all domains with the same plugin configuration.
+func New(cfg config.Domain) (*Domain, error) {
+	pc := cfg.Plugin
+	if pc == nil {
+		return nil, fmt.Errorf("domain %q: no plugin section", cfg.Name)
+	}
+	if (pc.URL == "") == (len(pc.Command) == 0) {
+		return nil, fmt.Errorf("domain %q: plugin must have exactly one of url or command", cfg.Name)
+	}
+	p, err := connect(*pc)
+	if err != nil {
+		return nil, fmt.Errorf("domain %q: plugin: %w", cfg.Name, err)
+	}
+	if p.info.Name != cfg.Name {
+		return nil, fmt.Errorf("domain %q: plugin implements domain %q", cfg.Name, p.info.Name)
+	}
+	d := &Domain{plugin: p, queries: cache.NewTTL[string, Query](queryCacheExpiry)}
+	var classes []korrel8r.Class
+	for _, ci := range p.info.Classes {
+		c := &Class{domain: d, name: ci.Name, description: ci.Description}
+		for _, x := range []struct {
+			field **jsonpath.Path
+			expr  string
+		}{{&c.id, ci.ID}, {&c.preview, ci.Preview}, {&c.timeAt, ci.Time}} {
+			if *x.field, err = jsonpath.New(x.expr); err != nil {
+				return nil, fmt.Errorf("domain %q: class %q: %w", cfg.Name, ci.Name, err)
+			}
+		}
+		classes = append(classes, c)
+	}
+	d.Domain = impl.NewDomain(cfg.Name, cmp.Or(cfg.Description, p.info.Description), classes...)
+	return d, nil
+}
+
+// Query parses the class locally, the plugin validates and normalizes the data.
+// Valid queries are cached, the plugin call is bounded by a short timeout since queries are parsed without a context.
+func (d *Domain) Query(s string) (korrel8r.Query, error) {
+	if q, ok := d.queries.Get(s); ok {
+		return q, nil
+	}
+	c, data, err := impl.ParseQuery(d, s)
+	if err != nil {
+		return nil, err
+	}
+	var resp QueryResponse
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := call(ctx, httpClient, d.plugin.url+PathQuery, QueryRequest{Class: c.Name(), Data: data}, &resp); err != nil {
+		return nil, fmt.Errorf("invalid query: %w: %v", err, s)
+	}
+	q := Query{class: c.(*Class), data: resp.Data}
+	d.queries.Put(s, q)
+	return q, nil
+}
+
+// Store creates a store that passes the store configuration to the plugin.
+// The caller's bearer token is only forwarded if the store "auth" field is "token".
+func (d *Domain) Store(s any) (korrel8r.Store, error) {
+	cs, err := impl.TypeAssert[config.Store](s)
+	if err != nil {
+		return nil, err
+	}
+	hc, err := storeclient.New(cs, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Store: impl.NewStore(d), config: cs, client: hc}, nil
+}
+
+// Class of a plugin domain.
+type Class struct {
+	domain              *Domain
+	name, description   string
+	id, preview, timeAt *jsonpath.Path
+}
+
+var _ interface {
+	korrel8r.IDer
+	korrel8r.Previewer
+} = &Class{}
+
+func (c *Class) Domain() korrel8r.Domain { return c.domain }
+func (c *Class) Name() string            { return c.name }
+func (c *Class) String() string          { return korrel8r.ClassString(c) }
+func (c *Class) Description() string     { return c.description }
+
+func (c *Class) Unmarshal(b []byte) (korrel8r.Object, error) {
+	var o any
+	err := json.Unmarshal(b, &o)
+	return o, err
+}
+
+// ID returns the value selected by the class ID path, or the JSON encoding of the object if there is none.
+func (c *Class) ID(o korrel8r.Object) any {
+	if id := c.id.FindString(o); id != "" {
+		return id
+	}
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+// Preview returns the value selected by the class preview path, or the ID if there is none.
+func (c *Class) Preview(o korrel8r.Object) string {
+	return cmp.Or(c.preview.FindString(o), c.id.FindString(o))
+}
+
+// Time returns the time of an object, the zero time if the class has no time path.
+func (c *Class) Time(o korrel8r.Object) time.Time { return c.timeAt.FindTime(o) }
+
+// Query of a plugin domain, the data is normalized by the plugin.
+type Query struct {
+	class *Class
+	data  string
+}
+
+func (q Query) Class() korrel8r.Class { return q.class }
+func (q Query) Data() string          { return q.data }
+func (q Query) String() string        { return korrel8r.QueryString(q) }
+
+// Store forwards Get requests to the plugin.
+type Store struct {
+	*impl.Store
+	config config.Store
+	client *http.Client
+}
+
+// Get objects from the plugin.
+// The constraint limit and time range are checked again, in case the plugin ignored them.
+func (s *Store) Get(ctx context.Context, query korrel8r.Query, c *korrel8r.Constraint, result korrel8r.Appender) error {
+	q, err := impl.TypeAssert[Query](query)
+	if err != nil {
+		return err
+	}
+	d := s.Domain().(*Domain)
+	if q.class.domain != d {
+		return fmt.Errorf("wrong domain for store %v: %v", d.Name(), query)
+	}
+	var resp GetResponse
+	req := GetRequest{Class: q.class.name, Data: q.data, Constraint: c, Store: s.config}
+	if err := call(ctx, s.client, d.plugin.url+PathGet, req, &resp); err != nil {
+		return err
+	}
+	limit, n := c.GetLimit(), 0
+	for _, raw := range resp.Objects {
+		if limit > 0 && n >= limit {
+			break
+		}
+		o, err := q.class.Unmarshal(raw)
+		if err != nil {
+			return err
+		}
+		if c.CompareTime(q.class.Time(o)) == 0 {
+			result.Append(o)
+			n++
+		}
+	}
+	return nil
+}
+
+// call the plugin: GET if req is nil, POST otherwise. Decodes the response into resp.
+func call(ctx context.Context, hc *http.Client, u string, req, resp any) error {
+	method, body := http.MethodGet, []byte(nil)
+	if req != nil {
+		method = http.MethodPost
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return err
+		}
+	}
+	hreq, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hresp, err := hc.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = hresp.Body.Close() }()
+	b, err := io.ReadAll(hresp.Body)
+	if err != nil {
+		return err
+	}
+	if hresp.StatusCode/100 != 2 {
+		var e Error
+		if json.Unmarshal(b, &e) == nil && e.Error != "" {
+			return errors.New(e.Error)
+		}
+		return fmt.Errorf("%v: %v", hresp.Status, strings.TrimSpace(string(b)))
+	}
+	return json.Unmarshal(b, resp)
+}
+
+// plugin is a connected plugin, shared by all domains with the same configuration.
+type plugin struct {
+	url  string
+	info DomainInfo
+	// stdin of a subprocess, held open for the life of korrel8r.
+	stdin io.WriteCloser
+}
+
+var (
+	pluginsMu sync.Mutex
+	plugins   = map[string]func() (*plugin, error){}
+)
+
+// connect returns the plugin for cfg, starting it on first use.
+// Failures are not remembered, the next call tries again.
+func connect(cfg config.PluginDomain) (*plugin, error) {
+	key := cfg.URL
+	if key == "" {
+		key = "command:" + strings.Join(cfg.Command, "\x00")
+	}
+	pluginsMu.Lock()
+	f, ok := plugins[key]
+	if !ok {
+		f = sync.OnceValues(func() (*plugin, error) { return start(cfg) })
+		plugins[key] = f
+	}
+	pluginsMu.Unlock()
+	p, err := f()
+	if err != nil {
+		pluginsMu.Lock()
+		delete(plugins, key)
+		pluginsMu.Unlock()
+	}
+	return p, err
+}
+
+func start(cfg config.PluginDomain) (*plugin, error) {
+	p := &plugin{url: cfg.URL}
+	if len(cfg.Command) > 0 {
+		if err := p.launch(cfg.Command); err != nil {
+			return nil, err
+		}
+	}
+	p.url = strings.TrimSuffix(p.url, "/")
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := call(ctx, httpClient, p.url+PathDomain, nil, &p.info); err != nil {
+		if p.stdin != nil {
+			_ = p.stdin.Close() // Ask the subprocess to exit.
+		}
+		return nil, err
+	}
+	log.V(1).Info("Plugin connected", "domain", p.info.Name, "url", p.url)
+	return p, nil
+}
+
+// launch a subprocess and read its URL.
+func (p *plugin) launch(command []string) (err error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stderr = os.Stderr
+	if p.stdin, err = cmd.StdinPipe(); err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	type line struct {
+		s   string
+		err error
+	}
+	lines := make(chan line, 1)
+	go func() {
+		r := bufio.NewReader(stdout)
+		s, err := r.ReadString('\n')
+		lines <- line{s, err}
+		_, _ = io.Copy(os.Stderr, r) // Anything else written to stdout.
+	}()
+	select {
+	case l := <-lines:
+		if l.err != nil {
+			_ = cmd.Process.Kill()
+			return fmt.Errorf("%v: no URL: %w", command[0], l.err)
+		}
+		p.url = strings.TrimSpace(l.s)
+	case <-time.After(startTimeout):
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("%v: no URL after %v", command[0], startTimeout)
+	}
+	if u, err := url.Parse(p.url); err != nil || !u.IsAbs() {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("%v: invalid URL: %q", command[0], p.url)
+	}
+	go func() {
+		err := cmd.Wait()
+		log.Info("Plugin exited", "command", command, "error", err)
+	}()
+	log.V(1).Info("Plugin started", "command", command, "url", p.url, "pid", cmd.Process.Pid)
+	return nil
+}
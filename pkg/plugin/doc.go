@@ -0,0 +1,38 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package plugin implements domains in a separate process, using a simple HTTP/JSON protocol.
+//
+// A plugin can be written in any language, and does not need to be built into korrel8r.
+// It is declared in the "domains" section of the configuration, see [config.PluginDomain]:
+//
+//	domains:
+//	  - name: apm
+//	    plugin:
+//	      url: http://apm-plugin:8080   # A running plugin, or...
+//	      # command: [/usr/bin/apm-plugin, --verbose] # ...a subprocess started by korrel8r.
+//	stores:
+//	  - domain: apm
+//	    endpoint: https://apm.example.com # Store configuration is passed to the plugin.
+//
+// # Protocol
+//
+// Requests and responses are JSON, paths are relative to the plugin URL:
+//
+//	GET  /v1/domain  -> [DomainInfo]
+//	POST /v1/query   [QueryRequest] -> [QueryResponse]
+//	POST /v1/get     [GetRequest] -> [GetResponse]
+//
+// A failed request has a non-2XX status and an [Error] body.
+// The caller's bearer token is forwarded in the Authorization header of /v1/get if the store "auth" field is "token".
+//
+// Classes declare optional JSONPath expressions (kubectl syntax) for the ID, preview and time of their objects,
+// so korrel8r can remove duplicates, show previews and apply time constraints without calling the plugin.
+//
+// # Subprocess
+//
+// A plugin started with "command" must listen on a local address,
+// print its URL as the first line of standard output, and exit when standard input is closed.
+// The plugin's standard error is copied to korrel8r's standard error.
+//
+// Plugins written in Go can use [Serve] and [Handler], see the sample plugin in cmd/korrel8r-plugin-sample.
+package plugin
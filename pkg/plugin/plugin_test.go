@@ -0,0 +1,191 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package plugin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/domain"
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/domaintest"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/plugin"
+	"github.com/korrel8r/korrel8r/pkg/plugin/sample"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// servePluginEnv makes the test binary run as a plugin subprocess, see TestMain.
+const servePluginEnv = "KORREL8R_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(servePluginEnv) != "" {
+		if err := plugin.Serve(sample.New(events)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// start is recent, so events are inside the default constraint time range.
+var start = time.Now().Add(-30 * time.Minute).Truncate(time.Second).UTC()
+
+// events has domain.BatchLen events in namespace "billing", one per minute, and one in namespace "web".
+var events = func() []sample.Event {
+	var events []sample.Event
+	for i := range domain.BatchLen {
+		events = append(events, sample.Event{
+			ID: fmt.Sprintf("e%v", i), Namespace: "billing", Name: "invoices",
+			Version: fmt.Sprintf("v%v", i), Time: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return append(events, sample.Event{ID: "w", Namespace: "web", Name: "frontend", Version: "v1", Time: start})
+}()
+
+// newServer serves the sample plugin, and records the last Authorization header.
+func newServer(t *testing.T) (srv *httptest.Server, authorization *string) {
+	authorization = new(string)
+	h := plugin.Handler(sample.New(events))
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == plugin.PathGet {
+			*authorization = r.Header.Get("Authorization")
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, authorization
+}
+
+func newDomain(t *testing.T, url string) *plugin.Domain {
+	t.Helper()
+	d, err := plugin.New(config.Domain{Name: "deploy", Plugin: &config.PluginDomain{URL: url}})
+	require.NoError(t, err)
+	return d
+}
+
+func TestNew_Error(t *testing.T) {
+	srv, _ := newServer(t)
+	for _, x := range []struct {
+		cfg config.Domain
+		err string
+	}{
+		{config.Domain{Name: "x"}, `domain "x": no plugin section`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{}}, `exactly one of url or command`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{URL: "u", Command: []string{"c"}}}, `exactly one of url or command`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{URL: srv.URL}}, `plugin implements domain "deploy"`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{Command: []string{"/nonesuch/plugin"}}}, `no such file`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{Command: []string{"true"}}}, `no URL`},
+		{config.Domain{Name: "x", Plugin: &config.PluginDomain{URL: srv.URL + "/nonesuch"}}, `404`},
+	} {
+		t.Run(x.err, func(t *testing.T) {
+			_, err := plugin.New(x.cfg)
+			assert.ErrorContains(t, err, x.err)
+		})
+	}
+}
+
+func TestDomain_Query(t *testing.T) {
+	srv, _ := newServer(t)
+	d := newDomain(t, srv.URL)
+	assert.Equal(t, "deploy", d.Name())
+	assert.Equal(t, "Application deployment events (sample plugin).", d.Description())
+	require.Len(t, d.Classes(), 1)
+	q, err := d.Query(`deploy:event:{"name": "invoices", "namespace": "billing"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `deploy:event:{"namespace":"billing","name":"invoices"}`, q.String())
+	_, err = d.Query(`deploy:event:["x"]`)
+	assert.ErrorContains(t, err, "invalid query: invalid selector")
+	_, err = d.Query(`deploy:nonesuch:{}`)
+	assert.Error(t, err)
+}
+
+func TestStore_Get(t *testing.T) {
+	srv, authorization := newServer(t)
+	d := newDomain(t, srv.URL)
+	s, err := d.Store(config.Store{"domain": "deploy"})
+	require.NoError(t, err)
+	ctx := auth.WithToken(context.Background(), "secret")
+	q, err := d.Query(`deploy:event:{"namespace":"billing"}`)
+	require.NoError(t, err)
+	class := q.Class().(*plugin.Class)
+
+	r := result.New(q.Class())
+	require.NoError(t, s.Get(ctx, q, nil, r))
+	require.Len(t, r.List(), domain.BatchLen)
+	o := r.List()[1]
+	assert.Equal(t, "e1", class.ID(o))
+	assert.Equal(t, "invoices", class.Preview(o))
+	assert.Equal(t, start.Add(time.Minute), class.Time(o).UTC())
+	assert.Empty(t, *authorization, "token not forwarded by default")
+
+	t.Run("constraint", func(t *testing.T) {
+		r := result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, &korrel8r.Constraint{Start: new(start.Add(8 * time.Minute)), Limit: new(5)}, r))
+		assert.Len(t, r.List(), 2)
+		r = result.New(q.Class())
+		require.NoError(t, s.Get(ctx, q, &korrel8r.Constraint{Limit: new(3)}, r))
+		assert.Len(t, r.List(), 3)
+	})
+
+	t.Run("auth token", func(t *testing.T) {
+		s, err := d.Store(config.Store{"domain": "deploy", "auth": "token"})
+		require.NoError(t, err)
+		require.NoError(t, s.Get(ctx, q, nil, result.New(q.Class())))
+		assert.Equal(t, "Bearer secret", *authorization)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		srv.Close()
+		assert.Error(t, s.Get(ctx, q, nil, result.New(q.Class())))
+	})
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv(servePluginEnv, "1")
+	e, err := engine.Build().Domains(mock.NewDomain("mock", "namespace")).Config(config.Configs{{
+		Domains: []config.Domain{{Name: "deploy", Plugin: &config.PluginDomain{Command: []string{os.Args[0]}}}},
+		Stores:  []config.Store{{"domain": "deploy"}},
+		Rules: []config.Rule{{
+			Name:   "NamespaceToEvent",
+			Start:  config.ClassSpec{Domain: "mock", Classes: []string{"namespace"}},
+			Goal:   config.ClassSpec{Domain: "deploy", Classes: []string{"event"}},
+			Result: config.ResultSpec{Query: `deploy:event:{"namespace":"{{.}}"}`},
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	queries, err := e.Rule("NamespaceToEvent").Apply("web")
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+	r := result.New(queries[0].Class())
+	require.NoError(t, e.Get(context.Background(), queries[0], &korrel8r.Constraint{}, r))
+	require.Len(t, r.List(), 1)
+	assert.Equal(t, "w", r.List()[0].(map[string]any)["id"])
+
+	_, err = engine.Build().Config(config.Configs{{Domains: []config.Domain{{
+		Name: "deploy", HTTP: &config.HTTPDomain{}, Plugin: &config.PluginDomain{URL: "u"},
+	}}}}).Engine()
+	assert.ErrorContains(t, err, "only one of http or plugin")
+}
+
+func TestPluginDomain(t *testing.T) {
+	srv, _ := newServer(t)
+	d := newDomain(t, srv.URL)
+	s, err := d.Store(config.Store{"domain": "deploy"})
+	require.NoError(t, err)
+	q, err := d.Query(`deploy:event:{"namespace":"billing"}`)
+	require.NoError(t, err)
+	suite := &domaintest.Suite{Domain: d, Store: s, Query: q, Len: domain.BatchLen, Time: q.Class().(*plugin.Class).Time}
+	suite.Run(t)
+}
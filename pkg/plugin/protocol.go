@@ -0,0 +1,64 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package plugin
+
+import (
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Paths of protocol requests, relative to the plugin URL.
+const (
+	PathDomain = "/v1/domain"
+	PathQuery  = "/v1/query"
+	PathGet    = "/v1/get"
+)
+
+// DomainInfo describes the domain implemented by a plugin.
+type DomainInfo struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Classes     []ClassInfo `json:"classes"`
+}
+
+// ClassInfo describes a class.
+// ID, Preview and Time are optional JSONPath expressions, see [config.HTTPClass] for their meaning.
+type ClassInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Preview     string `json:"preview,omitempty"`
+	Time        string `json:"time,omitempty"`
+}
+
+// QueryRequest asks the plugin to validate the data part of a query.
+type QueryRequest struct {
+	Class string `json:"class"`
+	Data  string `json:"data"`
+}
+
+// QueryResponse has the normalized query data: equivalent queries should have the same data.
+type QueryResponse struct {
+	Data string `json:"data"`
+}
+
+// GetRequest asks the plugin for the objects selected by a query.
+type GetRequest struct {
+	Class      string               `json:"class"`
+	Data       string               `json:"data"`
+	Constraint *korrel8r.Constraint `json:"constraint,omitempty"`
+	// Store is the store configuration from korrel8r, with templates expanded.
+	Store config.Store `json:"store,omitempty"`
+}
+
+// GetResponse returns the objects for a [GetRequest].
+// A query that selects nothing has an empty list, not an error.
+type GetResponse struct {
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// Error body for a failed request.
+type Error struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,93 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package sample is a small example of a [plugin.Plugin], used by tests and cmd/korrel8r-plugin-sample.
+//
+// It implements a "deploy" domain with one class "event", a record of an application deployment.
+// Query data is a JSON object with optional "namespace" and "name" fields, empty fields match anything.
+//
+//	deploy:event:{"namespace":"billing"}
+package sample
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/plugin"
+)
+
+// Event is a deployment event.
+type Event struct {
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Version   string    `json:"version"`
+	Time      time.Time `json:"time"`
+	User      string    `json:"user,omitempty"`
+}
+
+// Selector is the query data for class "event".
+type Selector struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Plugin serves a fixed list of events.
+type Plugin struct{ events []Event }
+
+var _ plugin.Plugin = &Plugin{}
+
+// New returns a plugin that serves events.
+func New(events []Event) *Plugin { return &Plugin{events: events} }
+
+func (p *Plugin) Domain() plugin.DomainInfo {
+	return plugin.DomainInfo{
+		Name:        "deploy",
+		Description: "Application deployment events (sample plugin).",
+		Classes: []plugin.ClassInfo{{
+			Name:        "event",
+			Description: "A deployment of a new application version.",
+			ID:          ".id",
+			Preview:     ".name",
+			Time:        ".time",
+		}},
+	}
+}
+
+func (p *Plugin) Query(class, data string) (string, error) {
+	if class != "event" {
+		return "", fmt.Errorf("unknown class: %q", class)
+	}
+	s, err := parse(data)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+func (p *Plugin) Get(ctx context.Context, req *plugin.GetRequest) ([]any, error) {
+	s, err := parse(req.Data)
+	if err != nil {
+		return nil, err
+	}
+	objects := []any{}
+	for _, e := range p.events {
+		if (s.Namespace == "" || s.Namespace == e.Namespace) && (s.Name == "" || s.Name == e.Name) &&
+			req.Constraint.CompareTime(e.Time) == 0 {
+			objects = append(objects, e)
+		}
+	}
+	return objects, nil
+}
+
+func parse(data string) (s Selector, err error) {
+	if data == "" {
+		return s, nil
+	}
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return s, fmt.Errorf("invalid selector: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,110 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+)
+
+// Plugin is implemented by Go plugins served by [Handler] or [Serve].
+type Plugin interface {
+	// Domain describes the domain and its classes.
+	Domain() DomainInfo
+	// Query validates query data for a class and returns the normalized data.
+	Query(class, data string) (string, error)
+	// Get returns the objects selected by a request.
+	// The context has the caller's token, if it was forwarded, see [auth.ContextToken].
+	Get(ctx context.Context, req *GetRequest) ([]any, error)
+}
+
+// Handler returns a HTTP handler that serves the plugin protocol for p.
+func Handler(p Plugin) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+PathDomain, func(w http.ResponseWriter, r *http.Request) {
+		reply(w, p.Domain(), nil)
+	})
+	mux.HandleFunc("POST "+PathQuery, func(w http.ResponseWriter, r *http.Request) {
+		var req QueryRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		data, err := p.Query(req.Class, req.Data)
+		reply(w, QueryResponse{Data: data}, err)
+	})
+	mux.HandleFunc("POST "+PathGet, func(w http.ResponseWriter, r *http.Request) {
+		var req GetRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		ctx := r.Context()
+		if token := auth.HeaderToken(r.Header); token != "" {
+			ctx = auth.WithToken(ctx, token)
+		}
+		objects, err := p.Get(ctx, &req)
+		if err != nil {
+			reply(w, nil, err)
+			return
+		}
+		resp := GetResponse{Objects: make([]json.RawMessage, 0, len(objects))}
+		for _, o := range objects {
+			b, err := json.Marshal(o)
+			if err != nil {
+				reply(w, nil, err)
+				return
+			}
+			resp.Objects = append(resp.Objects, b)
+		}
+		reply(w, resp, nil)
+	})
+	return mux
+}
+
+// Serve runs p as a korrel8r subprocess plugin.
+// It listens on a local port, prints the URL to stdout, and returns when stdin is closed.
+func Serve(p Plugin) error {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: Handler(p)}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(l) }()
+	if _, err := fmt.Fprintf(os.Stdout, "http://%v\n", l.Addr()); err != nil {
+		return err
+	}
+	go func() {
+		_, _ = io.Copy(io.Discard, os.Stdin)
+		done <- srv.Close()
+	}()
+	if err := <-done; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(Error{Error: err.Error()})
+		return false
+	}
+	return true
+}
+
+func reply(w http.ResponseWriter, v any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		v = Error{Error: err.Error()}
+	}
+	_ = json.NewEncoder(w).Encode(v)
+}
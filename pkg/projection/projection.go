@@ -0,0 +1,317 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package projection selects fields of result objects, to reduce the size of results.
+//
+// Projection is applied to objects when they are returned, after rules have been applied to the full objects.
+//
+// # Fields
+//
+// A field is a path of keys separated by ".", in a simplified JSONPath or jq form, for example:
+//
+//	metadata.name
+//	.status.conditions[*].type
+//	$.metadata.labels["app.kubernetes.io/name"]
+//
+// A leading "$" or "." is optional. Keys that contain "." or other special characters are quoted in brackets.
+// Arrays are traversed implicitly: "[*]" or "[]" may be used to make this clear, array indices are not supported.
+// The projected object has the same nested structure as the original, with only the selected fields.
+//
+// # Projections
+//
+// A projection is a list of entries separated by ";". Each entry has the form
+//
+//	[SELECTOR=]FIELDS
+//
+// FIELDS is the name of a profile or a comma-separated list of fields. The profiles are:
+//
+//   - "compact" uses the fields of [korrel8r.Compacter] classes.
+//   - "summary" replaces objects of [korrel8r.Summarizer] classes by their summary.
+//
+// SELECTOR is a domain name like "k8s", or a class name like "k8s:Pod".
+// Entries without a selector apply to all classes.
+// For each class, the entry with the most specific selector is used: class, then domain, then no selector.
+// Objects of classes with no matching entry are returned unchanged, as are objects that are not JSON objects.
+//
+// Examples:
+//
+//	compact
+//	summary;netflow=compact
+//	k8s=compact;log=body,level
+//	compact;k8s:Pod=metadata.name,status.containerStatuses[*].state
+package projection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Profile names.
+const (
+	Compact = "compact" // Uses [korrel8r.Compacter] fields.
+	Summary = "summary" // Uses [korrel8r.Summarizer] summaries.
+)
+
+// Projection selects fields of objects depending on their class.
+// Methods of a nil *Projection return objects unchanged.
+type Projection struct {
+	all     *fields
+	entries []entry
+}
+
+type entry struct {
+	domain, class string // class is "" for a domain entry
+	fields        *fields
+}
+
+type fields struct {
+	compact, summary bool
+	paths            []path
+}
+
+// path is a list of keys.
+type path []string
+
+// Parse a projection, returns nil for an empty string.
+func Parse(s string) (*Projection, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	p := &Projection{}
+	for e := range strings.SplitSeq(s, ";") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		selector, list, ok := strings.Cut(e, "=")
+		if !ok {
+			selector, list = "", e
+		}
+		f, err := parseFields(list)
+		if err != nil {
+			return nil, err
+		}
+		selector = strings.TrimSpace(selector)
+		if selector == "" {
+			p.all = f
+			continue
+		}
+		domain, class, _ := strings.Cut(selector, ":")
+		if domain == "" {
+			return nil, fmt.Errorf("invalid projection selector: %q", selector)
+		}
+		p.entries = append(p.entries, entry{domain: domain, class: class, fields: f})
+	}
+	return p, nil
+}
+
+func parseFields(s string) (*fields, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case Compact:
+		return &fields{compact: true}, nil
+	case Summary:
+		return &fields{summary: true}, nil
+	}
+	paths, err := parsePaths(strings.Split(s, ","))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("invalid projection, no fields: %q", s)
+	}
+	return &fields{paths: paths}, nil
+}
+
+func parsePaths(fields []string) ([]path, error) {
+	var paths []path
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		p, err := parsePath(f)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func parsePath(s string) (path, error) {
+	invalid := func() (path, error) { return nil, fmt.Errorf("invalid projection field: %q", s) }
+	rest := strings.TrimPrefix(s, "$")
+	var p path
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			i := strings.IndexAny(rest, ".[")
+			if i < 0 {
+				i = len(rest)
+			}
+			if i == 0 {
+				if rest == "" || rest[0] == '.' {
+					return invalid()
+				}
+				continue // Bracket follows the dot.
+			}
+			p, rest = append(p, rest[:i]), rest[i:]
+		case '[':
+			switch {
+			case strings.HasPrefix(rest, "[*]"):
+				rest = rest[3:]
+			case strings.HasPrefix(rest, "[]"):
+				rest = rest[2:]
+			case len(rest) > 1 && (rest[1] == '"' || rest[1] == '\''):
+				end := strings.Index(rest[2:], string(rest[1])+"]")
+				if end < 0 {
+					return invalid()
+				}
+				p, rest = append(p, rest[2:2+end]), rest[2+end+2:]
+			default:
+				return invalid()
+			}
+		default:
+			rest = "." + rest // First key without a leading dot.
+		}
+	}
+	if len(p) == 0 {
+		return invalid()
+	}
+	return p, nil
+}
+
+// Object returns the projection of o, which belongs to class c.
+func (p *Projection) Object(c korrel8r.Class, o korrel8r.Object) korrel8r.Object {
+	if s := p.summarizer(c); s != nil {
+		if summary := s.Summary(o); summary != nil {
+			return summary
+		}
+		return o
+	}
+	paths, ok := p.paths(c)
+	if !ok {
+		return o
+	}
+	var v any
+	switch o := o.(type) {
+	case map[string]any:
+		v = o
+	default:
+		b, err := json.Marshal(o)
+		if err != nil || json.Unmarshal(b, &v) != nil {
+			return o
+		}
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return o
+	}
+	projected, _ := project(v, paths)
+	return projected
+}
+
+// Objects returns the projections of objects, which belong to class c.
+// Returns objects unchanged if there is no projection for c.
+func (p *Projection) Objects(c korrel8r.Class, objects []korrel8r.Object) []korrel8r.Object {
+	if _, ok := p.paths(c); !ok && p.summarizer(c) == nil {
+		return objects
+	}
+	projected := make([]korrel8r.Object, len(objects))
+	for i, o := range objects {
+		projected[i] = p.Object(c, o)
+	}
+	return projected
+}
+
+// summarizer returns the summarizer for class c, nil if objects of c are not summarized.
+func (p *Projection) summarizer(c korrel8r.Class) korrel8r.Summarizer {
+	if f := p.fields(c); f != nil && f.summary {
+		s, _ := c.(korrel8r.Summarizer)
+		return s
+	}
+	return nil
+}
+
+// paths returns the paths to project for class c, false if objects of c are not projected by field.
+func (p *Projection) paths(c korrel8r.Class) ([]path, bool) {
+	f := p.fields(c)
+	if f == nil || f.summary {
+		return nil, false
+	}
+	if !f.compact {
+		return f.paths, true
+	}
+	cp, ok := c.(korrel8r.Compacter)
+	if !ok {
+		return nil, false
+	}
+	paths, err := parsePaths(cp.CompactFields())
+	if err != nil || len(paths) == 0 {
+		return nil, false
+	}
+	return paths, true
+}
+
+// fields returns the fields of the most specific entry that selects c.
+func (p *Projection) fields(c korrel8r.Class) *fields {
+	if p == nil || c == nil {
+		return nil
+	}
+	var domain *fields
+	for _, e := range p.entries {
+		if e.domain != c.Domain().Name() {
+			continue
+		}
+		if e.class == "" {
+			domain = e.fields
+		} else if e.class == c.Name() || c.Domain().Class(e.class) == c {
+			return e.fields
+		}
+	}
+	if domain != nil {
+		return domain
+	}
+	return p.all
+}
+
+// project returns the parts of v selected by paths, false if nothing is selected.
+func project(v any, paths []path) (any, bool) {
+	for _, p := range paths {
+		if len(p) == 0 {
+			return v, true // Whole value
+		}
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		next := map[string][]path{}
+		var keys []string
+		for _, p := range paths {
+			if _, ok := next[p[0]]; !ok {
+				keys = append(keys, p[0])
+			}
+			next[p[0]] = append(next[p[0]], p[1:])
+		}
+		out := map[string]any{}
+		for _, k := range keys {
+			if x, ok := v[k]; ok {
+				if x, ok := project(x, next[k]); ok {
+					out[k] = x
+				}
+			}
+		}
+		return out, len(out) > 0
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, x := range v {
+			if x, ok := project(x, paths); ok {
+				out = append(out, x)
+			}
+		}
+		return out, len(out) > 0
+	default:
+		return nil, false
+	}
+}
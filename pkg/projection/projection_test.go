@@ -0,0 +1,136 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package projection
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	for _, x := range []struct {
+		s    string
+		want path
+	}{
+		{"a", path{"a"}},
+		{"a.b.c", path{"a", "b", "c"}},
+		{".a.b", path{"a", "b"}},
+		{"$.a.b", path{"a", "b"}},
+		{"a[*].b", path{"a", "b"}},
+		{"a[].b", path{"a", "b"}},
+		{`a["x.y/z"].b`, path{"a", "x.y/z", "b"}},
+	} {
+		t.Run(x.s, func(t *testing.T) {
+			p, err := parsePath(x.s)
+			require.NoError(t, err)
+			assert.Equal(t, x.want, p)
+		})
+	}
+	for _, s := range []string{"$", ".", "a..b", "a.", "a[0]", `a["x`} {
+		t.Run(s, func(t *testing.T) {
+			_, err := parsePath(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse("")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+	for _, s := range []string{"a=", ":b=x", "a[0]"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestProjection_Object(t *testing.T) {
+	o := map[string]any{
+		"metadata": map[string]any{
+			"name":          "x",
+			"labels":        map[string]any{"app.kubernetes.io/name": "shop", "tier": "web"},
+			"managedFields": []any{map[string]any{"manager": "kubectl"}},
+		},
+		"status": map[string]any{
+			"phase": "Running",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True", "lastProbeTime": nil},
+				map[string]any{"type": "Scheduled", "status": "True"},
+			},
+		},
+	}
+	d := mock.NewDomain("mock", "a")
+	a := d.Class("a")
+	for _, x := range []struct {
+		projection string
+		want       korrel8r.Object
+	}{
+		{"metadata.name", map[string]any{"metadata": map[string]any{"name": "x"}}},
+		{"metadata.name,status.phase", map[string]any{"metadata": map[string]any{"name": "x"}, "status": map[string]any{"phase": "Running"}}},
+		{`metadata.labels["app.kubernetes.io/name"]`, map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "shop"}}}},
+		{"status.conditions[*].type", map[string]any{"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Ready"}, map[string]any{"type": "Scheduled"}}}}},
+		{"nonesuch", map[string]any{}},
+		{"metadata.name.nonesuch", map[string]any{}},
+		{"other=status;mock=metadata.name", map[string]any{"metadata": map[string]any{"name": "x"}}},
+		{"status.phase;mock:a=metadata.name;mock=status", map[string]any{"metadata": map[string]any{"name": "x"}}},
+		{"other=status", o},
+		{"compact", o}, // Mock class is not a Compacter
+	} {
+		t.Run(x.projection, func(t *testing.T) {
+			p, err := Parse(x.projection)
+			require.NoError(t, err)
+			assert.Equal(t, x.want, p.Object(a, o))
+		})
+	}
+	t.Run("not a JSON object", func(t *testing.T) {
+		p, err := Parse("a")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", p.Object(a, "hello"))
+	})
+	t.Run("nil", func(t *testing.T) {
+		var p *Projection
+		assert.Equal(t, o, p.Object(a, o))
+	})
+}
+
+func TestProjection_compact(t *testing.T) {
+	pod, err := k8s.Domain.Class("Pod").Unmarshal([]byte(`{
+  "apiVersion": "v1", "kind": "Pod",
+  "metadata": {"name": "x", "namespace": "y", "managedFields": [{"manager": "kubectl"}], "annotations": {"big": "data"}},
+  "spec": {"nodeName": "n", "containers": [{"name": "c", "image": "i"}]},
+  "status": {"phase": "Running", "containerStatuses": [{"name": "c", "ready": true, "restartCount": 3, "image": "i"}]}
+}`))
+	require.NoError(t, err)
+	p, err := Parse("k8s:Pod=compact")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"apiVersion": "v1", "kind": "Pod",
+		"metadata": map[string]any{"name": "x", "namespace": "y"},
+		"spec":     map[string]any{"nodeName": "n"},
+		"status": map[string]any{"phase": "Running", "containerStatuses": []any{
+			map[string]any{"name": "c", "ready": true, "restartCount": float64(3)}}},
+	}, p.Object(k8s.Domain.Class("Pod"), pod))
+}
+
+func TestProjection_summary(t *testing.T) {
+	pod, err := k8s.Domain.Class("Pod").Unmarshal([]byte(`{
+  "apiVersion": "v1", "kind": "Pod",
+  "metadata": {"name": "x", "namespace": "y", "managedFields": [{"manager": "kubectl"}]},
+  "status": {"phase": "Running"}
+}`))
+	require.NoError(t, err)
+	p, err := Parse("summary;mock=name")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"kind": "Pod", "name": "x", "namespace": "y", "phase": "Running", "ready": "0/0", "restarts": int64(0)},
+		p.Object(k8s.Domain.Class("Pod"), pod))
+	// Classes that are not a Summarizer are unchanged.
+	a := mock.NewDomain("other", "a").Class("a")
+	o := []korrel8r.Object{map[string]any{"name": "x"}}
+	assert.Equal(t, o, p.Objects(a, o))
+}
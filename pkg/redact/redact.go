@@ -0,0 +1,222 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package redact replaces secrets and personal data in objects returned to clients.
+//
+// Redaction is applied to objects when they are returned, after rules have been applied to the full objects.
+// Values are replaced by [Redacted], the structure of the object is unchanged.
+//
+// Built-in rules:
+//
+//   - [SecretData]: values of the "data" and "stringData" fields of a Kubernetes Secret,
+//     and its last-applied-configuration annotation, which contains the same data.
+//   - [Env]: the "value" of an element of an "env" list, if the "name" matches a regular expression.
+//   - [BearerToken], [Email]: matching text in any string value.
+//
+// Configured patterns are applied to string values in the same way as the built-in text rules.
+package redact
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// Redacted replaces redacted values.
+const Redacted = "[REDACTED]"
+
+// Names of the built-in rules, used as keys in [Counts].
+const (
+	SecretData  = "secret-data"
+	Env         = "env"
+	BearerToken = "bearer-token"
+	Email       = "email"
+)
+
+// DefaultEnvNames matches names of environment variables that usually have secret values.
+const DefaultEnvNames = `(?i)(PASSWORD|PASSWD|SECRET|TOKEN|KEY|CREDENTIAL)`
+
+const lastApplied = "kubectl.kubernetes.io/last-applied-configuration"
+
+var builtinPatterns = []pattern{
+	{BearerToken, regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)},
+	{Email, regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+}
+
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Counts is the number of values redacted by each rule.
+type Counts map[string]int
+
+// Add the counts in other to c.
+func (c Counts) Add(other Counts) {
+	for k, n := range other {
+		c[k] += n
+	}
+}
+
+// String returns the counts as a comma-separated list of name=count, sorted by name.
+func (c Counts) String() string {
+	var b strings.Builder
+	for i, k := range slices.Sorted(maps.Keys(c)) {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "%v=%v", k, c[k])
+	}
+	return b.String()
+}
+
+// Redactor redacts objects and strings.
+// Methods of a nil *Redactor return values unchanged.
+type Redactor struct {
+	envNames *regexp.Regexp
+	patterns []pattern
+}
+
+// New returns a redactor for cfg.
+func New(cfg config.Redaction) (*Redactor, error) {
+	envNames, err := regexp.Compile(cmp.Or(cfg.EnvNames, DefaultEnvNames))
+	if err != nil {
+		return nil, fmt.Errorf("redaction envNames: %w", err)
+	}
+	r := &Redactor{envNames: envNames, patterns: slices.Clone(builtinPatterns)}
+	for _, p := range cfg.Patterns {
+		if p.Name == "" {
+			return nil, fmt.Errorf("redaction pattern has no name: %q", p.Regex)
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("redaction pattern %v: %w", p.Name, err)
+		}
+		r.patterns = append(r.patterns, pattern{p.Name, re})
+	}
+	return r, nil
+}
+
+// Object returns a redacted copy of o, which belongs to class c, and adds redactions to counts.
+//
+// If nothing is redacted, o is returned unchanged.
+// Otherwise the redacted copy is decoded by c, so it has the same Go type as o if possible.
+// o is never modified.
+func (r *Redactor) Object(c korrel8r.Class, o korrel8r.Object, counts Counts) korrel8r.Object {
+	if r == nil {
+		return o
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return o
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return o
+	}
+	found := Counts{}
+	v = r.value(v, found)
+	if len(found) == 0 {
+		return o
+	}
+	counts.Add(found)
+	if c != nil {
+		if b, err := json.Marshal(v); err == nil {
+			if o, err := c.Unmarshal(b); err == nil {
+				return o
+			}
+		}
+	}
+	return v
+}
+
+// Objects returns redacted copies of objects, which belong to class c, and adds redactions to counts.
+func (r *Redactor) Objects(c korrel8r.Class, objects []korrel8r.Object, counts Counts) []korrel8r.Object {
+	if r == nil {
+		return objects
+	}
+	redacted := make([]korrel8r.Object, len(objects))
+	for i, o := range objects {
+		redacted[i] = r.Object(c, o, counts)
+	}
+	return redacted
+}
+
+// String returns s with matching text redacted, and adds redactions to counts.
+func (r *Redactor) String(s string, counts Counts) string {
+	if r == nil || s == Redacted {
+		return s
+	}
+	for _, p := range r.patterns {
+		s = p.re.ReplaceAllStringFunc(s, func(string) string {
+			counts[p.name]++
+			return Redacted
+		})
+	}
+	return s
+}
+
+// value redacts a decoded JSON value in place, and returns it.
+func (r *Redactor) value(v any, counts Counts) any {
+	switch v := v.(type) {
+	case string:
+		return r.String(v, counts)
+	case []any:
+		for i := range v {
+			v[i] = r.value(v[i], counts)
+		}
+	case map[string]any:
+		if isSecret(v) {
+			r.secret(v, counts)
+		}
+		if env, ok := v["env"].([]any); ok {
+			r.env(env, counts)
+		}
+		for k := range v {
+			v[k] = r.value(v[k], counts)
+		}
+	}
+	return v
+}
+
+// isSecret is true if m is a Kubernetes Secret.
+func isSecret(m map[string]any) bool { return m["kind"] == "Secret" && m["apiVersion"] == "v1" }
+
+// secret redacts the data of a Kubernetes Secret.
+func (r *Redactor) secret(m map[string]any, counts Counts) {
+	for _, field := range []string{"data", "stringData"} {
+		if data, ok := m[field].(map[string]any); ok {
+			for k := range data {
+				data[k] = Redacted
+				counts[SecretData]++
+			}
+		}
+	}
+	if meta, ok := m["metadata"].(map[string]any); ok {
+		if annotations, ok := meta["annotations"].(map[string]any); ok {
+			if _, ok := annotations[lastApplied]; ok {
+				annotations[lastApplied] = Redacted
+				counts[SecretData]++
+			}
+		}
+	}
+}
+
+// env redacts the values of environment variables with secret names.
+func (r *Redactor) env(env []any, counts Counts) {
+	for _, e := range env {
+		if e, ok := e.(map[string]any); ok {
+			name, _ := e["name"].(string)
+			if value, ok := e["value"]; ok && value != "" && r.envNames.MatchString(name) {
+				e["value"] = Redacted
+				counts[Env]++
+			}
+		}
+	}
+}
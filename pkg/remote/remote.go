@@ -0,0 +1,200 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Package remote forwards store requests and searches to a remote korrel8r instance.
+//
+// A remote store can be configured for any domain by setting the `remote` store field to the
+// base URL of another korrel8r, for example:
+//
+//	stores:
+//	  - domain: log
+//	    cluster: spoke1
+//	    remote: https://korrel8r.spoke1.example.com
+//
+// Get is forwarded to the remote /objects operation, results are unmarshalled by the local class.
+// The bearer token of the caller is forwarded to the remote korrel8r.
+// With `auth: token` requests with no caller token fail, rather than being sent unauthenticated.
+// The optional `certificateAuthority` field is a path to a CA certificate for the remote server.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/storeclient"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+)
+
+// MaxResponse is the maximum size in bytes of a response body from a remote korrel8r.
+// Larger responses are an error, rather than being read into memory.
+const MaxResponse = 64 << 20
+
+// Client calls the REST API of a remote korrel8r.
+type Client struct {
+	base *url.URL
+	hc   *http.Client
+}
+
+// NewClient returns a client for the korrel8r at base URL.
+// If hc is nil, use a default client that forwards the caller's bearer token.
+func NewClient(base string, hc *http.Client) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(base, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid remote URL: %q", base)
+	}
+	if hc == nil {
+		hc = &http.Client{Transport: auth.Wrap(http.DefaultTransport)}
+	}
+	return &Client{base: u, hc: hc}, nil
+}
+
+// URL is the base URL of the remote korrel8r.
+func (c *Client) URL() string { return c.base.String() }
+
+// Objects calls the remote /objects operation.
+func (c *Client) Objects(ctx context.Context, query string, constraint *api.Constraint) ([]json.RawMessage, error) {
+	v := url.Values{"query": {query}}
+	if constraint != nil {
+		if constraint.Limit != nil {
+			v.Set("limit", strconv.Itoa(*constraint.Limit))
+		}
+		if constraint.QueryLimit != nil {
+			v.Set("queryLimit", strconv.Itoa(*constraint.QueryLimit))
+		}
+		if constraint.Start != nil {
+			v.Set("start", constraint.Start.Format(time.RFC3339Nano))
+		}
+		if constraint.End != nil {
+			v.Set("end", constraint.End.Format(time.RFC3339Nano))
+		}
+		if constraint.Cluster != "" {
+			v.Set("cluster", constraint.Cluster)
+		}
+	}
+	var objects []json.RawMessage
+	err := c.do(ctx, http.MethodGet, "/objects?"+v.Encode(), nil, &objects)
+	return objects, err
+}
+
+// Goals calls the remote /graphs/goals operation.
+func (c *Client) Goals(ctx context.Context, goals api.Goals, opts api.GraphOptions) (*api.Graph, error) {
+	var g api.Graph
+	return &g, c.do(ctx, http.MethodPost, "/graphs/goals?"+options(opts), goals, &g)
+}
+
+// Neighbors calls the remote /graphs/neighbors operation.
+func (c *Client) Neighbors(ctx context.Context, neighbors api.Neighbors, opts api.GraphOptions) (*api.Graph, error) {
+	var g api.Graph
+	return &g, c.do(ctx, http.MethodPost, "/graphs/neighbors?"+options(opts), neighbors, &g)
+}
+
+func options(opts api.GraphOptions) string {
+	v := url.Values{}
+	for k, b := range map[string]*bool{"errors": opts.Errors, "results": opts.Results, "rules": opts.Rules} {
+		if b != nil {
+			v.Set(k, strconv.FormatBool(*b))
+		}
+	}
+	return v.Encode()
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base.String()+api.BasePath+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponse+1))
+	if err != nil {
+		return err
+	}
+	if len(b) > MaxResponse {
+		return fmt.Errorf("remote korrel8r %v: response is larger than %v bytes", c.base, MaxResponse)
+	}
+	if resp.StatusCode >= 400 {
+		var e api.Error
+		if json.Unmarshal(b, &e) == nil && e.Error != "" {
+			return fmt.Errorf("remote korrel8r %v: %v: %v", c.base, resp.Status, e.Error)
+		}
+		return fmt.Errorf("remote korrel8r %v: %v", c.base, resp.Status)
+	}
+	return json.Unmarshal(b, result)
+}
+
+// Store forwards Get requests for a domain to a remote korrel8r.
+type Store struct {
+	*Client
+	domain korrel8r.Domain
+}
+
+// NewStore creates a remote store for domain d from store configuration.
+func NewStore(d korrel8r.Domain, cs config.Store) (*Store, error) {
+	hc, err := storeclient.New(cs, true)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewClient(cs[config.StoreKeyRemote], hc)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Client: c, domain: d}, nil
+}
+
+func (s *Store) Domain() korrel8r.Domain { return s.domain }
+
+// Get forwards the query to the remote korrel8r, and unmarshals the results with the query class.
+// The constraint cluster is not forwarded, it is not meaningful to the remote korrel8r.
+func (s *Store) Get(ctx context.Context, q korrel8r.Query, c *korrel8r.Constraint, r korrel8r.Appender) error {
+	ac := Constraint(c)
+	if ac != nil {
+		ac.Cluster = ""
+	}
+	objects, err := s.Objects(ctx, q.String(), ac)
+	if err != nil {
+		return err
+	}
+	for _, raw := range objects {
+		o, err := q.Class().Unmarshal(raw)
+		if err != nil {
+			return err
+		}
+		r.Append(o)
+	}
+	return nil
+}
+
+// Constraint converts a korrel8r.Constraint to an api.Constraint.
+func Constraint(c *korrel8r.Constraint) *api.Constraint {
+	if c == nil {
+		return nil
+	}
+	return &api.Constraint{Limit: c.Limit, QueryLimit: c.QueryLimit, Start: c.Start, End: c.End, Cluster: c.Cluster}
+}
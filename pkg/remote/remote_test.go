@@ -0,0 +1,102 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/remote"
+	"github.com/korrel8r/korrel8r/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Get(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`["a", "b"]`))
+	}))
+	defer srv.Close()
+
+	d := mock.NewDomain("mock", "x")
+	s, err := remote.NewStore(d, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyRemote: srv.URL})
+	require.NoError(t, err)
+	q := mock.NewQuery(d.Class("x"), "sel")
+	r := result.New(q.Class())
+	ctx := auth.WithToken(context.Background(), "secret")
+	require.NoError(t, s.Get(ctx, q, &korrel8r.Constraint{Limit: new(10), Cluster: "local"}, r))
+
+	assert.Equal(t, []korrel8r.Object{"a", "b"}, r.List())
+	assert.Equal(t, api.BasePath+"/objects", got.URL.Path)
+	assert.Equal(t, "mock:x:sel", got.URL.Query().Get("query"))
+	assert.Equal(t, "10", got.URL.Query().Get("limit"))
+	assert.False(t, got.URL.Query().Has("cluster"), "local cluster name must not be forwarded")
+	assert.Equal(t, "Bearer secret", got.Header.Get("Authorization"))
+}
+
+func TestStore_GetError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer srv.Close()
+
+	d := mock.NewDomain("mock", "x")
+	s, err := remote.NewStore(d, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyRemote: srv.URL})
+	require.NoError(t, err)
+	q := mock.NewQuery(d.Class("x"), "sel")
+	err = s.Get(context.Background(), q, nil, result.New(q.Class()))
+	assert.ErrorContains(t, err, "404 Not Found: boom")
+}
+
+func TestStore_AuthToken(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	d := mock.NewDomain("mock", "x")
+	s, err := remote.NewStore(d, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyRemote: srv.URL, config.StoreKeyAuth: config.StoreAuthToken})
+	require.NoError(t, err)
+	q := mock.NewQuery(d.Class("x"), "sel")
+	err = s.Get(context.Background(), q, nil, result.New(q.Class()))
+	assert.ErrorIs(t, err, auth.ErrNoToken)
+	assert.False(t, called, "request must not be sent without a token")
+
+	_, err = remote.NewStore(d, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyRemote: srv.URL, config.StoreKeyAuth: config.StoreAuthImpersonate})
+	assert.Error(t, err)
+}
+
+func TestNewClient_BadURL(t *testing.T) {
+	_, err := remote.NewClient("not-a-url", nil)
+	assert.Error(t, err)
+}
+
+func TestStore_GetTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["`))
+		_, _ = w.Write(bytes.Repeat([]byte("x"), remote.MaxResponse))
+		_, _ = w.Write([]byte(`"]`))
+	}))
+	defer srv.Close()
+
+	d := mock.NewDomain("mock", "x")
+	s, err := remote.NewStore(d, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyRemote: srv.URL})
+	require.NoError(t, err)
+	q := mock.NewQuery(d.Class("x"), "sel")
+	err = s.Get(context.Background(), q, nil, result.New(q.Class()))
+	assert.ErrorContains(t, err, "response is larger than")
+}
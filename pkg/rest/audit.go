@@ -0,0 +1,89 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/audit"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/session"
+)
+
+const auditKey = "korrel8r.audit"
+
+// Audit returns a Gin handler that writes an audit record to l for each REST API request,
+// and for requests to other paths that are rejected as unauthorized.
+// Operation handlers add details with the audit* functions.
+//
+// It must be added to the router before [session.Middleware], so requests that fail authentication are recorded.
+// If l is nil, nothing is recorded.
+func Audit(l *audit.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil {
+			c.Next()
+			return
+		}
+		r := &audit.Record{Time: time.Now(), Interface: audit.REST, Remote: c.ClientIP()}
+		if tool := api.ContextMCPTool(c.Request.Context()); tool != "" { // Set in-process by the MCP server, not a header.
+			r.Interface, r.Tool = audit.MCP, tool
+		}
+		c.Set(auditKey, r)
+		c.Next()
+
+		r.Status = c.Writer.Status()
+		path := c.FullPath()
+		if !strings.HasPrefix(path, api.BasePath) && r.Status != http.StatusUnauthorized {
+			return
+		}
+		ctx := c.Request.Context() // Updated by session.Middleware if the request is authenticated.
+		if u := auth.ContextUser(ctx); u != nil {
+			r.User = u.Name
+		}
+		if s := session.FromContext(ctx); s != nil {
+			r.Session = s.ID
+		}
+		r.Operation = c.Request.Method + " " + cmp.Or(strings.TrimPrefix(path, api.BasePath), c.Request.URL.Path)
+		if err := c.Errors.Last(); err != nil {
+			r.Error = err.Error()
+		}
+		r.DurationMS = time.Since(r.Time).Milliseconds()
+		l.Log(r)
+	}
+}
+
+// auditRecord returns the audit record for the request, nil if there is none.
+func auditRecord(c *gin.Context) *audit.Record {
+	v, _ := c.Get(auditKey)
+	r, _ := v.(*audit.Record)
+	return r
+}
+
+// auditSearch records the parameters of a search.
+func auditSearch(c *gin.Context, start api.Start, goals []string, depth *int) {
+	if r := auditRecord(c); r != nil {
+		r.Start = &audit.Start{Class: start.Class, Queries: start.Queries, Objects: len(start.Objects)}
+		r.Goals, r.Depth, r.Constraint = goals, depth, start.Constraint
+	}
+}
+
+// auditGraph records the classes and counts of objects in a result graph.
+func auditGraph(c *gin.Context, g *graph.Graph) {
+	r := auditRecord(c)
+	if r == nil || g == nil {
+		return
+	}
+	g.EachNode(func(n *graph.Node) {
+		if !n.Empty() {
+			r.Results = append(r.Results, audit.Count{Class: n.Class.String(), Count: len(n.Result.List())})
+		}
+	})
+	slices.SortFunc(r.Results, func(a, b audit.Count) int { return cmp.Compare(a.Class, b.Class) })
+}
@@ -10,3 +10,5 @@ type GraphGoalsParams = api.GraphGoalsParams
 type GraphNeighborsParams = api.GraphNeighborsParams
 type GraphNeighboursParams = api.GraphNeighboursParams
 type ObjectsParams = api.ObjectsParams
+type RunRecipeParams = api.RunRecipeParams
+type DoctorParams = api.DoctorParams
@@ -18,6 +18,15 @@ type ServerInterface interface {
 	// SetConfig Change configuration settings at runtime.
 	// (PUT /config)
 	SetConfig(c *gin.Context, params SetConfigParams)
+	// DeleteOverlay Remove the configuration overlay for this session.
+	// (DELETE /config/overlay)
+	DeleteOverlay(c *gin.Context)
+	// GetOverlay Get the configuration overlay for this session.
+	// (GET /config/overlay)
+	GetOverlay(c *gin.Context)
+	// SetOverlay Set a configuration overlay for this session.
+	// (PUT /config/overlay)
+	SetOverlay(c *gin.Context)
 	// GetConsole Get current console state.
 	// (GET /console)
 	GetConsole(c *gin.Context)
@@ -30,6 +39,9 @@ type ServerInterface interface {
 	// ShowInConsole Send a display update to the console.
 	// (PUT /console/events)
 	ShowInConsole(c *gin.Context)
+	// Doctor Diagnose store connection and permission problems.
+	// (GET /doctor)
+	Doctor(c *gin.Context, params DoctorParams)
 	// ListDomainClasses Get the list of classes for a domain.
 	// (GET /domain/{domain}/classes)
 	ListDomainClasses(c *gin.Context, domain string)
@@ -59,6 +71,30 @@ type ServerInterface interface {
 	// Objects Execute a query, returns a list of JSON objects.
 	// (GET /objects)
 	Objects(c *gin.Context, params ObjectsParams)
+	// ListRecipes List recipes.
+	// (GET /recipes)
+	ListRecipes(c *gin.Context)
+	// RunRecipe Run a recipe, returns a correlation graph.
+	// (POST /recipes/{name})
+	RunRecipe(c *gin.Context, name string, params RunRecipeParams)
+	// Resolve Propose start queries from free text.
+	// (POST /resolve)
+	Resolve(c *gin.Context)
+	// RootCauses Rank the objects found by a neighbors search as candidate root causes of a problem.
+	// (POST /rootcauses)
+	RootCauses(c *gin.Context)
+	// ListSearches List saved searches for this session.
+	// (GET /searches)
+	ListSearches(c *gin.Context)
+	// DeleteSearch Delete a saved search for this session.
+	// (DELETE /searches/{name})
+	DeleteSearch(c *gin.Context, name string)
+	// SaveSearch Save a search for this session.
+	// (PUT /searches/{name})
+	SaveSearch(c *gin.Context, name string)
+	// Timeline Create a time-sorted list of the objects found by a correlation search.
+	// (POST /timeline)
+	Timeline(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -97,6 +133,45 @@ func (siw *ServerInterfaceWrapper) SetConfig(c *gin.Context) {
 	siw.Handler.SetConfig(c, params)
 }
 
+// DeleteOverlay operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOverlay(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteOverlay(c)
+}
+
+// GetOverlay operation middleware
+func (siw *ServerInterfaceWrapper) GetOverlay(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOverlay(c)
+}
+
+// SetOverlay operation middleware
+func (siw *ServerInterfaceWrapper) SetOverlay(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetOverlay(c)
+}
+
 // GetConsole operation middleware
 func (siw *ServerInterfaceWrapper) GetConsole(c *gin.Context) {
 
@@ -149,6 +224,32 @@ func (siw *ServerInterfaceWrapper) ShowInConsole(c *gin.Context) {
 	siw.Handler.ShowInConsole(c)
 }
 
+// Doctor operation middleware
+func (siw *ServerInterfaceWrapper) Doctor(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DoctorParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", c.Request.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter domain: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Doctor(c, params)
+}
+
 // ListDomainClasses operation middleware
 func (siw *ServerInterfaceWrapper) ListDomainClasses(c *gin.Context) {
 
@@ -344,6 +445,22 @@ func (siw *ServerInterfaceWrapper) Objects(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "project" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "project", c.Request.URL.Query(), &params.Project)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter project: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "groupBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupBy", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter groupBy: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -354,6 +471,156 @@ func (siw *ServerInterfaceWrapper) Objects(c *gin.Context) {
 	siw.Handler.Objects(c, params)
 }
 
+// ListRecipes operation middleware
+func (siw *ServerInterfaceWrapper) ListRecipes(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRecipes(c)
+}
+
+// RunRecipe operation middleware
+func (siw *ServerInterfaceWrapper) RunRecipe(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RunRecipeParams
+
+	// ------------- Optional query parameter "options" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "options", c.Request.URL.Query(), &params.Options)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter options: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RunRecipe(c, name, params)
+}
+
+// Resolve operation middleware
+func (siw *ServerInterfaceWrapper) Resolve(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Resolve(c)
+}
+
+// RootCauses operation middleware
+func (siw *ServerInterfaceWrapper) RootCauses(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RootCauses(c)
+}
+
+// ListSearches operation middleware
+func (siw *ServerInterfaceWrapper) ListSearches(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSearches(c)
+}
+
+// DeleteSearch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSearch(c, name)
+}
+
+// SaveSearch operation middleware
+func (siw *ServerInterfaceWrapper) SaveSearch(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SaveSearch(c, name)
+}
+
+// Timeline operation middleware
+func (siw *ServerInterfaceWrapper) Timeline(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Timeline(c)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -382,13 +649,25 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	}
 
 	router.PUT(options.BaseURL+"/config", wrapper.SetConfig)
+	router.DELETE(options.BaseURL+"/config/overlay", wrapper.DeleteOverlay)
+	router.GET(options.BaseURL+"/config/overlay", wrapper.GetOverlay)
+	router.PUT(options.BaseURL+"/config/overlay", wrapper.SetOverlay)
 	router.GET(options.BaseURL+"/domains", wrapper.ListDomains)
 	router.GET(options.BaseURL+"/domain/:domain/classes", wrapper.ListDomainClasses)
 	router.POST(options.BaseURL+"/graphs/goals", wrapper.GraphGoals)
 	router.POST(options.BaseURL+"/graphs/neighbors", wrapper.GraphNeighbors)
 	router.POST(options.BaseURL+"/graphs/neighbours", wrapper.GraphNeighbours)
 	router.POST(options.BaseURL+"/lists/goals", wrapper.ListGoals)
+	router.POST(options.BaseURL+"/timeline", wrapper.Timeline)
+	router.POST(options.BaseURL+"/rootcauses", wrapper.RootCauses)
+	router.GET(options.BaseURL+"/recipes", wrapper.ListRecipes)
+	router.POST(options.BaseURL+"/recipes/:name", wrapper.RunRecipe)
+	router.GET(options.BaseURL+"/searches", wrapper.ListSearches)
+	router.DELETE(options.BaseURL+"/searches/:name", wrapper.DeleteSearch)
+	router.PUT(options.BaseURL+"/searches/:name", wrapper.SaveSearch)
 	router.GET(options.BaseURL+"/objects", wrapper.Objects)
+	router.POST(options.BaseURL+"/resolve", wrapper.Resolve)
+	router.GET(options.BaseURL+"/doctor", wrapper.Doctor)
 	router.GET(options.BaseURL+"/help", wrapper.Help)
 	router.GET(options.BaseURL+"/help/:domain", wrapper.HelpDomain)
 	router.GET(options.BaseURL+"/console", wrapper.GetConsole)
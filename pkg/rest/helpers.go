@@ -7,6 +7,7 @@ import (
 	"cmp"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
@@ -15,10 +16,17 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/text"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	logs "github.com/korrel8r/korrel8r/pkg/domains/log"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/doctor"
+	"github.com/korrel8r/korrel8r/pkg/engine/resolve"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
+	"github.com/korrel8r/korrel8r/pkg/engine/timeline"
 	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/projection"
 	"github.com/korrel8r/korrel8r/pkg/ptr"
 )
 
@@ -57,10 +65,33 @@ func node(n *graph.Node, opts api.GraphOptions) api.Node {
 		Count:   new(len(n.Result.List())),
 	}
 	if ptr.Deref(opts.Results) {
-		for _, o := range n.Result.List() {
+		p, _ := projection.Parse(ptr.Deref(opts.Project)) // Validated by checkProjection
+		for _, o := range p.Objects(n.Class, n.Result.List()) {
 			j, _ := json.Marshal(o)
 			node.Result = append(node.Result, j)
 		}
+		node.ResultClusters = n.Clusters
+		if ptr.Deref(opts.Order) == api.OrderRank {
+			node.ResultScores = n.Scores
+		}
+	}
+	if n.Clusters != nil {
+		counts := map[string]int{}
+		for _, c := range n.Clusters {
+			if c != "" {
+				counts[c]++
+			}
+		}
+		for _, c := range slices.Sorted(maps.Keys(counts)) {
+			node.Clusters = append(node.Clusters, api.ClusterCount{Cluster: c, Count: counts[c]})
+		}
+	}
+	if _, ok := n.Class.(logs.Class); ok && ptr.Deref(opts.Patterns) {
+		node.Patterns = APILogPatterns(logs.FindPatterns(n.Result.List()))
+	}
+	if fields := GroupFields(ptr.Deref(opts.GroupBy)); len(fields) > 0 {
+		// Counts cover the node results only, which are limited by the constraint, they are not pushed down to stores.
+		node.Groups = APIGroups(impl.Aggregate(n.Result.List(), fields))
 	}
 	return node
 }
@@ -152,9 +183,22 @@ func NewGraph(g *graph.Graph, optsPtr *api.GraphOptions) *api.Graph {
 		return &api.Graph{}
 	}
 	opts := ptr.Deref(optsPtr)
-	return &api.Graph{Nodes: nodes(g, opts), Edges: edges(g, opts)}
+	ag := &api.Graph{Nodes: nodes(g, opts), Edges: edges(g, opts)}
+	if ptr.Deref(opts.Errors) {
+		ag.Errors = g.Errors
+	}
+	return ag
+}
+
+// checkProjection fails the request with 400 Bad Request if the projection in opts is not valid.
+func checkProjection(c *gin.Context, opts *api.GraphOptions) bool {
+	_, err := projection.Parse(ptr.Deref(ptr.Deref(opts).Project))
+	return check(c, http.StatusBadRequest, err)
 }
 
+// isRank returns true if opts asks for rank order, see [traverse.Start.Rank].
+func isRank(opts *api.GraphOptions) bool { return ptr.Deref(ptr.Deref(opts).Order) == api.OrderRank }
+
 func copyBody(r *http.Request) string {
 	if r.Body == nil {
 		return ""
@@ -237,7 +281,115 @@ func Constraint(c *api.Constraint) *korrel8r.Constraint {
 	if c == nil {
 		return nil
 	}
-	return &korrel8r.Constraint{Limit: c.Limit, QueryLimit: c.QueryLimit, Start: c.Start, End: c.End}
+	return &korrel8r.Constraint{Limit: c.Limit, QueryLimit: c.QueryLimit, Start: c.Start, End: c.End, Cluster: c.Cluster}
+}
+
+// APIRecipe converts an engine.Recipe to an api.Recipe
+func APIRecipe(r *engine.Recipe) api.Recipe {
+	ar := api.Recipe{Name: r.Name, Description: r.Description, Goals: r.Recipe.Goals}
+	if r.Depth > 0 {
+		ar.Depth = new(r.Depth)
+	}
+	for _, p := range r.Params {
+		ap := api.RecipeParam{Name: p.Name, Description: p.Description}
+		if p.Default != "" {
+			ap.Default = new(p.Default)
+		}
+		ar.Params = append(ar.Params, ap)
+	}
+	return ar
+}
+
+// APICandidates converts resolve.Candidate values to api.Candidates
+func APICandidates(candidates []resolve.Candidate) api.Candidates {
+	ac := api.Candidates{} // return [] not null for empty
+	for _, c := range candidates {
+		ac = append(ac, api.Candidate{Query: c.Query.String(), Confidence: c.Confidence, Reason: c.Reason, Count: c.Count})
+	}
+	return ac
+}
+
+func APIDiagnoses(stores []doctor.Store) api.Diagnoses {
+	ad := api.Diagnoses{} // return [] not null for empty
+	for _, s := range stores {
+		d := api.Diagnosis{Domain: s.Domain, Store: api.Store(s.Config), Level: api.Level(s.Level()), Findings: []api.Finding{}}
+		if s.Expanded != nil {
+			d.Expanded = new(api.Store(s.Expanded))
+		}
+		for _, f := range s.Findings {
+			d.Findings = append(d.Findings, api.Finding{Check: f.Check, Level: api.Level(f.Level), Message: f.Message, Fix: f.Fix})
+		}
+		ad = append(ad, d)
+	}
+	return ad
+}
+
+// APITimeline converts timeline entries and non-fatal search errors to an API timeline.
+func APITimeline(entries []timeline.Entry, errors []string) *api.Timeline {
+	at := &api.Timeline{Entries: []api.TimelineEntry{}, Errors: errors} // return [] not null for empty
+	for _, x := range entries {
+		ae := api.TimelineEntry{Class: x.Class.String(), Time: x.Time, Statuses: x.Statuses, Cluster: x.Cluster}
+		if p, ok := x.Class.(korrel8r.Previewer); ok {
+			ae.Preview = p.Preview(x.Object)
+		}
+		for _, r := range x.Rules {
+			ae.Rules = append(ae.Rules, r.Name())
+		}
+		at.Entries = append(at.Entries, ae)
+	}
+	return at
+}
+
+// APILogPatterns converts log patterns to API log patterns.
+func APILogPatterns(patterns []logs.Pattern) []api.LogPattern {
+	var ap []api.LogPattern
+	for _, p := range patterns {
+		lp := api.LogPattern{Template: p.Template, Count: p.Count, Example: p.Example}
+		if !p.First.IsZero() {
+			lp.First, lp.Last = &p.First, &p.Last
+		}
+		ap = append(ap, lp)
+	}
+	return ap
+}
+
+// GroupFields splits a comma-separated list of field names, ignoring empty names.
+func GroupFields(s string) []string {
+	var fields []string
+	for f := range strings.SplitSeq(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// APIGroups converts group counts to API group counts.
+func APIGroups(groups []korrel8r.Group) []api.GroupCount {
+	ag := make([]api.GroupCount, len(groups))
+	for i, g := range groups {
+		ag[i] = api.GroupCount{Fields: g.Fields, Count: g.Count}
+	}
+	return ag
+}
+
+// APIRootCauses converts root cause candidates and non-fatal search errors to API root causes.
+func APIRootCauses(candidates []rootcause.Candidate, errors []string) *api.RootCauses {
+	ar := &api.RootCauses{Candidates: []api.RootCause{}, Errors: errors} // return [] not null for empty
+	for _, x := range candidates {
+		rc := api.RootCause{Class: x.Class.String(), Score: x.Score, Statuses: x.Statuses, Reasons: x.Reasons, Cluster: x.Cluster}
+		if !x.Time.IsZero() {
+			rc.Time = &x.Time
+		}
+		if p, ok := x.Class.(korrel8r.Previewer); ok {
+			rc.Preview = p.Preview(x.Object)
+		}
+		for _, r := range x.Rules {
+			rc.Rules = append(rc.Rules, r.Name())
+		}
+		ar.Candidates = append(ar.Candidates, rc)
+	}
+	return ar
 }
 
 // DomainHelp returns the full description text for domains.
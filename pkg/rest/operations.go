@@ -4,6 +4,7 @@
 package rest
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -17,9 +18,17 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/json"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
 	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/audit"
+	"github.com/korrel8r/korrel8r/pkg/authz"
+	"github.com/korrel8r/korrel8r/pkg/engine/doctor"
+	"github.com/korrel8r/korrel8r/pkg/engine/resolve"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
+	"github.com/korrel8r/korrel8r/pkg/engine/timeline"
 	"github.com/korrel8r/korrel8r/pkg/engine/traverse"
 	"github.com/korrel8r/korrel8r/pkg/graph"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/projection"
+	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/result"
 	"github.com/korrel8r/korrel8r/pkg/session"
 	"github.com/korrel8r/korrel8r/pkg/unique"
@@ -73,7 +82,7 @@ func (a *API) ListDomains(c *gin.Context) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	c.JSON(http.StatusOK, ListDomains(session.Engine))
+	c.JSON(http.StatusOK, ListDomains(session.Engine()))
 }
 
 func (a *API) ListDomainClasses(c *gin.Context, domain string) {
@@ -81,7 +90,7 @@ func (a *API) ListDomainClasses(c *gin.Context, domain string) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	d, err := session.Engine.Domain(domain)
+	d, err := session.Engine().Domain(domain)
 	if !check(c, http.StatusNotFound, err, "domain not found: %s", domain) {
 		return
 	}
@@ -94,14 +103,17 @@ func (a *API) ListDomainClasses(c *gin.Context, domain string) {
 }
 
 func (a *API) GraphGoals(c *gin.Context, params GraphGoalsParams) {
-	g, _ := a.goals(c)
+	if !checkProjection(c, params.Options) {
+		return
+	}
+	g, _ := a.goals(c, params.Options)
 	gr := NewGraph(g, params.Options)
 	okResponse(c, gr)
 }
 
 func (a *API) ListGoals(c *gin.Context) {
 	nodes := []api.Node{} // return [] not null for empty
-	g, goals := a.goals(c)
+	g, goals := a.goals(c, nil)
 	if c.IsAborted() {
 		return
 	}
@@ -115,23 +127,30 @@ func (a *API) ListGoals(c *gin.Context) {
 }
 
 func (a *API) GraphNeighbors(c *gin.Context, params GraphNeighborsParams) {
+	if !checkProjection(c, params.Options) {
+		return
+	}
 	session, err := a.session(c)
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	e := session.Engine
+	e := session.Engine()
 	r := api.Neighbors{}
 	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
 		return
 	}
+	auditSearch(c, r.Start, nil, &r.Depth)
 	start, err := TraverseStart(e, r.Start)
 	if !check(c, http.StatusBadRequest, err) {
 		return
 	}
+	start.Rank = isRank(params.Options)
 	g, err := traverse.Neighbors(c.Request.Context(), e, start, r.Depth)
 	if !check(c, http.StatusNotFound, err) {
 		return
 	}
+	auditGraph(c, g)
+	redactGraph(c, e, g)
 	gr := NewGraph(g, params.Options)
 	okResponse(c, gr)
 }
@@ -148,23 +167,232 @@ func (a *API) Objects(c *gin.Context, params ObjectsParams) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	e := session.Engine
+	e := session.Engine()
+	ar := auditRecord(c)
+	if ar != nil {
+		ar.Start, ar.Constraint = &audit.Start{Queries: []string{params.Query}}, params.Constraint
+	}
 	query, err := e.Query(params.Query)
 	if !check(c, http.StatusBadRequest, err) {
 		return
 	}
 	constraint := Constraint(params.Constraint)
+	project, err := projection.Parse(ptr.Deref(params.Project))
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	if fields := GroupFields(ptr.Deref(params.GroupBy)); len(fields) > 0 {
+		groups, err := aggregate(c, e, query, constraint, fields)
+		if !check(c, http.StatusNotFound, authz.Fatal(err)) {
+			return
+		}
+		if ar != nil {
+			n := 0
+			for _, g := range groups {
+				n += g.Count
+			}
+			ar.Results = []audit.Count{{Class: query.Class().String(), Count: n}}
+		}
+		c.JSON(http.StatusOK, APIGroups(groups))
+		return
+	}
 	result := result.New(query.Class())
-	if !check(c, http.StatusNotFound, e.Get(c.Request.Context(), query, constraint, result)) {
+	// Objects the caller is not allowed to see are dropped silently, the rest are returned.
+	if !check(c, http.StatusNotFound, authz.Fatal(e.Get(c.Request.Context(), query, constraint, result))) {
 		return
 	}
-	body := []any(result.List())
+	if ar != nil {
+		ar.Results = []audit.Count{{Class: query.Class().String(), Count: len(result.List())}}
+	}
+	objects := redactObjects(c, e, query.Class(), result.List())
+	body := []any(project.Objects(query.Class(), objects))
 	if body == nil {
 		body = []any{} // Return [] on empty, not null.
 	}
 	c.JSON(http.StatusOK, body)
 }
 
+// Resolve proposes start queries from free text.
+// (POST /resolve)
+func (a *API) Resolve(c *gin.Context) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	var r api.Resolve
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return
+	}
+	ar := auditRecord(c)
+	if ar != nil {
+		ar.Text, ar.Constraint = r.Text, r.Constraint
+	}
+	candidates := resolve.Resolve(c.Request.Context(), session.Engine(), r.Text, Constraint(r.Constraint))
+	if ar != nil {
+		for _, cand := range candidates {
+			ar.Candidates = append(ar.Candidates, cand.Query.String())
+		}
+	}
+	c.JSON(http.StatusOK, APICandidates(candidates))
+}
+
+// Timeline runs a goals or neighbors search, and returns the objects found sorted by time.
+// (POST /timeline)
+func (a *API) Timeline(c *gin.Context) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	e := session.Engine()
+	var r api.TimelineSearch
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return
+	}
+	depth := cmp.Or(ptr.Deref(r.Depth), timeline.DefaultDepth)
+	if len(r.Goals) > 0 {
+		auditSearch(c, r.Start, r.Goals, nil)
+	} else {
+		auditSearch(c, r.Start, nil, &depth)
+	}
+	start, err := TraverseStart(e, r.Start)
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	var g *graph.Graph
+	if len(r.Goals) > 0 {
+		goals, err := e.Classes(r.Goals)
+		if !check(c, http.StatusBadRequest, err) {
+			return
+		}
+		g, err = traverse.Goals(c.Request.Context(), e, start, goals)
+	} else {
+		g, err = traverse.Neighbors(c.Request.Context(), e, start, depth)
+	}
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	auditGraph(c, g)
+	entries := timeline.New(e, g, start.Class)
+	redactTimeline(c, e, entries)
+	c.JSON(http.StatusOK, APITimeline(entries, g.Errors))
+}
+
+// RootCauses runs a neighbors search, and returns the objects found ranked as candidate root causes.
+// (POST /rootcauses)
+func (a *API) RootCauses(c *gin.Context) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	e := session.Engine()
+	var r api.RootCauseSearch
+	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
+		return
+	}
+	depth := cmp.Or(ptr.Deref(r.Depth), rootcause.DefaultDepth)
+	auditSearch(c, r.Start, nil, &depth)
+	start, err := TraverseStart(e, r.Start)
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	g, err := traverse.Neighbors(c.Request.Context(), e, start, depth)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	auditGraph(c, g)
+	candidates := rootcause.Analyze(e, g, start.Class, cmp.Or(ptr.Deref(r.Limit), rootcause.DefaultLimit))
+	redactRootCauses(c, e, candidates)
+	c.JSON(http.StatusOK, APIRootCauses(candidates, g.Errors))
+}
+
+// Doctor diagnoses store connection and permission problems.
+// (GET /doctor)
+func (a *API) Doctor(c *gin.Context, params DoctorParams) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	e := session.Engine()
+	ar := auditRecord(c)
+	if ar != nil {
+		ar.Domains = ptr.Deref(params.Domain)
+	}
+	var domains []korrel8r.Domain
+	for _, name := range ptr.Deref(params.Domain) {
+		d, err := e.Domain(name)
+		if !check(c, http.StatusNotFound, err, "domain not found: %s", name) {
+			return
+		}
+		domains = append(domains, d)
+	}
+	stores := doctor.Diagnose(c.Request.Context(), e, domains, nil)
+	if ar != nil {
+		ar.Diagnoses = map[string]int{}
+		for _, s := range stores {
+			ar.Diagnoses[string(s.Level())]++
+		}
+	}
+	c.JSON(http.StatusOK, APIDiagnoses(stores))
+}
+
+// ListRecipes lists the configured recipes.
+// (GET /recipes)
+func (a *API) ListRecipes(c *gin.Context) {
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	recipes := []api.Recipe{} // return [] not null for empty
+	for _, r := range session.Engine().Recipes() {
+		recipes = append(recipes, APIRecipe(r))
+	}
+	c.JSON(http.StatusOK, recipes)
+}
+
+// RunRecipe runs a recipe and returns the resulting graph.
+// (POST /recipes/{name})
+func (a *API) RunRecipe(c *gin.Context, name string, params RunRecipeParams) {
+	if !checkProjection(c, params.Options) {
+		return
+	}
+	session, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	e := session.Engine()
+	r, err := e.Recipe(name)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	var run api.RecipeRun
+	if c.Request.ContentLength != 0 && !check(c, http.StatusBadRequest, c.BindJSON(&run)) {
+		return
+	}
+	queries, err := r.Queries(run.Params)
+	if ar := auditRecord(c); ar != nil {
+		ar.Recipe, ar.Constraint = name, run.Constraint
+		ar.Start = &audit.Start{}
+		for _, q := range queries {
+			ar.Start.Queries = append(ar.Start.Queries, q.String())
+		}
+	}
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	opts := params.Options
+	if opts == nil && r.Options != nil {
+		opts = &api.GraphOptions{Rules: &r.Options.Rules, Results: &r.Options.Results, Errors: &r.Options.Errors}
+	}
+	start := traverse.Start{Queries: queries, Constraint: Constraint(run.Constraint), Rank: isRank(opts)}
+	g, err := traverse.Recipe(c.Request.Context(), e, r, start)
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	auditGraph(c, g)
+	redactGraph(c, e, g)
+	okResponse(c, NewGraph(g, opts))
+}
+
 func (a *API) SetConfig(c *gin.Context, params SetConfigParams) {
 	if params.Verbose != nil {
 		log.V(1).Info("Config set verbose", "level", *params.Verbose)
@@ -173,17 +401,115 @@ func (a *API) SetConfig(c *gin.Context, params SetConfigParams) {
 	c.JSON(http.StatusOK, params)
 }
 
-// goals is shared between GraphGoals and ListGoals
-func (a *API) goals(c *gin.Context) (*graph.Graph, []korrel8r.Class) {
+// GetOverlay returns the configuration overlay for the session.
+// (GET /config/overlay)
+func (a *API) GetOverlay(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	overlay, err := s.Overlay()
+	if !check(c, http.StatusNotFound, err) {
+		return
+	}
+	o, err := APIOverlay(overlay)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	c.JSON(http.StatusOK, o)
+}
+
+// SetOverlay rebuilds the session engine with a configuration overlay.
+// (PUT /config/overlay)
+func (a *API) SetOverlay(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	var o api.Overlay
+	if !check(c, http.StatusBadRequest, c.BindJSON(&o)) {
+		return
+	}
+	overlay, err := ConfigOverlay(&o)
+	if !check(c, http.StatusBadRequest, err) {
+		return
+	}
+	if !check(c, http.StatusBadRequest, s.SetOverlay(overlay), "invalid overlay") {
+		return
+	}
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+// DeleteOverlay restores the base configuration for the session.
+// (DELETE /config/overlay)
+func (a *API) DeleteOverlay(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	if !check(c, http.StatusInternalServerError, s.SetOverlay(nil)) {
+		return
+	}
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+// ListSearches returns the saved searches for the session.
+// (GET /searches)
+func (a *API) ListSearches(c *gin.Context) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	searches := s.Searches()
+	if searches == nil {
+		searches = api.SavedSearches{}
+	}
+	c.JSON(http.StatusOK, searches)
+}
+
+// SaveSearch saves a search for the session.
+// (PUT /searches/{name})
+func (a *API) SaveSearch(c *gin.Context, name string) {
+	var search api.Search
+	if !check(c, http.StatusBadRequest, c.BindJSON(&search)) {
+		return
+	}
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	if !check(c, http.StatusBadRequest, ConsoleOK(s.Engine(), &api.Console{Search: &search}), "invalid search") {
+		return
+	}
+	s.SaveSearch(name, search)
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+// DeleteSearch deletes a saved search for the session.
+// (DELETE /searches/{name})
+func (a *API) DeleteSearch(c *gin.Context, name string) {
+	s, err := a.session(c)
+	if !check(c, http.StatusInternalServerError, err) {
+		return
+	}
+	if !check(c, http.StatusNotFound, s.DeleteSearch(name)) {
+		return
+	}
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+// goals is shared between GraphGoals and ListGoals, it returns the graph and goals.
+func (a *API) goals(c *gin.Context, opts *api.GraphOptions) (*graph.Graph, []korrel8r.Class) {
 	session, err := a.session(c)
 	if !check(c, http.StatusInternalServerError, err) {
 		return nil, nil
 	}
-	e := session.Engine
+	e := session.Engine()
 	r := api.Goals{}
 	if !check(c, http.StatusBadRequest, c.BindJSON(&r)) {
 		return nil, nil
 	}
+	auditSearch(c, r.Start, r.Goals, nil)
 	start, err := TraverseStart(e, r.Start)
 	if !check(c, http.StatusBadRequest, err) {
 		return nil, nil
@@ -192,8 +518,11 @@ func (a *API) goals(c *gin.Context) (*graph.Graph, []korrel8r.Class) {
 	if !check(c, http.StatusBadRequest, err) {
 		return nil, nil
 	}
+	start.Rank = isRank(opts)
 	g, err := traverse.Goals(c.Request.Context(), e, start, goals)
 	check(c, http.StatusNotFound, err)
+	auditGraph(c, g)
+	redactGraph(c, e, g)
 	return g, goals
 }
 
@@ -223,7 +552,7 @@ func (a *API) Help(c *gin.Context) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	doc, err := DomainHelp(session.Engine, "")
+	doc, err := DomainHelp(session.Engine(), "")
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
@@ -235,7 +564,7 @@ func (a *API) HelpDomain(c *gin.Context, domain string) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	doc, err := DomainHelp(session.Engine, domain)
+	doc, err := DomainHelp(session.Engine(), domain)
 	if !check(c, http.StatusNotFound, err, "domain not found: %s", domain) {
 		return
 	}
@@ -268,7 +597,7 @@ func (a *API) ShowInConsole(c *gin.Context) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	if !check(c, http.StatusBadRequest, ConsoleOK(s.Engine, update)) {
+	if !check(c, http.StatusBadRequest, ConsoleOK(s.Engine(), update)) {
 		return
 	}
 	if !check(c, http.StatusNotFound, s.ShowInConsole(update)) {
@@ -288,7 +617,7 @@ func (a *API) SetConsole(c *gin.Context) {
 	if !check(c, http.StatusInternalServerError, err) {
 		return
 	}
-	if !check(c, http.StatusBadRequest, ConsoleOK(s.Engine, state)) {
+	if !check(c, http.StatusBadRequest, ConsoleOK(s.Engine(), state)) {
 		return
 	}
 	s.SetConsoleState(state)
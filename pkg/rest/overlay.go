@@ -0,0 +1,33 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rest
+
+import (
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/internal/pkg/yaml"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/config"
+)
+
+// ConfigOverlay converts a REST overlay to a configuration, rejecting unknown fields.
+func ConfigOverlay(o *api.Overlay) (*config.Config, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	c := &config.Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// APIOverlay converts a configuration overlay to its REST form.
+func APIOverlay(c *config.Config) (*api.Overlay, error) {
+	b, err := json.Marshal(config.Config{Rules: c.Rules, Aliases: c.Aliases, Templates: c.Templates, Stores: c.Stores})
+	if err != nil {
+		return nil, err
+	}
+	o := &api.Overlay{}
+	return o, json.Unmarshal(b, o)
+}
@@ -0,0 +1,99 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
+	"github.com/korrel8r/korrel8r/pkg/engine/timeline"
+	"github.com/korrel8r/korrel8r/pkg/graph"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r"
+	"github.com/korrel8r/korrel8r/pkg/korrel8r/impl"
+	"github.com/korrel8r/korrel8r/pkg/redact"
+	"github.com/korrel8r/korrel8r/pkg/result"
+)
+
+// redactGraph replaces the results of each node in g with redacted objects, if redaction is enabled.
+// Must be called before the graph is converted, so everything returned is derived from redacted objects.
+func redactGraph(c *gin.Context, e *engine.Engine, g *graph.Graph) {
+	r := e.Redactor()
+	if r == nil || g == nil {
+		return
+	}
+	counts := redact.Counts{}
+	g.EachNode(func(n *graph.Node) {
+		if n.Empty() {
+			return
+		}
+		redacted := result.NewList() // Keep order and duplicates, n.Clusters and n.Scores are indexed by position.
+		redacted.Append(r.Objects(n.Class, n.Result.List(), counts)...)
+		n.Result = redacted
+	})
+	setRedacted(c, counts)
+}
+
+// redactObjects returns redacted objects of class, if redaction is enabled.
+func redactObjects(c *gin.Context, e *engine.Engine, class korrel8r.Class, objects []korrel8r.Object) []korrel8r.Object {
+	r := e.Redactor()
+	if r == nil {
+		return objects
+	}
+	counts := redact.Counts{}
+	objects = r.Objects(class, objects, counts)
+	setRedacted(c, counts)
+	return objects
+}
+
+// redactTimeline redacts the object of each timeline entry, if redaction is enabled.
+// Must be called after [timeline.New], so status rules are applied to the full objects.
+func redactTimeline(c *gin.Context, e *engine.Engine, entries []timeline.Entry) {
+	r := e.Redactor()
+	if r == nil {
+		return
+	}
+	counts := redact.Counts{}
+	for i := range entries {
+		entries[i].Object = r.Object(entries[i].Class, entries[i].Object, counts)
+	}
+	setRedacted(c, counts)
+}
+
+// redactRootCauses redacts the object of each candidate, if redaction is enabled.
+// Must be called after [rootcause.Analyze], so scores are computed from the full objects.
+func redactRootCauses(c *gin.Context, e *engine.Engine, candidates []rootcause.Candidate) {
+	r := e.Redactor()
+	if r == nil {
+		return
+	}
+	counts := redact.Counts{}
+	for i := range candidates {
+		candidates[i].Object = r.Object(candidates[i].Class, candidates[i].Object, counts)
+	}
+	setRedacted(c, counts)
+}
+
+// aggregate counts the objects selected by query, grouped by fields.
+//
+// If redaction is enabled, objects are retrieved, redacted and counted in memory.
+// Counting in the backend would return unredacted field values, for example the data of a Secret.
+func aggregate(c *gin.Context, e *engine.Engine, query korrel8r.Query, constraint *korrel8r.Constraint, fields []string) ([]korrel8r.Group, error) {
+	if e.Redactor() == nil {
+		return e.Aggregate(c.Request.Context(), query, constraint, fields)
+	}
+	result := result.New(query.Class())
+	err := e.Get(c.Request.Context(), query, constraint, result)
+	return impl.Aggregate(redactObjects(c, e, query.Class(), result.List()), fields), err
+}
+
+// setRedacted reports redaction counts in the response header and the audit record.
+func setRedacted(c *gin.Context, counts redact.Counts) {
+	if len(counts) == 0 {
+		return
+	}
+	c.Header(api.HeaderRedacted, counts.String())
+	if r := auditRecord(c); r != nil {
+		r.Redacted = counts
+	}
+}
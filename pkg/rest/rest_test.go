@@ -7,11 +7,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -22,8 +27,12 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/audit"
 	"github.com/korrel8r/korrel8r/pkg/config"
+	logs "github.com/korrel8r/korrel8r/pkg/domains/log"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/engine/rank"
+	"github.com/korrel8r/korrel8r/pkg/engine/rootcause"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/ptr"
 	"github.com/korrel8r/korrel8r/pkg/session"
@@ -147,6 +156,102 @@ func TestAPIGetObjects_withConstraint(t *testing.T) {
 	require.Equal(t, `["a1"]`, w.Body.String())
 }
 
+func TestAPIGetObjects_groupBy(t *testing.T) {
+	d := mock.NewDomain("x")
+	s := mock.NewStore(d)
+	s.AddQuery("x:y:logs", []korrel8r.Object{
+		map[string]string{"level": "info", "container": "a"},
+		map[string]string{"level": "error", "container": "a"},
+		map[string]string{"level": "info", "container": "b"},
+	})
+	e, err := engine.Build().Domains(d).Stores(s).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	assertDo(t, a, "GET", "/api/v1alpha1/objects?query=x:y:logs&groupBy=level", nil, http.StatusOK, []api.GroupCount{
+		{Fields: map[string]string{"level": "info"}, Count: 2},
+		{Fields: map[string]string{"level": "error"}, Count: 1},
+	})
+	assertDo(t, a, "GET", "/api/v1alpha1/objects?query=x:y:logs&groupBy=level,+container", nil, http.StatusOK, []api.GroupCount{
+		{Fields: map[string]string{"level": "error", "container": "a"}, Count: 1},
+		{Fields: map[string]string{"level": "info", "container": "a"}, Count: 1},
+		{Fields: map[string]string{"level": "info", "container": "b"}, Count: 1},
+	})
+	assertDo(t, a, "GET", "/api/v1alpha1/objects?query=x:y:nothing&groupBy=level", nil, http.StatusOK, []api.GroupCount{})
+}
+
+func TestAPIGetObjects_project(t *testing.T) {
+	d := mock.NewDomain("x")
+	s := mock.NewStore(d)
+	s.AddQuery("x:y:pods", []korrel8r.Object{
+		map[string]any{"metadata": map[string]any{"name": "a", "managedFields": []any{"big"}}, "status": map[string]any{"phase": "Running"}},
+	})
+	e, err := engine.Build().Domains(d).Stores(s).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	w := a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:pods&project="+url.QueryEscape("metadata.name"), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"metadata":{"name":"a"}}]`, w.Body.String())
+
+	w = a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:pods&project="+url.QueryEscape("other=status;x=status.phase"), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"status":{"phase":"Running"}}]`, w.Body.String())
+
+	w = a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:pods&project="+url.QueryEscape("a[0]"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAPIGetObjects_redact(t *testing.T) {
+	d := mock.NewDomain("x")
+	s := mock.NewStore(d)
+	s.AddQuery("x:y:logs", []korrel8r.Object{
+		map[string]any{"body": "sent to bob@example.com", "user": "alice@example.com"},
+		map[string]any{"body": "ok", "user": "alice@example.com"},
+	})
+	e, err := engine.Build().Domains(d).Stores(s).Tuning(&config.Tuning{Redaction: &config.Redaction{}}).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	w := a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:logs&project=body", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"body":"sent to [REDACTED]"},{"body":"ok"}]`, w.Body.String())
+	assert.Equal(t, "email=3", w.Header().Get(api.HeaderRedacted))
+
+	w = a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:logs&groupBy=user", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"fields":{"user":"[REDACTED]"},"count":2}]`, w.Body.String())
+	assert.Equal(t, "email=3", w.Header().Get(api.HeaderRedacted))
+}
+
+func TestAPIGetObjects_redactSecretGroups(t *testing.T) {
+	d := mock.NewDomain("x")
+	s := mock.NewStore(d)
+	secret := func(password string) korrel8r.Object {
+		return map[string]any{
+			"apiVersion": "v1", "kind": "Secret",
+			"metadata": map[string]any{"annotations": map[string]any{
+				"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"` + password + `"}}`,
+			}},
+			"data": map[string]any{"password": password},
+		}
+	}
+	s.AddQuery("x:y:secrets", []korrel8r.Object{secret("c2VjcmV0"), secret("b3RoZXI=")})
+	e, err := engine.Build().Domains(d).Stores(s).Tuning(&config.Tuning{Redaction: &config.Redaction{}}).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	for _, field := range []string{"data.password", "metadata.annotations.kubectl.kubernetes.io/last-applied-configuration"} {
+		t.Run(field, func(t *testing.T) {
+			w := a.do(t, "GET", "/api/v1alpha1/objects?query=x:y:secrets&groupBy="+url.QueryEscape(field), nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `[{"fields":{"`+field+`":"[REDACTED]"},"count":2}]`, w.Body.String())
+			assert.NotContains(t, w.Body.String(), "c2VjcmV0")
+			assert.Equal(t, "secret-data=4", w.Header().Get(api.HeaderRedacted))
+		})
+	}
+}
+
 func ginEngine() *gin.Engine {
 	if os.Getenv(gin.EnvGinMode) == "" { // Don't override an explicit env setting.
 		gin.SetMode(gin.TestMode)
@@ -162,7 +267,7 @@ type testAPI struct {
 
 func newTestAPI(t *testing.T, e *engine.Engine) *testAPI {
 	r := ginEngine()
-	a, err := New(session.NewSingleManager(e), r)
+	a, err := New(session.NewSingleManager(e, nil), r)
 	require.NoError(t, err)
 	return &testAPI{API: a, Router: r}
 }
@@ -271,6 +376,46 @@ func TestAPIGraphGoals_labels(t *testing.T) {
 		})
 }
 
+func TestAPIGraphGoals_rank(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	// The error is after the limit, it is found because objects are ranked before the limit is applied.
+	s.AddQuery("mock:b:y", []korrel8r.Object{"ok", "fine", "boom"})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "boom",
+			Start:  config.ClassSpec{Domain: "mock", Classes: []string{"b"}},
+			Status: `{{if eq . "boom"}}Error{{end}}`,
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	goals := api.Goals{
+		Start: api.Start{
+			Class:      "mock:a",
+			Objects:    []json.RawMessage{[]byte(`"x"`)},
+			Constraint: &api.Constraint{Limit: new(2)},
+		},
+		Goals: []string{"mock:b"},
+	}
+	ta := newTestAPI(t, e)
+	assertDo(t, ta, "POST", "/api/v1alpha1/graphs/goals?results=true&order=rank", goals, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Result: []api.Object{[]byte(`"x"`)}, ResultScores: []float64{0}},
+				{
+					Class:        "mock:b",
+					Count:        ptr.To(2),
+					Queries:      []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2), Statuses: []api.StatusCount{{Status: "Error", Count: ptr.To(1)}}}},
+					Result:       []api.Object{[]byte(`"boom"`), []byte(`"ok"`)},
+					ResultScores: []float64{rank.StatusWeight, 0},
+				}},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+}
+
 func TestAPIGraphNeighbors(t *testing.T) {
 	e := testEngine(t)
 	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors",
@@ -288,6 +433,406 @@ func TestAPIGraphNeighbors(t *testing.T) {
 		})
 }
 
+func TestAPIGraphNeighbors_patterns(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	line := func(body string, at time.Time) korrel8r.Object {
+		return logs.Object{logs.AttrBody: body, logs.AttrTimestamp: at.Format(time.RFC3339Nano)}
+	}
+	query := `log:application:{kubernetes_namespace_name="demo"}`
+	s := mock.NewStore(logs.Domain)
+	s.AddQuery(query, []korrel8r.Object{
+		line("request took 12ms", t0),
+		line("connection reset", t0.Add(time.Minute)),
+		line("request took 40ms", t0.Add(2*time.Minute)),
+	})
+	d := mock.NewDomain("mock", "a")
+	a := d.Class("a")
+	ms := mock.NewStore(d)
+	ms.AddQuery("mock:a:y", []korrel8r.Object{"a"})
+	e, err := engine.Build().Domains(logs.Domain, d).Stores(s, ms).Rules(
+		mock.NewRule("log-a", list[korrel8r.Class](logs.Application), list(a), mock.NewQuery(a, "y")),
+	).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	neighbors := api.Neighbors{Start: api.Start{Queries: []string{query}}, Depth: 1}
+	node := api.Node{Class: "log:application", Count: ptr.To(3), Queries: []api.QueryCount{{Query: query, Count: ptr.To(3)}}}
+	want := func(node api.Node) api.Graph {
+		return api.Graph{
+			Nodes: []api.Node{node, {Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:y", Count: ptr.To(1)}}}},
+			Edges: []api.Edge{{Start: "log:application", Goal: "mock:a"}},
+		}
+	}
+	assertDo(t, ta, "POST", "/api/v1alpha1/graphs/neighbors", neighbors, http.StatusOK, want(node))
+	node.Patterns = []api.LogPattern{
+		{Template: "request took <*>", Count: 2, First: ptr.To(t0), Last: ptr.To(t0.Add(2 * time.Minute)), Example: "request took 12ms"},
+		{Template: "connection reset", Count: 1, First: ptr.To(t0.Add(time.Minute)), Last: ptr.To(t0.Add(time.Minute)), Example: "connection reset"},
+	}
+	assertDo(t, ta, "POST", "/api/v1alpha1/graphs/neighbors?patterns=true", neighbors, http.StatusOK, want(node))
+}
+
+func TestAPIGraphNeighbors_groupBy(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{map[string]string{"status": "ok"}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{map[string]string{"status": "ok"}, map[string]string{"status": "failed"}, map[string]string{}})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	neighbors := api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}
+	assertDo(t, ta, "POST", "/api/v1alpha1/graphs/neighbors?groupBy=status", neighbors, http.StatusOK, api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}},
+				Groups: []api.GroupCount{{Fields: map[string]string{"status": "ok"}, Count: 1}}},
+			{Class: "mock:b", Count: ptr.To(3), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(3)}},
+				Groups: []api.GroupCount{
+					{Fields: map[string]string{"status": ""}, Count: 1},
+					{Fields: map[string]string{"status": "failed"}, Count: 1},
+					{Fields: map[string]string{"status": "ok"}, Count: 1},
+				}},
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	})
+}
+
+func TestAPIGraphNeighbors_project(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{map[string]any{"name": "a", "spec": "big"}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{map[string]any{"name": "b", "spec": "big"}})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	neighbors := api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}
+	assertDo(t, ta, "POST", "/api/v1alpha1/graphs/neighbors?results=true&project="+url.QueryEscape("mock:b=name"), neighbors, http.StatusOK, api.Graph{
+		Nodes: []api.Node{
+			{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}},
+				Result: []api.Object{json.RawMessage(`{"name":"a","spec":"big"}`)}},
+			{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}},
+				Result: []api.Object{json.RawMessage(`{"name":"b"}`)}},
+		},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	})
+	w := ta.do(t, "POST", "/api/v1alpha1/graphs/neighbors?project="+url.QueryEscape("..."), neighbors)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAPIGraphNeighbors_redact(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{map[string]any{"name": "a"}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{map[string]any{"apiVersion": "v1", "kind": "Secret", "data": map[string]any{"key": "c2VjcmV0"}}})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).
+		Tuning(&config.Tuning{Redaction: &config.Redaction{}}).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	neighbors := api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}
+	w := ta.do(t, "POST", "/api/v1alpha1/graphs/neighbors?results=true", neighbors)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "secret-data=1", w.Header().Get(api.HeaderRedacted))
+	var g api.Graph
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	i := slices.IndexFunc(g.Nodes, func(n api.Node) bool { return n.Class == "mock:b" })
+	require.GreaterOrEqual(t, i, 0)
+	assert.JSONEq(t, `{"apiVersion":"v1","kind":"Secret","data":{"key":"[REDACTED]"}}`, string(g.Nodes[i].Result[0]))
+}
+
+func TestAPIGraphNeighbors_redactClusters(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	dir := t.TempDir()
+	var stores []config.Store
+	for _, cluster := range []string{"east", "west"} {
+		// Same object, so the same ID, in both clusters.
+		file := filepath.Join(dir, cluster+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`"mock:b:y": ["mail bob@example.org"]`), 0o644))
+		stores = append(stores, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyCluster: cluster, config.StoreKeyMock: file})
+	}
+	e, err := engine.Build().Domains(d).StoreConfigs(stores...).
+		Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).
+		Tuning(&config.Tuning{Redaction: &config.Redaction{}}).Engine()
+	require.NoError(t, err)
+	start := api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}}
+	w := newTestAPI(t, e).do(t, "POST", "/api/v1alpha1/graphs/neighbors?results=true&order=rank", api.Neighbors{Start: start, Depth: 1})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "email=2", w.Header().Get(api.HeaderRedacted))
+	var g api.Graph
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	i := slices.IndexFunc(g.Nodes, func(n api.Node) bool { return n.Class == "mock:b" })
+	require.GreaterOrEqual(t, i, 0)
+	n := g.Nodes[i]
+	assert.Equal(t, []api.Object{[]byte(`"mail [REDACTED]"`), []byte(`"mail [REDACTED]"`)}, n.Result)
+	assert.Equal(t, []string{"east", "west"}, n.ResultClusters)
+	assert.Len(t, n.ResultScores, 2, "node is ranked")
+	assert.Equal(t, []api.ClusterCount{{Cluster: "east", Count: 1}, {Cluster: "west", Count: 1}}, n.Clusters)
+}
+
+func TestAPIGraphNeighbors_clusters(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	dir := t.TempDir()
+	var stores []config.Store
+	for _, cluster := range []string{"east", "west"} {
+		file := filepath.Join(dir, cluster+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`"mock:b:y": ["`+cluster+`"]`), 0o644))
+		stores = append(stores, config.Store{config.StoreKeyDomain: "mock", config.StoreKeyCluster: cluster, config.StoreKeyMock: file})
+	}
+	e, err := engine.Build().Domains(d).StoreConfigs(stores...).
+		Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))).Engine()
+	require.NoError(t, err)
+	start := api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors?results=true",
+		api.Neighbors{Start: start, Depth: 1},
+		http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Result: []api.Object{[]byte(`"x"`)}},
+				{
+					Class:          "mock:b",
+					Count:          ptr.To(2),
+					Queries:        []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}},
+					Result:         []api.Object{[]byte(`"east"`), []byte(`"west"`)},
+					ResultClusters: []string{"east", "west"},
+					Clusters:       []api.ClusterCount{{Cluster: "east", Count: 1}, {Cluster: "west", Count: 1}},
+				},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+	start.Constraint = &api.Constraint{Cluster: "west"}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors",
+		api.Neighbors{Start: start, Depth: 1},
+		http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				// Start objects belong to the constraint cluster.
+				{Class: "mock:a", Count: ptr.To(1), Clusters: []api.ClusterCount{{Cluster: "west", Count: 1}}},
+				{
+					Class:    "mock:b",
+					Count:    ptr.To(1),
+					Queries:  []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}},
+					Clusters: []api.ClusterCount{{Cluster: "west", Count: 1}},
+				},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+}
+
+func TestAPIGraphNeighbors_remote(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	rule := mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y"))
+	dir := t.TempDir()
+	store := func(cluster string) config.Store {
+		file := filepath.Join(dir, cluster+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`"mock:b:y": ["`+cluster+`"]`), 0o644))
+		return config.Store{config.StoreKeyDomain: "mock", config.StoreKeyCluster: cluster, config.StoreKeyMock: file}
+	}
+	// Spoke korrel8r serving its own untagged local store.
+	spokeStore := store("spoke")
+	delete(spokeStore, config.StoreKeyCluster)
+	spoke, err := engine.Build().Domains(d).StoreConfigs(spokeStore).Rules(rule).Engine()
+	require.NoError(t, err)
+	var searches atomic.Int32
+	spokeAPI := newTestAPI(t, spoke)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/graphs/") {
+			searches.Add(1)
+		}
+		spokeAPI.Router.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	// Hub korrel8r with a local store and a remote store for the spoke.
+	hub, err := engine.Build().Domains(d).Rules(rule).StoreConfigs(
+		store("hub"),
+		config.Store{config.StoreKeyDomain: "mock", config.StoreKeyCluster: "spoke", config.StoreKeyRemote: srv.URL},
+	).Engine()
+	require.NoError(t, err)
+	start := api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}}
+
+	// Without a cluster, the hub traverses locally and calls the remote store.
+	assertDo(t, newTestAPI(t, hub), "POST", "/api/v1alpha1/graphs/neighbors?results=true",
+		api.Neighbors{Start: start, Depth: 1},
+		http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Result: []api.Object{[]byte(`"x"`)}},
+				{
+					Class:          "mock:b",
+					Count:          ptr.To(2),
+					Queries:        []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(2)}},
+					Result:         []api.Object{[]byte(`"hub"`), []byte(`"spoke"`)},
+					ResultClusters: []string{"hub", "spoke"},
+					Clusters:       []api.ClusterCount{{Cluster: "hub", Count: 1}, {Cluster: "spoke", Count: 1}},
+				},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+	assert.Equal(t, int32(0), searches.Load())
+
+	// Restricted to the spoke cluster, the whole search is forwarded.
+	start.Constraint = &api.Constraint{Cluster: "spoke"}
+	assertDo(t, newTestAPI(t, hub), "POST", "/api/v1alpha1/graphs/neighbors?results=true",
+		api.Neighbors{Start: start, Depth: 1},
+		http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{
+					Class: "mock:a", Count: ptr.To(1), Result: []api.Object{[]byte(`"x"`)},
+					ResultClusters: []string{"spoke"}, Clusters: []api.ClusterCount{{Cluster: "spoke", Count: 1}},
+				},
+				{
+					Class:          "mock:b",
+					Count:          ptr.To(1),
+					Queries:        []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}},
+					Result:         []api.Object{[]byte(`"spoke"`)},
+					ResultClusters: []string{"spoke"},
+					Clusters:       []api.ClusterCount{{Cluster: "spoke", Count: 1}},
+				},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+		})
+	assert.Equal(t, int32(1), searches.Load())
+}
+
+func TestAPIGraphNeighbors_errors(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	e, err := engine.Build().Domains(d).Stores(mock.NewStore(d)).
+		Rules(mock.NewRule("a-b", list(a), list(b), mock.NewQueryError(b, "y", errors.New("boom")))).Engine()
+	require.NoError(t, err)
+	neighbors := api.Neighbors{Start: api.Start{Class: "mock:a", Objects: []json.RawMessage{[]byte(`"x"`)}}, Depth: 1}
+	want := api.Graph{Nodes: []api.Node{{Class: "mock:a", Count: ptr.To(1)}}}
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors", neighbors, http.StatusOK, want)
+	// Store errors are not reported to the caller, only objects dropped by authorization.
+	assertDo(t, newTestAPI(t, e), "POST", "/api/v1alpha1/graphs/neighbors?errors=true", neighbors, http.StatusOK, want)
+}
+
+// newAuditAPI returns a test API with an audit log written to buf, and session middleware for sessions.
+func newAuditAPI(t *testing.T, sessions session.Manager, buf *bytes.Buffer) *testAPI {
+	l, err := audit.NewLogger(buf, nil)
+	require.NoError(t, err)
+	r := ginEngine()
+	r.Use(Audit(l), session.Middleware(sessions))
+	a, err := New(sessions, r)
+	require.NoError(t, err)
+	return &testAPI{API: a, Router: r}
+}
+
+func TestAPI_audit(t *testing.T) {
+	var buf bytes.Buffer
+	a := newAuditAPI(t, session.NewSingleManager(testEngine(t), nil), &buf)
+
+	rr := a.do(t, "POST", "/api/v1alpha1/graphs/neighbors", api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	req := httptest.NewRequest("GET", "/api/v1alpha1/objects?query=nonsense", nil)
+	a.Router.ServeHTTP(httptest.NewRecorder(), req.WithContext(api.WithMCPTool(req.Context(), "get_objects")))
+	// A client can't claim to be the MCP server with a header.
+	req = httptest.NewRequest("GET", "/api/v1alpha1/objects?query=mock:a:x", nil)
+	req.Header.Set("Korrel8r-Mcp-Tool", "forged")
+	a.Router.ServeHTTP(httptest.NewRecorder(), req)
+	rr = a.do(t, "POST", "/api/v1alpha1/resolve", api.Resolve{Text: "mock:a:x"})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	rr = a.do(t, "GET", "/api/v1alpha1/doctor?domain=mock", nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var got []audit.Record
+	for line := range strings.Lines(buf.String()) {
+		var r audit.Record
+		require.NoError(t, json.Unmarshal([]byte(line), &r))
+		assert.NotZero(t, r.Time)
+		r.Time, r.DurationMS, r.Remote = time.Time{}, 0, ""
+		got = append(got, r)
+	}
+	assert.Equal(t, []audit.Record{
+		{
+			Interface: audit.REST, Operation: "POST /graphs/neighbors",
+			Start: &audit.Start{Queries: []string{"mock:a:x"}}, Depth: ptr.To(1),
+			Results: []audit.Count{{Class: "mock:a", Count: 1}, {Class: "mock:b", Count: 1}}, Status: http.StatusOK,
+		},
+		{
+			Interface: audit.MCP, Tool: "get_objects", Operation: "GET /objects",
+			Start: &audit.Start{Queries: []string{"nonsense"}}, Status: http.StatusBadRequest, Error: got[1].Error,
+		},
+		{
+			Interface: audit.REST, Operation: "GET /objects",
+			Start: &audit.Start{Queries: []string{"mock:a:x"}}, Results: []audit.Count{{Class: "mock:a", Count: 1}}, Status: http.StatusOK,
+		},
+		{
+			Interface: audit.REST, Operation: "POST /resolve",
+			Text: "mock:a:x", Status: http.StatusOK, // Mock domain has no candidates.
+		},
+		{
+			Interface: audit.REST, Operation: "GET /doctor",
+			Domains: []string{"mock"}, Status: http.StatusOK, // Mock store has no configuration to diagnose.
+		},
+	}, got)
+	assert.NotEmpty(t, got[1].Error)
+}
+
+func TestAPI_auditDoctor(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	file := filepath.Join(t.TempDir(), "mock.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`"mock:a:x": ["x"]`), 0o644))
+	e, err := engine.Build().Domains(d).StoreConfigs(config.Store{config.StoreKeyDomain: "mock", config.StoreKeyMock: file}).Engine()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	a := newAuditAPI(t, session.NewSingleManager(e, nil), &buf)
+	rr := a.do(t, "GET", "/api/v1alpha1/doctor", nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var r audit.Record
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+	assert.Empty(t, r.Domains)
+	assert.Equal(t, map[string]int{"skipped": 1}, r.Diagnoses) // Mock stores are not probed.
+}
+
+// authFunc is a session.Authenticator function.
+type authFunc func(token string) (*auth.User, error)
+
+func (f authFunc) Authenticate(token string) (*auth.User, error) { return f(token) }
+
+func TestAPI_auditUnauthorized(t *testing.T) {
+	e := testEngine(t)
+	sessions := session.NewAuthManager(authFunc(func(token string) (*auth.User, error) {
+		if token != "good" {
+			return nil, errors.New("invalid token")
+		}
+		return &auth.User{Name: "alice"}, nil
+	}), time.Hour, func(*config.Config) (*engine.Engine, error) { return e, nil }, nil)
+	var buf bytes.Buffer
+	a := newAuditAPI(t, sessions, &buf)
+	a.Router.GET("/other", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	req := httptest.NewRequest("GET", "/api/v1alpha1/domains", nil)
+	req.Header.Set("Authorization", "Bearer bad")
+	rr := httptest.NewRecorder()
+	a.Router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+	req = httptest.NewRequest("GET", "/other", nil)
+	req.Header.Set("Authorization", "Bearer bad")
+	a.Router.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest("GET", "/other", nil)
+	req.Header.Set("Authorization", "Bearer good")
+	a.Router.ServeHTTP(httptest.NewRecorder(), req) // Not a REST API request, not audited.
+	req = httptest.NewRequest("GET", "/api/v1alpha1/domains", nil)
+	req.Header.Set("Authorization", "Bearer good")
+	a.Router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var got []audit.Record
+	for line := range strings.Lines(buf.String()) {
+		var r audit.Record
+		require.NoError(t, json.Unmarshal([]byte(line), &r))
+		r.Time, r.DurationMS, r.Remote, r.Error = time.Time{}, 0, "", ""
+		got = append(got, r)
+	}
+	assert.Equal(t, []audit.Record{
+		{Interface: audit.REST, Operation: "GET /domains", Status: http.StatusUnauthorized},
+		{Interface: audit.REST, Operation: "GET /other", Status: http.StatusUnauthorized},
+		{Interface: audit.REST, Operation: "GET /domains", Status: http.StatusOK, User: "alice", Session: "alice"},
+	}, got)
+}
+
 func TestAPIGraphNeighbors_badRequest(t *testing.T) {
 	a := newTestAPI(t, testEngine(t))
 	w := a.do(t, "POST", "/api/v1alpha1/graphs/neighbors", `not json`)
@@ -298,14 +843,14 @@ func TestMultiSession_QueryIsolation(t *testing.T) {
 	// Each session gets a separate engine with different store data.
 	// Verify that REST requests with different auth tokens get different results.
 	var callCount atomic.Int32
-	factory := func() (*engine.Engine, error) {
+	factory := func(*config.Config) (*engine.Engine, error) {
 		n := callCount.Add(1)
 		d := mock.NewDomain("mock", "a")
 		s := mock.NewStore(d)
 		s.AddQuery("mock:a:q", fmt.Sprintf("result-%d", n))
 		return engine.Build().Domains(d).Stores(s).Engine()
 	}
-	sessions := session.NewTokenReviewManager(test.FakeTokenReview(), time.Hour, factory)
+	sessions := session.NewTokenReviewManager(test.FakeTokenReview(), time.Hour, factory, nil)
 
 	r := ginEngine()
 	r.Use(func(c *gin.Context) {
@@ -391,6 +936,270 @@ func TestAPI_GetConsole_noConsole(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestAPI_Overlay(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", "ax")
+	s.AddQuery("mock:b:y", "by")
+	factory := func(overlay *config.Config) (*engine.Engine, error) {
+		configs, err := config.Configs{}.WithOverlay(overlay)
+		if err != nil {
+			return nil, err
+		}
+		return engine.Build().Domains(d).Stores(s).Config(configs).Rules(
+			mock.NewRule("b-a", list(d.Class("b")), list(d.Class("a")), mock.NewQuery(d.Class("a"), "x")),
+		).Engine()
+	}
+	e, err := factory(nil)
+	require.NoError(t, err)
+	r := ginEngine()
+	_, err = New(session.NewSingleManager(e, factory), r)
+	require.NoError(t, err)
+	a := &testAPI{Router: r}
+
+	neighbors := api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}
+	nodeA := api.Node{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}}
+	assertDo(t, a, "POST", "/api/v1alpha1/graphs/neighbors", neighbors, http.StatusOK, api.Graph{Nodes: []api.Node{nodeA}})
+	assert.Equal(t, http.StatusNotFound, a.do(t, "GET", "/api/v1alpha1/config/overlay", nil).Code)
+
+	overlay := api.Overlay{Rules: []api.Object{json.RawMessage(
+		`{"name":"a-b","start":{"domain":"mock","classes":["a"]},"goal":{"domain":"mock","classes":["b"]},"result":{"query":"mock:b:y"}}`)}}
+	assertDo(t, a, "PUT", "/api/v1alpha1/config/overlay", overlay, http.StatusOK, map[string]any{})
+	assertDo(t, a, "GET", "/api/v1alpha1/config/overlay", nil, http.StatusOK, overlay)
+	assertDo(t, a, "POST", "/api/v1alpha1/graphs/neighbors", neighbors, http.StatusOK, api.Graph{
+		Nodes: []api.Node{nodeA, {Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}}},
+		Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b"}},
+	})
+
+	// Invalid overlay leaves the session unchanged.
+	bad := api.Overlay{Rules: []api.Object{json.RawMessage(`{"name":"bad","start":{"domain":"nosuch"}}`)}}
+	assert.Equal(t, http.StatusBadRequest, a.do(t, "PUT", "/api/v1alpha1/config/overlay", bad).Code)
+	assertDo(t, a, "GET", "/api/v1alpha1/config/overlay", nil, http.StatusOK, overlay)
+
+	// Overlay stores can't choose other credentials.
+	for _, s := range []api.Store{
+		{config.StoreKeyDomain: "mock", config.StoreKeyContext: "admin"},
+		{config.StoreKeyDomain: "mock", config.StoreKeyAuth: config.StoreAuthImpersonate},
+	} {
+		w := a.do(t, "PUT", "/api/v1alpha1/config/overlay", api.Overlay{Stores: []api.Store{s}})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v", s)
+	}
+	assertDo(t, a, "GET", "/api/v1alpha1/config/overlay", nil, http.StatusOK, overlay)
+
+	assertDo(t, a, "DELETE", "/api/v1alpha1/config/overlay", nil, http.StatusOK, map[string]any{})
+	assert.Equal(t, http.StatusNotFound, a.do(t, "GET", "/api/v1alpha1/config/overlay", nil).Code)
+	assertDo(t, a, "POST", "/api/v1alpha1/graphs/neighbors", neighbors, http.StatusOK, api.Graph{Nodes: []api.Node{nodeA}})
+}
+
+func TestAPI_Searches(t *testing.T) {
+	d := mock.NewDomain("mock", "a")
+	e, err := engine.Build().Domains(d).Stores(mock.NewStore(d)).Engine()
+	require.NoError(t, err)
+	a := newTestAPI(t, e)
+
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{})
+	search := api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 1}}
+	assertDo(t, a, "PUT", "/api/v1alpha1/searches/x", search, http.StatusOK, map[string]any{})
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{"x": search})
+
+	bad := api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"nosuch:a:x"}}, Depth: 1}}
+	assert.Equal(t, http.StatusBadRequest, a.do(t, "PUT", "/api/v1alpha1/searches/y", bad).Code)
+	assert.Equal(t, http.StatusBadRequest, a.do(t, "PUT", "/api/v1alpha1/searches/y", api.Search{}).Code)
+
+	assertDo(t, a, "DELETE", "/api/v1alpha1/searches/x", nil, http.StatusOK, map[string]any{})
+	assert.Equal(t, http.StatusNotFound, a.do(t, "DELETE", "/api/v1alpha1/searches/x", nil).Code)
+	assertDo(t, a, "GET", "/api/v1alpha1/searches", nil, http.StatusOK, api.SavedSearches{})
+}
+
+func TestAPI_Recipes(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", "ax")
+	s.AddQuery("mock:b:y", "by")
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Config(config.Configs{{
+		Recipes: []config.Recipe{{
+			Name:    "a-to-b",
+			Params:  []config.RecipeParam{{Name: "a", Description: "Selector for a"}},
+			Start:   config.RecipeStart{Queries: []string{"mock:a:{{.a}}"}},
+			Goals:   []string{"mock:b"},
+			Options: &config.RecipeOptions{Rules: true},
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	assertDo(t, ta, "GET", "/api/v1alpha1/recipes", nil, http.StatusOK,
+		[]api.Recipe{{Name: "a-to-b", Params: []api.RecipeParam{{Name: "a", Description: "Selector for a"}}, Goals: []string{"mock:b"}}})
+	assertDo(t, ta, "POST", "/api/v1alpha1/recipes/a-to-b", api.RecipeRun{Params: map[string]string{"a": "x"}}, http.StatusOK,
+		api.Graph{
+			Nodes: []api.Node{
+				{Class: "mock:a", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:a:x", Count: ptr.To(1)}}},
+				{Class: "mock:b", Count: ptr.To(1), Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}},
+			},
+			Edges: []api.Edge{{Start: "mock:a", Goal: "mock:b", Rules: []api.Rule{{Name: "a-b", Queries: []api.QueryCount{{Query: "mock:b:y", Count: ptr.To(1)}}}}}},
+		})
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/recipes/a-to-b", api.RecipeRun{}).Code)
+	assert.Equal(t, http.StatusNotFound, ta.do(t, "POST", "/api/v1alpha1/recipes/nonesuch", nil).Code)
+}
+
+func TestAPI_Recipes_rank(t *testing.T) {
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", "ax")
+	// The error is after the recipe limit, it is found because objects are ranked before the limit is applied.
+	s.AddQuery("mock:b:y", []korrel8r.Object{"ok", "fine", "boom"})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Config(config.Configs{{
+		StatusRules: []config.StatusRule{{
+			Name:   "boom",
+			Start:  config.ClassSpec{Domain: "mock", Classes: []string{"b"}},
+			Status: `{{if eq . "boom"}}Error{{end}}`,
+		}},
+		Recipes: []config.Recipe{{
+			Name:       "a-to-b",
+			Start:      config.RecipeStart{Queries: []string{"mock:a:x"}},
+			Goals:      []string{"mock:b"},
+			Constraint: &config.RecipeConstraint{Limit: 1},
+		}},
+	}}).Engine()
+	require.NoError(t, err)
+	w := newTestAPI(t, e).do(t, "POST", "/api/v1alpha1/recipes/a-to-b?results=true&order=rank", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var g api.Graph
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	i := slices.IndexFunc(g.Nodes, func(n api.Node) bool { return n.Class == "mock:b" })
+	require.GreaterOrEqual(t, i, 0)
+	assert.Equal(t, []api.Object{[]byte(`"boom"`)}, g.Nodes[i].Result)
+}
+
+func TestAPI_Resolve(t *testing.T) {
+	ta := newTestAPI(t, testEngine(t))
+	// The mock domain has no resolvable entities, candidates for other domains are dropped.
+	assertDo(t, ta, "POST", "/api/v1alpha1/resolve", api.Resolve{Text: "pod shop/cart-0 alertname=Foo"}, http.StatusOK, api.Candidates{})
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/resolve", `not json`).Code)
+}
+
+func TestAPI_Doctor(t *testing.T) {
+	dir := t.TempDir()
+	e, err := engine.Build().Domains(mock.NewDomain("foo"), mock.NewDomain("bar")).
+		StoreConfigs(config.Store{"domain": "foo", "mockData": dir}).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	store := api.Store{"domain": "foo", "mockData": dir}
+	assertDo(t, ta, "GET", "/api/v1alpha1/doctor", nil, http.StatusOK, api.Diagnoses{{
+		Domain: "foo", Store: store, Expanded: &store, Level: api.LevelSkipped,
+		Findings: []api.Finding{
+			{Check: "template", Level: api.LevelOk, Message: "expanded"},
+			{Check: "create", Level: api.LevelOk, Message: "created"},
+			{Check: "probe", Level: api.LevelSkipped, Message: "no probe query for domain foo"},
+			{Check: "rbac", Level: api.LevelSkipped, Message: "mock store"},
+		},
+	}})
+	assertDo(t, ta, "GET", "/api/v1alpha1/doctor?domain=bar", nil, http.StatusOK, api.Diagnoses{})
+	assert.Equal(t, http.StatusNotFound, ta.do(t, "GET", "/api/v1alpha1/doctor?domain=nonesuch", nil).Code)
+}
+
+// event is a mock object with a time.
+type event struct {
+	Msg string
+	At  time.Time
+}
+
+func (e event) Timestamp() time.Time { return e.At }
+
+func TestAPI_Timeline(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{event{"a", t0}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{event{"b1", t0.Add(-time.Minute)}, event{"b2", t0.Add(time.Minute)}, "no time"})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	want := &api.Timeline{Entries: []api.TimelineEntry{
+		{Class: "mock:b", Time: t0.Add(-time.Minute), Rules: []string{"a-b"}},
+		{Class: "mock:a", Time: t0},
+		{Class: "mock:b", Time: t0.Add(time.Minute), Rules: []string{"a-b"}},
+	}}
+	start := api.Start{Queries: []string{"mock:a:x"}}
+	assertDo(t, ta, "POST", "/api/v1alpha1/timeline", api.TimelineSearch{Start: start}, http.StatusOK, want)
+	assertDo(t, ta, "POST", "/api/v1alpha1/timeline", api.TimelineSearch{Start: start, Goals: []string{"mock:b"}}, http.StatusOK, want)
+	assertDo(t, ta, "POST", "/api/v1alpha1/timeline", api.TimelineSearch{Start: start, Depth: new(1)}, http.StatusOK, want)
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/timeline", api.TimelineSearch{Start: start, Goals: []string{"nonesuch"}}).Code)
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/timeline", `not json`).Code)
+}
+
+// Status rules and scores use the full objects, only the returned objects are redacted.
+func TestAPI_TimelineRootCauses_redact(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{event{"a", t0}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{event{"alice@example.com", t0.Add(-time.Minute)}})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Config(config.Configs{{StatusRules: []config.StatusRule{
+		{Name: "email", Start: config.ClassSpec{Domain: "mock"}, Status: `{{if contains "@" .Msg}}email{{end}}`},
+	}}}).Tuning(&config.Tuning{Redaction: &config.Redaction{}}).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	start := api.Start{Queries: []string{"mock:a:x"}}
+
+	w := ta.do(t, "POST", "/api/v1alpha1/timeline", api.TimelineSearch{Start: start})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "email=1", w.Header().Get(api.HeaderRedacted))
+	var tl api.Timeline
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tl))
+	require.Len(t, tl.Entries, 2)
+	assert.Equal(t, []string{"email"}, tl.Entries[0].Statuses)
+
+	w = ta.do(t, "POST", "/api/v1alpha1/rootcauses", api.RootCauseSearch{Start: start})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "email=1", w.Header().Get(api.HeaderRedacted))
+	var rc api.RootCauses
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rc))
+	require.Len(t, rc.Candidates, 1)
+	assert.Equal(t, []string{"email"}, rc.Candidates[0].Statuses)
+}
+
+func TestAPI_RootCauses(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := mock.NewDomain("mock", "a", "b")
+	a, b := d.Class("a"), d.Class("b")
+	s := mock.NewStore(d)
+	s.AddQuery("mock:a:x", []korrel8r.Object{event{"a", t0}})
+	s.AddQuery("mock:b:y", []korrel8r.Object{event{"b1", t0.Add(-time.Minute)}, event{"b2", t0.Add(time.Minute)}, "no time"})
+	e, err := engine.Build().Domains(d).Stores(s).Rules(
+		mock.NewRule("a-b", list(a), list(b), mock.NewQuery(b, "y")),
+	).Engine()
+	require.NoError(t, err)
+	ta := newTestAPI(t, e)
+	start := api.Start{Queries: []string{"mock:a:x"}}
+	w := ta.do(t, "POST", "/api/v1alpha1/rootcauses", api.RootCauseSearch{Start: start})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got api.RootCauses
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Len(t, got.Candidates, 1, "only b1 is before the start")
+	rc := got.Candidates[0]
+	assert.Equal(t, "mock:b", rc.Class)
+	assert.Equal(t, []string{"a-b"}, rc.Rules)
+	assert.Equal(t, []string{"1m0s before start"}, rc.Reasons)
+	assert.True(t, t0.Add(-time.Minute).Equal(*rc.Time))
+	assert.InDelta(t, rootcause.PrecedenceWeight*math.Exp2(-1.0/15)+rootcause.DistanceWeight, rc.Score, 1e-9)
+
+	assertDo(t, ta, "POST", "/api/v1alpha1/rootcauses", api.RootCauseSearch{Start: start, Depth: new(1), Limit: new(1)}, http.StatusOK, &got)
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/rootcauses", api.RootCauseSearch{Start: api.Start{Queries: []string{"nonesuch"}}}).Code)
+	assert.Equal(t, http.StatusBadRequest, ta.do(t, "POST", "/api/v1alpha1/rootcauses", `not json`).Code)
+}
+
 func TestAPI_ShowInConsole(t *testing.T) {
 	d := mock.NewDomain("mock", "a")
 	e, err := engine.Build().Domains(d).Stores(mock.NewStore(d)).Engine()
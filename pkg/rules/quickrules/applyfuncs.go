@@ -11,6 +11,7 @@ import (
 
 // applyFuncs maps rule names to the generated quicktemplate stream functions that render them.
 var applyFuncs = map[string]func(qw *quicktemplate.Writer, start korrel8r.Object){
+	"AlertToChange":               StreamAlertToChange,
 	"AlertToDaemonSet":            StreamAlertToDaemonSet,
 	"AlertToDeployment":           StreamAlertToDeployment,
 	"AlertToIncident":             StreamAlertToIncident,
@@ -23,6 +24,7 @@ var applyFuncs = map[string]func(qw *quicktemplate.Writer, start korrel8r.Object
 	"AlertToVmim":                 StreamAlertToVmim,
 	"AllToEvent":                  StreamAllToEvent,
 	"AllToMetric":                 StreamAllToMetric,
+	"ChangeToResource":            StreamChangeToResource,
 	"ClusterInstanceToOperands":   StreamClusterInstanceToOperands,
 	"CRDToInstances":              StreamCRDToInstances,
 	"CSVToCRD":                    StreamCSVToCRD,
@@ -115,4 +117,5 @@ var applyFuncs = map[string]func(qw *quicktemplate.Writer, start korrel8r.Object
 	"VmToVmi":                     StreamVmToVmi,
 	"VmToVmRestore":               StreamVmToVmRestore,
 	"VmToVmSnapshot":              StreamVmToVmSnapshot,
+	"WorkloadToChange":            StreamWorkloadToChange,
 }
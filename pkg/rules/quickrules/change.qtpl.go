@@ -0,0 +1,272 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+// Code generated by qtc from "change.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// # Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+// #
+
+//line change.qtpl:3
+package quickrules
+
+//line change.qtpl:5
+import "github.com/korrel8r/korrel8r/pkg/domains/alert"
+
+//line change.qtpl:6
+import "github.com/korrel8r/korrel8r/pkg/domains/change"
+
+//line change.qtpl:7
+import "github.com/korrel8r/korrel8r/pkg/domains/k8s"
+
+//line change.qtpl:8
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// # WorkloadToChange finds recent rollouts and config changes that affect a workload.
+// name: WorkloadToChange
+// start:
+//   domain: k8s
+//   classes: [Deployment.apps, StatefulSet.apps, DaemonSet.apps]
+// goal:
+//   domain: change
+//
+
+//line change.qtpl:18
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line change.qtpl:18
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line change.qtpl:18
+func StreamWorkloadToChange(qw422016 *qt422016.Writer, o interface{}) {
+//line change.qtpl:18
+	qw422016.N().S(`
+`)
+//line change.qtpl:19
+	_, _, ns, name, kind := k8sMetadata(o)
+	RequireAll(ns, name, kind)
+
+//line change.qtpl:19
+	qw422016.N().S(`
+change:change:{"namespace":`)
+//line change.qtpl:20
+	qw422016.N().Q(ns)
+//line change.qtpl:20
+	qw422016.N().S(`,"kind":`)
+//line change.qtpl:20
+	qw422016.N().Q(kind)
+//line change.qtpl:20
+	qw422016.N().S(`,"name":`)
+//line change.qtpl:20
+	qw422016.N().Q(name)
+//line change.qtpl:20
+	qw422016.N().S(`}
+`)
+//line change.qtpl:21
+}
+
+//line change.qtpl:21
+func WriteWorkloadToChange(qq422016 qtio422016.Writer, o interface{}) {
+//line change.qtpl:21
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line change.qtpl:21
+	StreamWorkloadToChange(qw422016, o)
+//line change.qtpl:21
+	qt422016.ReleaseWriter(qw422016)
+//line change.qtpl:21
+}
+
+//line change.qtpl:21
+func WorkloadToChange(o interface{}) string {
+//line change.qtpl:21
+	qb422016 := qt422016.AcquireByteBuffer()
+//line change.qtpl:21
+	WriteWorkloadToChange(qb422016, o)
+//line change.qtpl:21
+	qs422016 := string(qb422016.B)
+//line change.qtpl:21
+	qt422016.ReleaseByteBuffer(qb422016)
+//line change.qtpl:21
+	return qs422016
+//line change.qtpl:21
+}
+
+// # AlertToChange finds recent changes to the workload named by alert labels,
+// # or to the alert namespace if there is no workload label.
+// name: AlertToChange
+// start:
+//   domain: alert
+//   classes: [alert]
+// goal:
+//   domain: change
+//
+
+//line change.qtpl:32
+func StreamAlertToChange(qw422016 *qt422016.Writer, o interface{}) {
+//line change.qtpl:32
+	qw422016.N().S(`
+`)
+//line change.qtpl:33
+	l := o.(*alert.Object).Labels
+	ns := Require(l["namespace"])
+
+//line change.qtpl:33
+	qw422016.N().S(`
+`)
+//line change.qtpl:34
+	switch {
+//line change.qtpl:35
+	case l["deployment"] != "":
+//line change.qtpl:35
+		qw422016.N().S(`
+change:change:{"namespace":`)
+//line change.qtpl:36
+		qw422016.N().Q(ns)
+//line change.qtpl:36
+		qw422016.N().S(`,"kind":"Deployment","name":`)
+//line change.qtpl:36
+		qw422016.N().Q(l["deployment"])
+//line change.qtpl:36
+		qw422016.N().S(`}
+`)
+//line change.qtpl:37
+	case l["statefulset"] != "":
+//line change.qtpl:37
+		qw422016.N().S(`
+change:change:{"namespace":`)
+//line change.qtpl:38
+		qw422016.N().Q(ns)
+//line change.qtpl:38
+		qw422016.N().S(`,"kind":"StatefulSet","name":`)
+//line change.qtpl:38
+		qw422016.N().Q(l["statefulset"])
+//line change.qtpl:38
+		qw422016.N().S(`}
+`)
+//line change.qtpl:39
+	case l["daemonset"] != "":
+//line change.qtpl:39
+		qw422016.N().S(`
+change:change:{"namespace":`)
+//line change.qtpl:40
+		qw422016.N().Q(ns)
+//line change.qtpl:40
+		qw422016.N().S(`,"kind":"DaemonSet","name":`)
+//line change.qtpl:40
+		qw422016.N().Q(l["daemonset"])
+//line change.qtpl:40
+		qw422016.N().S(`}
+`)
+//line change.qtpl:41
+	default:
+//line change.qtpl:41
+		qw422016.N().S(`
+change:change:{"namespace":`)
+//line change.qtpl:42
+		qw422016.N().Q(ns)
+//line change.qtpl:42
+		qw422016.N().S(`}
+`)
+//line change.qtpl:43
+	}
+//line change.qtpl:43
+	qw422016.N().S(`
+`)
+//line change.qtpl:44
+}
+
+//line change.qtpl:44
+func WriteAlertToChange(qq422016 qtio422016.Writer, o interface{}) {
+//line change.qtpl:44
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line change.qtpl:44
+	StreamAlertToChange(qw422016, o)
+//line change.qtpl:44
+	qt422016.ReleaseWriter(qw422016)
+//line change.qtpl:44
+}
+
+//line change.qtpl:44
+func AlertToChange(o interface{}) string {
+//line change.qtpl:44
+	qb422016 := qt422016.AcquireByteBuffer()
+//line change.qtpl:44
+	WriteAlertToChange(qb422016, o)
+//line change.qtpl:44
+	qs422016 := string(qb422016.B)
+//line change.qtpl:44
+	qt422016.ReleaseByteBuffer(qb422016)
+//line change.qtpl:44
+	return qs422016
+//line change.qtpl:44
+}
+
+// # ChangeToResource finds the resource that changed.
+// name: ChangeToResource
+// start:
+//   domain: change
+// goal:
+//   domain: k8s
+//   classes: [Deployment.apps, StatefulSet.apps, DaemonSet.apps, ConfigMap.v1, Secret.v1]
+//
+
+//line change.qtpl:54
+func StreamChangeToResource(qw422016 *qt422016.Writer, o interface{}) {
+//line change.qtpl:54
+	qw422016.N().S(`
+`)
+//line change.qtpl:56
+	r := o.(*change.Object).Resource
+	RequireAll(r.APIVersion, r.Kind, r.Namespace, r.Name)
+	class := k8s.Class(schema.FromAPIVersionAndKind(r.APIVersion, r.Kind))
+
+//line change.qtpl:59
+	qw422016.N().S(`
+k8s:`)
+//line change.qtpl:60
+	qw422016.N().S(class.Name())
+//line change.qtpl:60
+	qw422016.N().S(`:{"namespace":`)
+//line change.qtpl:60
+	qw422016.N().Q(r.Namespace)
+//line change.qtpl:60
+	qw422016.N().S(`,"name":`)
+//line change.qtpl:60
+	qw422016.N().Q(r.Name)
+//line change.qtpl:60
+	qw422016.N().S(`}
+`)
+//line change.qtpl:61
+}
+
+//line change.qtpl:61
+func WriteChangeToResource(qq422016 qtio422016.Writer, o interface{}) {
+//line change.qtpl:61
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line change.qtpl:61
+	StreamChangeToResource(qw422016, o)
+//line change.qtpl:61
+	qt422016.ReleaseWriter(qw422016)
+//line change.qtpl:61
+}
+
+//line change.qtpl:61
+func ChangeToResource(o interface{}) string {
+//line change.qtpl:61
+	qb422016 := qt422016.AcquireByteBuffer()
+//line change.qtpl:61
+	WriteChangeToResource(qb422016, o)
+//line change.qtpl:61
+	qs422016 := string(qb422016.B)
+//line change.qtpl:61
+	qt422016.ReleaseByteBuffer(qb422016)
+//line change.qtpl:61
+	return qs422016
+//line change.qtpl:61
+}
@@ -0,0 +1,47 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package quickrules_test
+
+import (
+	"testing"
+
+	"github.com/korrel8r/korrel8r/pkg/domains/alert"
+	"github.com/korrel8r/korrel8r/pkg/domains/change"
+)
+
+func TestChange(t *testing.T) {
+	for _, x := range []ruleTest{
+		{
+			rule:  "WorkloadToChange",
+			start: newK8s("Deployment.apps", "ns", "web", nil),
+			want:  []string{`change:change:{"namespace":"ns","kind":"Deployment","name":"web"}`},
+		},
+		{
+			rule:  "WorkloadToChange",
+			start: newK8s("DaemonSet.apps", "ns", "agent", nil),
+			want:  []string{`change:change:{"namespace":"ns","kind":"DaemonSet","name":"agent"}`},
+		},
+		{
+			rule:  "AlertToChange",
+			start: &alert.Object{Labels: map[string]string{"namespace": "ns", "statefulset": "db"}},
+			want:  []string{`change:change:{"namespace":"ns","kind":"StatefulSet","name":"db"}`},
+		},
+		{
+			rule:  "AlertToChange",
+			start: &alert.Object{Labels: map[string]string{"namespace": "ns", "pod": "web-1"}},
+			want:  []string{`change:change:{"namespace":"ns"}`},
+		},
+		{
+			rule:  "ChangeToResource",
+			start: &change.Object{Type: change.Rollout, Resource: change.Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "ns", Name: "web"}},
+			want:  []string{`k8s:Deployment.v1.apps:{"namespace":"ns","name":"web"}`},
+		},
+		{
+			rule:  "ChangeToResource",
+			start: &change.Object{Type: change.Config, Resource: change.Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns", Name: "web-config"}},
+			want:  []string{`k8s:ConfigMap.v1:{"namespace":"ns","name":"web-config"}`},
+		},
+	} {
+		x.Run(t)
+	}
+}
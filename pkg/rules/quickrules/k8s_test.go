@@ -543,6 +543,27 @@ func TestK8sStatusRules(t *testing.T) {
 			start: newK8s("Pod", "ns", "pod2", nil),
 			want:  nil,
 		},
+		{
+			rule:  "ContainerStatus",
+			class: "Pod",
+			start: newK8s("Pod", "ns", "crashing", k8s.Object{
+				"status": k8s.Object{
+					"containerStatuses": []any{
+						k8s.Object{
+							"state":     k8s.Object{"waiting": k8s.Object{"reason": "CrashLoopBackOff"}},
+							"lastState": k8s.Object{"terminated": k8s.Object{"reason": "OOMKilled"}},
+						},
+					},
+				},
+			}),
+			want: []string{"CrashLoopBackOff", "OOMKilled"},
+		},
+		{
+			rule:  "ContainerStatus",
+			class: "Pod",
+			start: newK8s("Pod", "ns", "pod3", nil),
+			want:  nil,
+		},
 		{
 			rule:  "EventType",
 			class: "Event.v1",
@@ -110,3 +110,31 @@ func TestMetricRulesRequiredGuards(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricStatusRules(t *testing.T) {
+	for _, x := range []statusRuleTest{
+		{
+			rule:   "MetricAnomaly",
+			domain: metric.Domain,
+			class:  "metric",
+			start:  metric.Object{Labels: map[string]string{"__name__": "up"}, Anomaly: &metric.Anomaly{Statuses: []string{metric.Drop}, Score: 1}},
+			want:   []string{"Drop"},
+		},
+		{
+			rule:   "MetricAnomaly",
+			domain: metric.Domain,
+			class:  "metric",
+			start:  metric.Object{Labels: map[string]string{"__name__": "up"}, Anomaly: &metric.Anomaly{}},
+			want:   nil,
+		},
+		{
+			rule:   "MetricAnomaly",
+			domain: metric.Domain,
+			class:  "metric",
+			start:  metric.Object{Labels: map[string]string{"__name__": "up"}},
+			want:   nil,
+		},
+	} {
+		x.Run(t)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/korrel8r/korrel8r/pkg/domains/alert"
 	"github.com/korrel8r/korrel8r/pkg/domains/k8s"
 	"github.com/korrel8r/korrel8r/pkg/domains/log"
+	"github.com/korrel8r/korrel8r/pkg/domains/metric"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	"github.com/korrel8r/korrel8r/pkg/status"
 )
@@ -41,9 +42,11 @@ func StatusRules(d *korrel8r.Domains) []status.Rule {
 	specs := []spec{
 		{name: "HealthStatus", domain: "k8s", apply: healthStatus},
 		{name: "HasFinalizer", domain: "k8s", apply: hasFinalizer},
+		{name: "ContainerStatus", domain: "k8s", classes: []string{"Pod"}, apply: containerStatus},
 		{name: "EventType", domain: "k8s", classes: []string{"Event.v1", "Event.v1.events.k8s.io"}, apply: eventType},
 		{name: "AlertSeverity", domain: "alert", apply: alertSeverity},
 		{name: "LogSeverity", domain: "log", apply: logSeverity},
+		{name: "MetricAnomaly", domain: "metric", apply: metricAnomaly},
 	}
 	var result []status.Rule
 	for _, s := range specs {
@@ -64,6 +67,10 @@ func healthStatus(o korrel8r.Object) []string {
 	return nil
 }
 
+func containerStatus(o korrel8r.Object) []string {
+	return k8s.ContainerStatus(o.(k8s.Object))
+}
+
 func hasFinalizer(o korrel8r.Object) []string {
 	obj := o.(map[string]any)
 	metadata, _ := obj["metadata"].(map[string]any)
@@ -112,3 +119,10 @@ func logSeverity(o korrel8r.Object) []string {
 	}
 	return nil
 }
+
+func metricAnomaly(o korrel8r.Object) []string {
+	if a := o.(metric.Object).Anomaly; a != nil {
+		return a.Statuses
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package session
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var sessionMeter = otel.Meter("korrel8r/session")
+
+var (
+	metricReloads, _ = sessionMeter.Int64Counter("session.config.reloads", metric.WithDescription("Configuration reload attempts"))
+)
+
+// Pre-calculated metric attributes, indexed by [status=="error"]
+var reloadAttrs = [2]metric.MeasurementOption{
+	metric.WithAttributes(attribute.String("status", "ok")),
+	metric.WithAttributes(attribute.String("status", "error")),
+}
+
+// recordReload records metrics for a configuration reload attempt.
+func recordReload(err error) {
+	statusIdx := 0
+	if err != nil {
+		statusIdx = 1
+	}
+	metricReloads.Add(context.Background(), 1, reloadAttrs[statusIdx])
+}
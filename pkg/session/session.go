@@ -3,14 +3,18 @@
 // Package session manages per-user sessions, each with its own Engine.
 // Each session has a numeric ID for logging and a string Key for map lookup.
 // Sessions expire after a configurable timeout of inactivity.
+// A session may have a configuration overlay and saved searches, which are dropped when the session expires.
+// Sessions can be saved to a [Store] and restored after a restart.
 //
-// Session key is the username resolved from a bearer token via TokenReview.
+// Session key is the username resolved from a bearer token by an [Authenticator],
+// using TokenReview or OIDC.
 package session
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -18,9 +22,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/korrel8r/korrel8r/internal/pkg/logging"
+	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
-	"github.com/korrel8r/korrel8r/pkg/tokenreview"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
+	"github.com/korrel8r/korrel8r/pkg/tokenreview"
 )
 
 var log = logging.Log()
@@ -31,16 +37,165 @@ type AuthError struct{ Err error }
 func (e *AuthError) Error() string { return e.Err.Error() }
 func (e *AuthError) Unwrap() error { return e.Err }
 
+// Factory creates a new engine from the base configuration plus an optional session overlay.
+// The overlay is nil if the session has none.
+type Factory func(overlay *config.Config) (*engine.Engine, error)
+
+// ErrNoOverlay is returned by [Session.Overlay] if the session has no overlay.
+var ErrNoOverlay = errors.New("no configuration overlay for this session")
+
+// ErrNoSearch is returned by [Session.DeleteSearch] if there is no saved search with the name.
+var ErrNoSearch = errors.New("no saved search with this name")
+
 // Session holds per-user state including engine, console state, and configuration.
 type Session struct {
-	ID       string // Session ID - a username or hashed authorization token.
-	Engine   *engine.Engine
-	lastUsed atomic.Int64 // UnixNano timestamp for expiration, atomic for lock-free access.
+	ID       string                        // Session ID - a username or hashed authorization token.
+	engine   atomic.Pointer[engine.Engine] // Current engine, replaced on configuration reload.
+	lastUsed atomic.Int64                  // UnixNano timestamp for expiration, atomic for lock-free access.
 	*consoleEvents
+
+	mu       sync.Mutex        // Serializes engine rebuilds and changes to saved state.
+	factory  *Factory          // Factory that built the current engine.
+	overlay  *config.Config    // Configuration overlay for this session, nil if none.
+	searches api.SavedSearches // Saved searches by name, nil if none.
+
+	store     Store         // Persistent store, nil if the session is not persisted.
+	saveEvery time.Duration // Save an unchanged session at this interval to update its last-used time.
+	savedAt   atomic.Int64  // UnixNano timestamp of the last save to store.
 }
 
 func (s *Session) String() string { return s.ID }
 
+// Engine returns the current engine for the session.
+// The engine can be replaced by a configuration reload,
+// a request should call Engine once and use the same engine throughout.
+func (s *Session) Engine() *engine.Engine { return s.engine.Load() }
+
+// refresh rebuilds the session engine if factory is not the one that built the current engine.
+// On error the current engine is kept, the error is recorded as its reload error,
+// and the rebuild is tried again on the next refresh.
+func (s *Session) refresh(factory *Factory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.factory == factory {
+		return
+	}
+	e, err := (*factory)(s.overlay)
+	if err != nil {
+		log.Error(err, "Session engine reload failed", "session", s.ID)
+		s.Engine().SetReloadError(err)
+		return
+	}
+	s.factory = factory
+	s.engine.Store(e)
+	log.V(1).Info("Session engine reloaded", "session", s.ID)
+}
+
+// Overlay returns the configuration overlay for the session.
+// Returns [ErrNoOverlay] if there is none.
+func (s *Session) Overlay() (*config.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overlay == nil {
+		return nil, ErrNoOverlay
+	}
+	return s.overlay, nil
+}
+
+// SetOverlay rebuilds the session engine from the base configuration plus overlay.
+// A nil overlay restores the base configuration.
+// If the engine cannot be built, the session is unchanged and the error is returned.
+func (s *Session) SetOverlay(overlay *config.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.factory == nil {
+		return errors.New("configuration overlays are not supported for this session")
+	}
+	e, err := (*s.factory)(overlay)
+	if err != nil {
+		return err
+	}
+	s.overlay = overlay
+	s.engine.Store(e)
+	log.V(1).Info("Session configuration overlay changed", "session", s.ID, "overlay", overlay != nil)
+	s.saveLocked()
+	return nil
+}
+
+// Searches returns a copy of the saved searches for the session.
+func (s *Session) Searches() api.SavedSearches {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.searches)
+}
+
+// SaveSearch saves search under name, replacing any search with the same name.
+func (s *Session) SaveSearch(name string, search api.Search) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.searches == nil {
+		s.searches = api.SavedSearches{}
+	}
+	s.searches[name] = search
+	s.saveLocked()
+}
+
+// DeleteSearch deletes the saved search with name.
+// Returns [ErrNoSearch] if there is none.
+func (s *Session) DeleteSearch(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.searches[name]; !ok {
+		return ErrNoSearch
+	}
+	delete(s.searches, name)
+	s.saveLocked()
+	return nil
+}
+
+// SetConsoleState sets the latest console state, and saves it if the session is persisted.
+func (s *Session) SetConsoleState(state *api.Console) {
+	s.consoleEvents.SetConsoleState(state)
+	s.save()
+}
+
+// save the session to its store, if it has one.
+func (s *Session) save() {
+	if s.store == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saveLocked()
+}
+
+// saveLocked saves the session, the caller must hold s.mu.
+func (s *Session) saveLocked() {
+	if s.store == nil {
+		return
+	}
+	r := &Record{
+		ID:       s.ID,
+		LastUsed: time.Unix(0, s.lastUsed.Load()),
+		Console:  s.ConsoleState(),
+		Overlay:  s.overlay,
+		Searches: s.searches,
+	}
+	if err := s.store.Save(r); err != nil {
+		log.Error(err, "Session save failed", "session", s.ID)
+		return
+	}
+	s.savedAt.Store(time.Now().UnixNano())
+}
+
+// touch updates the last-used time, and saves the session if it has not been saved recently.
+func (s *Session) touch(now int64) {
+	s.lastUsed.Store(now)
+	if s.store != nil && now-s.savedAt.Load() > int64(s.saveEvery) {
+		s.save()
+	}
+}
+
 // FromContext returns the session from ctx. See [WithSession].
 func FromContext(ctx context.Context) *Session {
 	s, _ := ctx.Value(sessionKey{}).(*Session)
@@ -56,6 +211,27 @@ func WithSession(ctx context.Context, s *Session) context.Context {
 type Manager interface {
 	// Get the session for a context.
 	Get(ctx context.Context) (*Session, error)
+	// Reload replaces the engine of every session with an engine created by factory.
+	// The factory is called once to validate it before any session is changed,
+	// the validated engine is used by the next session that has no overlay.
+	// If it fails, all sessions keep their current engine, the error is recorded
+	// as the engine reload error and returned.
+	Reload(factory Factory) error
+}
+
+// reuse returns a factory that returns e on the first call with no overlay, and calls factory otherwise.
+// Used by Reload so the engine built to validate a configuration is not built twice.
+func reuse(factory Factory, e *engine.Engine) Factory {
+	var spare atomic.Pointer[engine.Engine]
+	spare.Store(e)
+	return func(overlay *config.Config) (*engine.Engine, error) {
+		if overlay == nil {
+			if e := spare.Swap(nil); e != nil {
+				return e, nil
+			}
+		}
+		return factory(overlay)
+	}
 }
 
 // singleManager always returns the same session, ignoring the context.
@@ -63,23 +239,42 @@ type singleManager struct {
 	session *Session
 }
 
-func newSession(e *engine.Engine, id string) *Session {
-	return &Session{
+func newSession(e *engine.Engine, id string, factory *Factory) *Session {
+	s := &Session{
 		ID:            id,
-		Engine:        e,
 		consoleEvents: newConsoleEvents(),
+		factory:       factory,
 	}
+	s.engine.Store(e)
+	return s
 }
 
 // NewSingleManager returns a Manager that always returns the same session.
 // There is no session isolation.
-func NewSingleManager(e *engine.Engine) Manager {
-	return &singleManager{session: newSession(e, "")}
+// If factory is not nil it is used to apply configuration overlays, see [Session.SetOverlay].
+func NewSingleManager(e *engine.Engine, factory Factory) Manager {
+	var f *Factory
+	if factory != nil {
+		f = &factory
+	}
+	return &singleManager{session: newSession(e, "", f)}
 }
 
 func (m *singleManager) Get(ctx context.Context) (*Session, error) {
 	return m.session, nil
 }
+
+func (m *singleManager) Reload(factory Factory) error {
+	e, err := factory(nil)
+	recordReload(err)
+	if err != nil {
+		m.session.Engine().SetReloadError(err)
+		return err
+	}
+	f := reuse(factory, e)
+	m.session.refresh(&f)
+	return nil
+}
 func (m *singleManager) Close() {}
 
 // entry holds a session that is initialized exactly once.
@@ -93,19 +288,49 @@ type entry struct {
 // poolManager maps session keys to Sessions, with timeout-based cleanup.
 type poolManager struct {
 	sessions    sync.Map // map[string]*entry
-	tokenReview *tokenreview.TokenReview
-	factory     func() (*engine.Engine, error)
+	store       Store    // Optional persistent store.
+	authn       Authenticator
+	factory     atomic.Pointer[Factory] // Replaced by Reload, sessions rebuild their engine on next use.
+	reloadErr   atomic.Pointer[error]   // Error from the last failed Reload, nil after a successful Reload.
 	timeout     time.Duration
 	lastCleanup atomic.Int64
 }
 
+// Authenticator identifies the user that owns a bearer token.
+//
+// Implementations include [tokenreview.TokenReview] and oidc.Authenticator.
+type Authenticator interface {
+	// Authenticate returns the user for token, or an error if the token is not valid.
+	Authenticate(token string) (*auth.User, error)
+}
+
 // NewTokenReviewManager creates a Manager that creates per-user sessions
 // using bearer tokens and TokenReview to find the owning user-id.
-func NewTokenReviewManager(tokenReview *tokenreview.TokenReview, timeout time.Duration, factory func() (*engine.Engine, error)) Manager {
-	return Manager(&poolManager{timeout: timeout, tokenReview: tokenReview, factory: factory})
+//
+// If store is not nil, sessions are saved to it and restored on first use after a restart,
+// unless they have expired.
+func NewTokenReviewManager(tokenReview *tokenreview.TokenReview, timeout time.Duration, factory Factory, store Store) Manager {
+	var authn Authenticator
+	if tokenReview != nil {
+		authn = tokenReview
+	}
+	return NewAuthManager(authn, timeout, factory, store)
+}
+
+// NewAuthManager creates a Manager that creates per-user sessions,
+// using authn to find the user that owns the bearer token of each request.
+// The session ID is the user name.
+//
+// If store is not nil, sessions are saved to it and restored on first use after a restart,
+// unless they have expired.
+func NewAuthManager(authn Authenticator, timeout time.Duration, factory Factory, store Store) Manager {
+	m := &poolManager{timeout: timeout, authn: authn, store: store}
+	m.factory.Store(&factory)
+	return m
 }
 
-func (m *poolManager) id(ctx context.Context) (id string, err error) {
+// user returns the authenticated user for the bearer token in ctx.
+func (m *poolManager) user(ctx context.Context) (u *auth.User, err error) {
 	defer func() {
 		if err != nil {
 			err = &AuthError{Err: fmt.Errorf("session authentication error: %w", err)}
@@ -114,42 +339,112 @@ func (m *poolManager) id(ctx context.Context) (id string, err error) {
 	token := auth.ContextToken(ctx)
 	switch {
 	case token == "":
-		return "", errors.New("no bearer token in request")
-	case m.tokenReview == nil:
-		return "", errors.New("TokenReview is not available")
+		return nil, errors.New("no bearer token in request")
+	case m.authn == nil:
+		return nil, errors.New("no authenticator is available")
 	default:
-		return m.tokenReview.User(token)
+		return m.authn.Authenticate(token)
 	}
 }
 
 // Get returns the Session for the given context, creating a new session if needed.
 func (m *poolManager) Get(ctx context.Context) (*Session, error) {
-	id, err := m.id(ctx)
+	u, err := m.user(ctx)
 	if err != nil {
 		return nil, err
 	}
+	id := u.Name
 
 	v, _ := m.sessions.LoadOrStore(id, &entry{})
 	e := v.(*entry)
-	e.once.Do(func() {
-		var eng *engine.Engine
-		eng, e.err = m.factory()
-		if e.err == nil {
-			e.session = newSession(eng, id)
-			log.V(1).Info("Session created", "session", id)
-		}
-	})
+	factory := m.factory.Load()
+	e.once.Do(func() { e.session, e.err = m.newSession(id, factory) })
 	if e.err != nil {
 		log.Error(e.err, "Session create failed")
 		m.sessions.CompareAndSwap(id, e, &entry{}) // Allow retry with a fresh entry.
 		return nil, e.err
 	}
+	e.session.refresh(factory)
 	now := time.Now().UnixNano()
-	e.session.lastUsed.Store(now)
+	e.session.touch(now)
 	m.maybeCleanup(now)
 	return e.session, nil
 }
 
+// newSession creates a session, restoring its saved state if there is any.
+func (m *poolManager) newSession(id string, factory *Factory) (*Session, error) {
+	r := m.restore(id)
+	var overlay *config.Config
+	if r != nil {
+		overlay = r.Overlay
+	}
+	eng, err := (*factory)(overlay)
+	if err != nil && overlay != nil {
+		log.Error(err, "Saved configuration overlay is not valid, dropped", "session", id)
+		overlay = nil
+		eng, err = (*factory)(nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := m.reloadErr.Load(); err != nil {
+		eng.SetReloadError(*err)
+	}
+	s := newSession(eng, id, factory)
+	s.store = m.store
+	// Save often enough that the saved last-used time does not expire an active session.
+	s.saveEvery = time.Minute
+	if m.timeout > 0 {
+		s.saveEvery = min(s.saveEvery, m.timeout/2)
+	}
+	s.overlay = overlay
+	if r != nil {
+		s.consoleEvents.SetConsoleState(r.Console)
+		s.searches = r.Searches
+		log.V(1).Info("Session restored", "session", id)
+	} else {
+		log.V(1).Info("Session created", "session", id)
+	}
+	return s, nil
+}
+
+// restore returns the saved record for id, or nil if there is none or it has expired.
+func (m *poolManager) restore(id string) *Record {
+	if m.store == nil {
+		return nil
+	}
+	r, err := m.store.Load(id)
+	if err != nil {
+		log.Error(err, "Session restore failed", "session", id)
+		return nil
+	}
+	if r != nil && m.timeout > 0 && time.Since(r.LastUsed) > m.timeout {
+		return nil
+	}
+	return r
+}
+
+// Reload validates factory and makes it the factory for new sessions.
+// Existing sessions rebuild their engine on next use, see [Session.refresh].
+func (m *poolManager) Reload(factory Factory) error {
+	e, err := factory(nil)
+	recordReload(err)
+	if err != nil {
+		m.reloadErr.Store(&err)
+		m.sessions.Range(func(_, value any) bool {
+			if s := value.(*entry).session; s != nil {
+				s.Engine().SetReloadError(err)
+			}
+			return true
+		})
+		return err
+	}
+	m.reloadErr.Store(nil)
+	f := reuse(factory, e)
+	m.factory.Store(&f)
+	return nil
+}
+
 // maybeCleanup runs cleanup if timeout is enabled and enough time has passed since the last cleanup.
 //
 // Note this allows sessions to hang around longer if there is no activity, but in that case the
@@ -173,11 +468,21 @@ func (m *poolManager) maybeCleanup(now int64) {
 		}
 		return true
 	})
+	if m.store != nil { // Includes saved sessions that were never restored.
+		if err := m.store.DeleteBefore(time.Unix(0, now).Add(-m.timeout)); err != nil {
+			log.Error(err, "Expired session delete failed")
+		}
+	}
 }
 
 type sessionKey struct{}
 
-// UpdateRequest adds session and timeout to request context.
+// userManager is implemented by managers that authenticate users.
+type userManager interface {
+	user(ctx context.Context) (*auth.User, error)
+}
+
+// UpdateRequest adds session, authenticated user and timeout to request context.
 // Returns the request and cancel function for the timeout
 func UpdateRequest(req *http.Request, sessions Manager) (*http.Request, func(), error) {
 	ctx := req.Context()
@@ -186,8 +491,15 @@ func UpdateRequest(req *http.Request, sessions Manager) (*http.Request, func(),
 	if err != nil {
 		return nil, func() {}, err
 	}
+	if um, ok := sessions.(userManager); ok {
+		u, err := um.user(ctx) // Cached by the manager, Get has already succeeded.
+		if err != nil {
+			return nil, func() {}, err
+		}
+		ctx = auth.WithUser(ctx, u)
+	}
 	ctx = WithSession(ctx, ss)
-	ctx, cancel := ss.Engine.WithTimeout(ctx, 0)
+	ctx, cancel := ss.Engine().WithTimeout(ctx, 0)
 	req = req.WithContext(ctx)
 	return req, cancel, nil
 }
@@ -4,6 +4,8 @@ package session
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,18 +13,24 @@ import (
 
 	"github.com/korrel8r/korrel8r/internal/pkg/test"
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
+	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func testFactory() (*engine.Engine, error) {
-	return engine.Build().Domains(mock.NewDomain("mock")).Engine()
+func testFactory(overlay *config.Config) (*engine.Engine, error) {
+	configs, err := config.Configs{}.WithOverlay(overlay)
+	if err != nil {
+		return nil, err
+	}
+	return engine.Build().Domains(mock.NewDomain("mock")).Config(configs).Engine()
 }
 
 func testMulti(timeout time.Duration) Manager {
-	return NewTokenReviewManager(test.FakeTokenReview(), timeout, testFactory)
+	return NewTokenReviewManager(test.FakeTokenReview(), timeout, testFactory, nil)
 }
 
 func tokenCtx(token string) context.Context {
@@ -50,6 +58,55 @@ func TestGet_DifferentKeys(t *testing.T) {
 	assert.NotSame(t, s1, s2, "different keys should return different sessions")
 }
 
+func TestUpdateRequest_User(t *testing.T) {
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer alice")
+	req, cancel, err := UpdateRequest(req, testMulti(time.Hour))
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, &auth.User{Name: "alice", Username: "alice"}, auth.ContextUser(req.Context()))
+	assert.Equal(t, "alice", FromContext(req.Context()).ID)
+
+	// A single manager does not authenticate.
+	e, err := testFactory(nil)
+	require.NoError(t, err)
+	req, err = http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req, cancel, err = UpdateRequest(req, NewSingleManager(e, nil))
+	require.NoError(t, err)
+	defer cancel()
+	assert.Nil(t, auth.ContextUser(req.Context()))
+}
+
+// authFunc is an Authenticator function.
+type authFunc func(token string) (*auth.User, error)
+
+func (f authFunc) Authenticate(token string) (*auth.User, error) { return f(token) }
+
+func TestNewAuthManager(t *testing.T) {
+	m := NewAuthManager(authFunc(func(token string) (*auth.User, error) {
+		if token == "bad" {
+			return nil, errors.New("invalid token")
+		}
+		return &auth.User{Name: "user-" + token, Groups: []string{"g"}}, nil
+	}), time.Hour, testFactory, nil)
+	s := getSession(t, m, "a")
+	assert.Equal(t, "user-a", s.ID)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer a")
+	req, cancel, err := UpdateRequest(req, m)
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, &auth.User{Name: "user-a", Groups: []string{"g"}}, auth.ContextUser(req.Context()))
+	_, err = m.Get(tokenCtx("bad"))
+	assert.ErrorContains(t, err, "invalid token")
+
+	_, err = NewAuthManager(nil, time.Hour, testFactory, nil).Get(tokenCtx("a"))
+	assert.ErrorContains(t, err, "no authenticator is available")
+}
+
 func TestConcurrent(t *testing.T) {
 	m := testMulti(time.Hour)
 	var wg sync.WaitGroup
@@ -90,9 +147,9 @@ func TestConcurrent_NewKey(t *testing.T) {
 }
 
 func TestUnsafeSharedSession(t *testing.T) {
-	e, err := testFactory()
+	e, err := testFactory(nil)
 	require.NoError(t, err)
-	m := NewSingleManager(e)
+	m := NewSingleManager(e, nil)
 
 	// No token — gets the shared session.
 	s1, err := m.Get(context.Background())
@@ -128,3 +185,187 @@ func TestCleanup_OneExpiredOneActive(t *testing.T) {
 	sNewAgain := getSession(t, m, "new-token")
 	assert.Same(t, sNew, sNewAgain, "active session should be retained")
 }
+
+func TestReload(t *testing.T) {
+	m := testMulti(time.Hour)
+	s := getSession(t, m, "key-a")
+	before := s.Engine()
+
+	// Failed reload keeps the engine and records the error.
+	require.EqualError(t, m.Reload(func(*config.Config) (*engine.Engine, error) { return nil, errors.New("bad config") }), "bad config")
+	s = getSession(t, m, "key-a")
+	assert.Same(t, before, s.Engine())
+	assert.EqualError(t, s.Engine().ReloadError(), "bad config")
+	assert.EqualError(t, getSession(t, m, "key-b").Engine().ReloadError(), "bad config", "new session")
+
+	// Successful reload replaces the engine on next use.
+	require.NoError(t, m.Reload(testFactory))
+	s = getSession(t, m, "key-a")
+	assert.NotSame(t, before, s.Engine())
+	assert.NoError(t, s.Engine().ReloadError())
+}
+
+// countFactory returns a factory that counts its calls, and fails for an overlay while fail is true.
+func countFactory(calls *atomic.Int32, fail *atomic.Bool) Factory {
+	return func(overlay *config.Config) (*engine.Engine, error) {
+		calls.Add(1)
+		if overlay != nil && fail.Load() {
+			return nil, errors.New("bad overlay")
+		}
+		return testFactory(overlay)
+	}
+}
+
+func TestReload_BuildOnce(t *testing.T) {
+	var calls atomic.Int32
+	m := testMulti(time.Hour)
+	before := getSession(t, m, "key-a").Engine()
+	require.NoError(t, m.Reload(countFactory(&calls, new(atomic.Bool))))
+	assert.NotSame(t, before, getSession(t, m, "key-a").Engine())
+	assert.Equal(t, int32(1), calls.Load(), "validated engine is reused")
+	getSession(t, m, "key-b")
+	assert.Equal(t, int32(2), calls.Load(), "validated engine is used once")
+
+	e, err := testFactory(nil)
+	require.NoError(t, err)
+	sm := NewSingleManager(e, nil)
+	calls.Store(0)
+	require.NoError(t, sm.Reload(countFactory(&calls, new(atomic.Bool))))
+	assert.Equal(t, int32(1), calls.Load(), "validated engine is reused")
+}
+
+func TestReload_Retry(t *testing.T) {
+	var (
+		calls atomic.Int32
+		fail  atomic.Bool
+	)
+	m := testMulti(time.Hour)
+	s := getSession(t, m, "key-a")
+	require.NoError(t, s.SetOverlay(&config.Config{}))
+	before := s.Engine()
+
+	// A failed session rebuild keeps the engine and is retried on next use.
+	fail.Store(true)
+	require.NoError(t, m.Reload(countFactory(&calls, &fail)))
+	assert.Same(t, before, getSession(t, m, "key-a").Engine())
+	assert.EqualError(t, before.ReloadError(), "bad overlay")
+	fail.Store(false)
+	assert.NotSame(t, before, getSession(t, m, "key-a").Engine())
+}
+
+func TestReload_SingleManager(t *testing.T) {
+	e, err := testFactory(nil)
+	require.NoError(t, err)
+	m := NewSingleManager(e, nil)
+	require.Error(t, m.Reload(func(*config.Config) (*engine.Engine, error) { return nil, errors.New("bad config") }))
+	s, _ := m.Get(context.Background())
+	assert.Same(t, e, s.Engine())
+	require.NoError(t, m.Reload(testFactory))
+	assert.NotSame(t, e, s.Engine())
+}
+
+func TestOverlay(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	m := testMulti(timeout)
+	s := getSession(t, m, "key-a")
+	_, err := s.Overlay()
+	require.ErrorIs(t, err, ErrNoOverlay)
+
+	before := s.Engine()
+	overlay := &config.Config{Stores: []config.Store{{"domain": "mock"}}}
+	require.NoError(t, s.SetOverlay(overlay))
+	assert.NotSame(t, before, s.Engine())
+	got, err := s.Overlay()
+	require.NoError(t, err)
+	assert.Same(t, overlay, got)
+	_, err = getSession(t, m, "key-b").Overlay()
+	assert.ErrorIs(t, err, ErrNoOverlay, "other sessions unchanged")
+
+	// Overlay is kept across a reload.
+	require.NoError(t, m.Reload(testFactory))
+	got, err = getSession(t, m, "key-a").Overlay()
+	require.NoError(t, err)
+	assert.Same(t, overlay, got)
+
+	// Invalid overlay leaves the session unchanged.
+	before = s.Engine()
+	require.Error(t, s.SetOverlay(&config.Config{Stores: []config.Store{{"domain": "nosuch"}}}))
+	assert.Same(t, before, s.Engine())
+
+	// Overlay is dropped when the session expires.
+	time.Sleep(timeout * 3)
+	getSession(t, m, "key-b") // Trigger cleanup.
+	_, err = getSession(t, m, "key-a").Overlay()
+	assert.ErrorIs(t, err, ErrNoOverlay)
+}
+
+func TestOverlay_SingleManager(t *testing.T) {
+	e, err := testFactory(nil)
+	require.NoError(t, err)
+	s, _ := NewSingleManager(e, nil).Get(context.Background())
+	assert.Error(t, s.SetOverlay(&config.Config{}), "no factory")
+
+	s, _ = NewSingleManager(e, testFactory).Get(context.Background())
+	require.NoError(t, s.SetOverlay(&config.Config{}))
+	assert.NotSame(t, e, s.Engine())
+}
+
+func TestStore_Restore(t *testing.T) {
+	store, _ := testStore(t)
+	m := NewTokenReviewManager(test.FakeTokenReview(), time.Hour, testFactory, store)
+	s := getSession(t, m, "key-a")
+	console := &api.Console{View: "mock:a:x"}
+	s.SetConsoleState(console)
+	overlay := &config.Config{Stores: []config.Store{{"domain": "mock"}}}
+	require.NoError(t, s.SetOverlay(overlay))
+
+	// New manager simulates a restart.
+	m = NewTokenReviewManager(test.FakeTokenReview(), time.Hour, testFactory, store)
+	s = getSession(t, m, "key-a")
+	assert.Equal(t, console, s.ConsoleState())
+	got, err := s.Overlay()
+	require.NoError(t, err)
+	assert.Equal(t, overlay, got)
+	assert.Len(t, s.Engine().StoreConfigsFor(s.Engine().Domains()[0]), 1)
+
+	// Unknown sessions are not restored.
+	s = getSession(t, m, "key-b")
+	assert.Nil(t, s.ConsoleState())
+}
+
+func TestStore_RestoreSearches(t *testing.T) {
+	store, _ := testStore(t)
+	m := NewTokenReviewManager(test.FakeTokenReview(), time.Hour, testFactory, store)
+	s := getSession(t, m, "key-a")
+	search := api.Search{Neighbors: &api.Neighbors{Start: api.Start{Queries: []string{"mock:a:x"}}, Depth: 2}}
+	s.SaveSearch("x", search)
+	s.SaveSearch("y", search)
+	require.NoError(t, s.DeleteSearch("y"))
+	assert.ErrorIs(t, s.DeleteSearch("y"), ErrNoSearch)
+
+	// New manager simulates a restart.
+	m = NewTokenReviewManager(test.FakeTokenReview(), time.Hour, testFactory, store)
+	s = getSession(t, m, "key-a")
+	assert.Equal(t, api.SavedSearches{"x": search}, s.Searches())
+	assert.Empty(t, getSession(t, m, "key-b").Searches())
+}
+
+func TestStore_Expired(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	store, _ := testStore(t)
+	m := NewTokenReviewManager(test.FakeTokenReview(), timeout, testFactory, store)
+	getSession(t, m, "key-a").SetConsoleState(&api.Console{View: "mock:a:x"})
+	time.Sleep(timeout * 3)
+
+	// Expired sessions are not restored after a restart.
+	m = NewTokenReviewManager(test.FakeTokenReview(), timeout, testFactory, store)
+	assert.Nil(t, getSession(t, m, "key-a").ConsoleState())
+
+	// Cleanup removes expired records.
+	require.NoError(t, store.Save(&Record{ID: "old", LastUsed: time.Now().Add(-time.Hour)}))
+	time.Sleep(timeout * 3)
+	getSession(t, m, "key-b")
+	r, err := store.Load("old")
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
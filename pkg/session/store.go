@@ -0,0 +1,105 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package session
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/korrel8r/korrel8r/internal/pkg/json"
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Record is the persistent state of a session.
+type Record struct {
+	ID       string            `json:"id"`
+	LastUsed time.Time         `json:"lastUsed"`
+	Console  *api.Console      `json:"console,omitempty"` // Last console state, including the console search.
+	Overlay  *config.Config    `json:"overlay,omitempty"`
+	Searches api.SavedSearches `json:"searches,omitempty"` // Saved searches by name.
+}
+
+// Store persists session records so sessions survive a restart.
+// Methods must be safe for concurrent use.
+type Store interface {
+	// Load returns the record for id, or nil if there is none.
+	Load(id string) (*Record, error)
+	// Save creates or replaces the record for r.ID.
+	Save(r *Record) error
+	// Delete the record for id, it is not an error if there is none.
+	Delete(id string) error
+	// DeleteBefore deletes records last used before t.
+	DeleteBefore(t time.Time) error
+	// Close the store.
+	Close() error
+}
+
+var sessionsBucket = []byte("sessions")
+
+// boltStore is a Store in an embedded bbolt database file.
+type boltStore struct{ db *bolt.DB }
+
+// NewFileStore returns a Store using an embedded database in file, which is created if it does not exist.
+// Only one process can open the file at a time.
+func NewFileStore(file string) (Store, error) {
+	db, err := bolt.Open(file, 0o600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Load(id string) (r *Record, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sessionsBucket).Get([]byte(id))
+		if b == nil {
+			return nil
+		}
+		r = &Record{}
+		return json.Unmarshal(b, r)
+	})
+	return r, err
+}
+
+func (s *boltStore) Save(r *Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error { return tx.Bucket(sessionsBucket).Put([]byte(r.ID), b) })
+}
+
+func (s *boltStore) Delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error { return tx.Bucket(sessionsBucket).Delete([]byte(id)) })
+}
+
+func (s *boltStore) DeleteBefore(t time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sessionsBucket)
+		var expired [][]byte
+		_ = bucket.ForEach(func(k, v []byte) error {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil || r.LastUsed.Before(t) {
+				expired = append(expired, bytes.Clone(k)) // Also delete unreadable records.
+			}
+			return nil
+		})
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStore) Close() error { return s.db.Close() }
@@ -0,0 +1,61 @@
+// Copyright: This file is part of korrel8r, released under https://github.com/korrel8r/korrel8r/blob/main/LICENSE
+
+package session
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/korrel8r/korrel8r/pkg/api"
+	"github.com/korrel8r/korrel8r/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStore(t *testing.T) (Store, string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "sessions.db")
+	s, err := NewFileStore(file)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s, file
+}
+
+func TestFileStore(t *testing.T) {
+	s, file := testStore(t)
+	now := time.Now().Truncate(time.Millisecond)
+	r := &Record{
+		ID:       "a",
+		LastUsed: now,
+		Console:  &api.Console{View: "k8s:Pod:{}"},
+		Overlay:  &config.Config{Stores: []config.Store{{"domain": "log"}}},
+	}
+	require.NoError(t, s.Save(r))
+	require.NoError(t, s.Save(&Record{ID: "b", LastUsed: now.Add(-time.Hour)}))
+
+	got, err := s.Load("a")
+	require.NoError(t, err)
+	assert.Equal(t, r.Console, got.Console)
+	assert.Equal(t, r.Overlay, got.Overlay)
+	assert.True(t, now.Equal(got.LastUsed))
+
+	// Records survive re-opening the file.
+	require.NoError(t, s.Close())
+	s, err = NewFileStore(file)
+	require.NoError(t, err)
+	got, err = s.Load("b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", got.ID)
+
+	require.NoError(t, s.DeleteBefore(now.Add(-time.Minute)))
+	got, err = s.Load("b")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, s.Delete("a"))
+	got, err = s.Load("a")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	require.NoError(t, s.Close())
+}
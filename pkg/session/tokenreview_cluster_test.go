@@ -19,7 +19,7 @@ func TestTokenReview_SessionID_cluster(t *testing.T) {
 
 	tr, err := tokenreview.New()
 	require.NoError(t, err)
-	m := NewTokenReviewManager(tr, time.Hour, testFactory)
+	m := NewTokenReviewManager(tr, time.Hour, testFactory, nil)
 
 	// Use the real bearer token to get a session.
 	ctx := tokenCtx(cfg.BearerToken)
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,8 +19,8 @@ import (
 const cacheExpiry = 5 * time.Minute
 
 type cacheEntry struct {
-	username string
-	expires  time.Time
+	user    *auth.User
+	expires time.Time
 }
 
 // TokenReview resolves bearer tokens to Kubernetes usernames via the TokenReview API.
@@ -65,9 +66,20 @@ func New() (*TokenReview, error) {
 // Returns the username, falling back to UID if username is empty.
 // Results are cached for 5 minutes per token.
 func (tr *TokenReview) User(token string) (string, error) {
+	u, err := tr.Authenticate(token)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+
+// Authenticate resolves a bearer token to a Kubernetes user name and groups.
+// The name is the username, falling back to UID if username is empty.
+// Results are cached for 5 minutes per token.
+func (tr *TokenReview) Authenticate(token string) (*auth.User, error) {
 	if v, ok := tr.cache.Load(token); ok {
 		if e := v.(cacheEntry); time.Now().Before(e.expires) {
-			return e.username, nil
+			return e.user, nil
 		}
 		tr.cache.Delete(token)
 	}
@@ -77,18 +89,19 @@ func (tr *TokenReview) User(token string) (string, error) {
 	result, err := tr.clientset.AuthenticationV1().TokenReviews().Create(
 		context.Background(), review, metav1.CreateOptions{})
 	if err != nil {
-		return "", fmt.Errorf("TokenReview failed: %w", err)
+		return nil, fmt.Errorf("TokenReview failed: %w", err)
 	}
 	if !result.Status.Authenticated {
-		return "", fmt.Errorf("TokenReview: token not authenticated")
+		return nil, fmt.Errorf("TokenReview: token not authenticated")
 	}
 	username := result.Status.User.Username
 	if username == "" {
 		username = result.Status.User.UID
 	}
 	if username == "" {
-		return "", fmt.Errorf("TokenReview: no username or UID in response")
+		return nil, fmt.Errorf("TokenReview: no username or UID in response")
 	}
-	tr.cache.Store(token, cacheEntry{username: username, expires: time.Now().Add(cacheExpiry)})
-	return username, nil
+	u := &auth.User{Name: username, Username: result.Status.User.Username, Groups: result.Status.User.Groups}
+	tr.cache.Store(token, cacheEntry{user: u, expires: time.Now().Add(cacheExpiry)})
+	return u, nil
 }
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/korrel8r/korrel8r/pkg/api/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -149,4 +150,24 @@ func TestTokenReview_EmptyUsername(t *testing.T) {
 	key, err := tr.User("token")
 	require.NoError(t, err)
 	assert.Equal(t, "uid-fallback", key, "should fall back to UID when username is empty")
+	u, err := tr.Authenticate("token")
+	require.NoError(t, err)
+	assert.Empty(t, u.Username, "UID is not a username")
+}
+
+func TestTokenReview_Authenticate(t *testing.T) {
+	cs := fake.NewSimpleClientset()
+	cs.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		tr := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		tr.Status = authenticationv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev", "system:authenticated"}},
+		}
+		return true, tr, nil
+	})
+
+	tr := &TokenReview{clientset: cs}
+	u, err := tr.Authenticate("token")
+	require.NoError(t, err)
+	assert.Equal(t, &auth.User{Name: "alice", Username: "alice", Groups: []string{"dev", "system:authenticated"}}, u)
 }
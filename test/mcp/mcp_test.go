@@ -23,6 +23,7 @@ import (
 	"github.com/korrel8r/korrel8r/internal/pkg/test/mock"
 	"github.com/korrel8r/korrel8r/pkg/api"
 	"github.com/korrel8r/korrel8r/pkg/api/auth"
+	"github.com/korrel8r/korrel8r/pkg/config"
 	"github.com/korrel8r/korrel8r/pkg/engine"
 	"github.com/korrel8r/korrel8r/pkg/korrel8r"
 	mcpserver "github.com/korrel8r/korrel8r/pkg/mcp"
@@ -45,9 +46,17 @@ func TestListTools(t *testing.T) {
 		[]string{
 			mcpserver.GetConsole,
 			mcpserver.ShowInConsole,
+			mcpserver.GetConfigOverlay,
+			mcpserver.SetConfigOverlay,
+			mcpserver.DeleteConfigOverlay,
+			mcpserver.ListRecipes,
+			mcpserver.RunRecipe,
 			mcpserver.CreateNeighborsGraph,
 			mcpserver.CreateGoalsGraph,
 			mcpserver.GetObjects,
+			mcpserver.Resolve,
+			mcpserver.CreateTimeline,
+			mcpserver.FindRootCauses,
 			mcpserver.Help,
 			mcpserver.ListDomainClasses,
 			mcpserver.ListDomains})
@@ -110,6 +119,33 @@ func TestCreateGoalsGraph(t *testing.T) {
 	require.Equal(t, want, got)
 }
 
+func TestCreateTimeline(t *testing.T) {
+	client := newClient(t, newEngine(t))
+	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      mcpserver.CreateTimeline,
+		Arguments: mcpserver.TimelineParams{Start: api.Start{Queries: []string{"mock:a:x"}}},
+	})
+	require.NoError(t, err)
+	b, err := json.Marshal(r.StructuredContent)
+	require.NoError(t, err)
+	// Mock objects have no time.
+	assert.JSONEq(t, `{"entries":[]}`, string(b))
+}
+
+func TestFindRootCauses(t *testing.T) {
+	client := newClient(t, newEngine(t))
+	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      mcpserver.FindRootCauses,
+		Arguments: mcpserver.RootCausesParams{Start: api.Start{Queries: []string{"mock:a:x"}}},
+	})
+	require.NoError(t, err)
+	require.False(t, r.IsError)
+	b, err := json.Marshal(r.StructuredContent)
+	require.NoError(t, err)
+	// Mock objects have no time or status, so there is no evidence for any candidate.
+	assert.JSONEq(t, `{"candidates":[]}`, string(b))
+}
+
 func TestGetObjects(t *testing.T) {
 	client := newClient(t, newEngine(t))
 	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
@@ -134,6 +170,19 @@ func TestGetObjects_empty(t *testing.T) {
 	assert.Equal(t, []any{}, got["objects"])
 }
 
+func TestGetObjects_groupBy(t *testing.T) {
+	client := newClient(t, newEngineMany(t))
+	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      mcpserver.GetObjects,
+		Arguments: mcpserver.ObjectsParams{Query: "mock:a:many", GroupBy: []string{"x"}},
+	})
+	require.NoError(t, err)
+	require.False(t, r.IsError)
+	got := r.StructuredContent.(map[string]any)
+	assert.Equal(t, []any{map[string]any{"fields": map[string]any{"x": ""}, "count": float64(3)}}, got["groups"])
+	assert.NotContains(t, got, "objects")
+}
+
 func TestGetObjects_withConstraint(t *testing.T) {
 	client := newClient(t, newEngineMany(t))
 	r, err := client.CallTool(context.Background(), &mcp.CallToolParams{
@@ -222,7 +271,7 @@ func newRouter(t *testing.T, e *engine.Engine) *gin.Engine {
 	if os.Getenv(gin.EnvGinMode) == "" {
 		gin.SetMode(gin.TestMode)
 	}
-	sessions := session.NewSingleManager(e)
+	sessions := session.NewSingleManager(e, nil)
 	router := gin.New()
 	router.Use(session.Middleware(sessions))
 	_, err := rest.New(sessions, router)
@@ -654,7 +703,7 @@ func newMultiSessionFixture(t *testing.T) *multiSessionFixture {
 	if os.Getenv(gin.EnvGinMode) == "" {
 		gin.SetMode(gin.TestMode)
 	}
-	sessions := session.NewTokenReviewManager(test.FakeTokenReview(), time.Hour, func() (*engine.Engine, error) { return newEngine(t), nil })
+	sessions := session.NewTokenReviewManager(test.FakeTokenReview(), time.Hour, func(*config.Config) (*engine.Engine, error) { return newEngine(t), nil }, nil)
 	router := gin.New()
 	router.Use(session.Middleware(sessions))
 	_, err := rest.New(sessions, router)